@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure/repository"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/service"
+)
+
+const usage = `Usage:
+  backup dump    [db flags] [-file gearpit-backup.json]
+  backup restore [db flags] -file gearpit-backup.json [-policy skip|overwrite|fail]
+
+Use "-file -" for stdin/stdout.`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	// Parse CLI flags
+	var (
+		host     string
+		port     string
+		user     string
+		password string
+		dbname   string
+		sslmode  string
+		file     string
+		policy   string
+	)
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.StringVar(&host, "host", "localhost", "Database host")
+	flags.StringVar(&port, "port", "5432", "Database port")
+	flags.StringVar(&user, "user", "gearpit", "Database user")
+	flags.StringVar(&password, "password", "password", "Database password")
+	flags.StringVar(&dbname, "dbname", "gearpit", "Database name")
+	flags.StringVar(&sslmode, "sslmode", "disable", "SSL mode")
+	flags.StringVar(&file, "file", "-", "Archive path")
+	flags.StringVar(&policy, "policy", string(domain.ConflictSkip), "Conflict policy for restore (skip, overwrite, fail)")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	// Construct DSN
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=Asia/Tokyo",
+		host, user, password, dbname, port, sslmode)
+
+	db, err := infrastructure.InitDB(dsn)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	backupService := service.NewBackupService(repository.NewBackupRepository(db))
+	ctx := context.Background()
+
+	switch command {
+	case "dump":
+		if err := dump(ctx, backupService, file); err != nil {
+			log.Fatalf("Backup failed: %v", err)
+		}
+	case "restore":
+		conflictPolicy, err := domain.ParseConflictPolicy(policy)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := restore(ctx, backupService, file, conflictPolicy); err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func dump(ctx context.Context, s domain.BackupService, path string) error {
+	archive, err := s.CreateBackup(ctx)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive); err != nil {
+		return err
+	}
+	log.Printf("Backup written (schema version %d, %d items, %d trips)", archive.SchemaVersion, len(archive.Items), len(archive.Trips))
+	return nil
+}
+
+func restore(ctx context.Context, s domain.BackupService, path string, policy domain.ConflictPolicy) error {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		in = f
+	}
+
+	var archive domain.BackupArchive
+	if err := json.NewDecoder(in).Decode(&archive); err != nil {
+		return fmt.Errorf("failed to decode archive: %w", err)
+	}
+
+	result, err := s.RestoreBackup(ctx, &archive, policy)
+	if err != nil {
+		return err
+	}
+	for _, t := range result.Tables {
+		log.Printf("%-20s archived=%d restored=%d", t.Table, t.Archived, t.Restored)
+	}
+	log.Printf("Restore completed (policy: %s)", result.Policy)
+	return nil
+}
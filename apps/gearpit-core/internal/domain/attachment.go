@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Register decoders for thumbnails
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// --- Attachments ---
+
+// MaxAttachmentSize limits uploads; files are held in memory while they are processed.
+const MaxAttachmentSize = 20 << 20
+
+// ThumbnailSize is the longest side of generated thumbnails in pixels.
+const ThumbnailSize = 320
+
+// maxThumbnailPixels skips thumbnails for huge images instead of decoding them.
+const maxThumbnailPixels = 50_000_000
+
+// AttachmentOwner names the table an attachment belongs to. Values match TrashEntity.
+type AttachmentOwner string
+
+const (
+	AttachmentOwnerItem           AttachmentOwner = "items"
+	AttachmentOwnerMaintenanceLog AttachmentOwner = "maintenanceLogs"
+)
+
+func ParseAttachmentOwner(s string) (AttachmentOwner, error) {
+	switch AttachmentOwner(s) {
+	case AttachmentOwnerItem, AttachmentOwnerMaintenanceLog:
+		return AttachmentOwner(s), nil
+	}
+	return "", fmt.Errorf("%w: attachments can belong to items or maintenanceLogs, not %q", ErrInvalidInput, s)
+}
+
+// BlobStorage stores attachment content under opaque keys.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns ErrNotFound when the key does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete succeeds when the key is already gone.
+	Delete(ctx context.Context, key string) error
+}
+
+// StorageKey is where the attachment content lives in BlobStorage.
+func (a Attachment) StorageKey() string {
+	return "attachments/" + a.ID
+}
+
+// ThumbnailKey is where the thumbnail lives; only meaningful when HasThumbnail is set.
+func (a Attachment) ThumbnailKey() string {
+	return "attachments/" + a.ID + ".thumb.jpg"
+}
+
+// IsImage reports whether the content type is one the thumbnailer can decode.
+func IsImage(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// DetectContentType sniffs data, ignoring the client-supplied type which browsers often get wrong.
+func DetectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 && !strings.HasPrefix(contentType, "text/") {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// CleanFileName strips directories and control characters from an uploaded file name.
+func CleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		return "attachment"
+	}
+	return name
+}
+
+// MakeThumbnail scales an image so its longest side is at most maxSide and encodes it as JPEG.
+func MakeThumbnail(data []byte, maxSide int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxThumbnailPixels {
+		return nil, fmt.Errorf("image is too large for a thumbnail (%dx%d)", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scaleImage(src, maxSide), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleImage downsamples with a box filter: each target pixel averages the
+// source pixels it covers. Images that already fit are returned as is.
+func scaleImage(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSide && h <= maxSide {
+		return src
+	}
+	tw, th := maxSide, h*maxSide/w
+	if h > w {
+		tw, th = w*maxSide/h, maxSide
+	}
+	if tw < 1 {
+		tw = 1
+	}
+	if th < 1 {
+		th = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			// JPEG has no alpha; composite onto white (RGBA values are premultiplied)
+			white := 0xffff - a/n
+			dst.Set(x, y, color.RGBA64{R: uint16(r/n + white), G: uint16(g/n + white), B: uint16(bl/n + white), A: 0xffff})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestMakeThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 800; x++ {
+			src.Set(x, y, color.RGBA{R: 200, G: 50, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	thumb, err := MakeThumbnail(buf.Bytes(), 320)
+	if err != nil {
+		t.Fatalf("MakeThumbnail() error = %v", err)
+	}
+	if got := DetectContentType(thumb); got != "image/jpeg" {
+		t.Errorf("thumbnail content type = %v, want image/jpeg", got)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 320 || cfg.Height != 160 {
+		t.Errorf("thumbnail size = %dx%d, want 320x160", cfg.Width, cfg.Height)
+	}
+
+	if _, err := MakeThumbnail([]byte("not an image"), 320); err == nil {
+		t.Error("MakeThumbnail() expected error for non-image data")
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"receipt.pdf", "receipt.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\me\photo.jpg`, "photo.jpg"},
+		{"bad\"name\n.png", "badname.png"},
+		{"", "attachment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := CleanFileName(tt.input); got != tt.want {
+				t.Errorf("CleanFileName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAttachmentOwner(t *testing.T) {
+	if _, err := ParseAttachmentOwner("maintenanceLogs"); err != nil {
+		t.Errorf("ParseAttachmentOwner() unexpected error = %v", err)
+	}
+	if _, err := ParseAttachmentOwner("trips"); err == nil {
+		t.Error("ParseAttachmentOwner() expected error for trips")
+	}
+}
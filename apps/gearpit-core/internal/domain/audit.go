@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gorm.io/datatypes"
+)
+
+// --- Audit Log ---
+
+// AuditEntity names an entity whose field changes are recorded. Values match TrashEntity.
+type AuditEntity string
+
+const (
+	AuditItems           AuditEntity = "items"
+	AuditKits            AuditEntity = "kits"
+	AuditLoadouts        AuditEntity = "loadouts"
+	AuditTrips           AuditEntity = "trips"
+	AuditMaintenanceLogs AuditEntity = "maintenanceLogs"
+)
+
+func ParseAuditEntity(s string) (AuditEntity, error) {
+	switch AuditEntity(s) {
+	case AuditItems, AuditKits, AuditLoadouts, AuditTrips, AuditMaintenanceLogs:
+		return AuditEntity(s), nil
+	}
+	return "", fmt.Errorf("%w: history is kept for items, kits, loadouts, trips and maintenanceLogs, not %q", ErrInvalidInput, s)
+}
+
+// DefaultActor is recorded when a request does not say who made it.
+const DefaultActor = "anonymous"
+
+// Fields derived from associations rather than columns.
+const (
+	AuditFieldItemIDs        = "itemIds"        // Kits and loadouts
+	AuditFieldKitIDs         = "kitIds"         // Loadouts
+	AuditFieldItemQuantities = "itemQuantities" // Trips: item ID -> quantity
+)
+
+// auditIgnoredFields are bookkeeping, computed or association fields that are never diffed.
+var auditIgnoredFields = []string{
+	"id", "createdAt", "updatedAt", "deletedAt", "display", "warnings",
+	"items", "kits", "tripItems", "userProfile",
+	"totalWeightGram", "baseWeightGram", "consumableWeightGram", "wornWeightGram", "longWeightGram",
+	"predictedHydrationML", "predictedCalories", "packWeightGram",
+}
+
+// AuditFields maps the JSON name of each audited field to its JSON value.
+type AuditFields map[string]json.RawMessage
+
+// Value returns the field's JSON, or null when it is absent (omitempty fields).
+func (f AuditFields) Value(field string) json.RawMessage {
+	if v, ok := f[field]; ok {
+		return v
+	}
+	return json.RawMessage("null")
+}
+
+func auditFieldsOf(v interface{}) AuditFields {
+	fields := AuditFields{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	for _, name := range auditIgnoredFields {
+		delete(fields, name)
+	}
+	return fields
+}
+
+func (f AuditFields) set(field string, v interface{}) {
+	if data, err := json.Marshal(v); err == nil {
+		f[field] = data
+	}
+}
+
+func (i Item) AuditFields() AuditFields {
+	return auditFieldsOf(i)
+}
+
+func (k Kit) AuditFields() AuditFields {
+	fields := auditFieldsOf(k)
+	fields.set(AuditFieldItemIDs, sortedItemIDs(k.Items))
+	return fields
+}
+
+func (l Loadout) AuditFields() AuditFields {
+	fields := auditFieldsOf(l)
+	fields.set(AuditFieldItemIDs, sortedItemIDs(l.Items))
+	kitIDs := make([]string, 0, len(l.Kits))
+	for _, kit := range l.Kits {
+		kitIDs = append(kitIDs, kit.ID)
+	}
+	sort.Strings(kitIDs)
+	fields.set(AuditFieldKitIDs, kitIDs)
+	return fields
+}
+
+func (t Trip) AuditFields() AuditFields {
+	fields := auditFieldsOf(t)
+	fields.set(AuditFieldItemQuantities, t.ItemQuantities())
+	return fields
+}
+
+func (m MaintenanceLog) AuditFields() AuditFields {
+	return auditFieldsOf(m)
+}
+
+// ItemQuantities maps each packed item to its quantity.
+func (t Trip) ItemQuantities() map[string]int {
+	quantities := map[string]int{}
+	for _, ti := range t.TripItems {
+		quantities[ti.ItemID] = ti.Quantity
+	}
+	return quantities
+}
+
+func sortedItemIDs(items []Item) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DiffAudit returns one entry per changed field, ordered by field name.
+// Entity, actor and time are filled in by the caller.
+func DiffAudit(before, after AuditFields) []AuditEntry {
+	names := map[string]bool{}
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+
+	var entries []AuditEntry
+	for name := range names {
+		oldValue, newValue := before.Value(name), after.Value(name)
+		if JSONEqual(oldValue, newValue) {
+			continue
+		}
+		entries = append(entries, AuditEntry{
+			Field:    name,
+			OldValue: datatypes.JSON(oldValue),
+			NewValue: datatypes.JSON(newValue),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Field < entries[j].Field })
+	return entries
+}
+
+// JSONEqual compares two JSON documents by value, ignoring formatting and key order.
+func JSONEqual(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// SetAuditField sets the column-backed field of the struct pointed to by v from
+// its JSON value. A null value resets the field to its zero value.
+func SetAuditField(v interface{}, field string, value json.RawMessage) error {
+	for _, name := range auditIgnoredFields {
+		if name == field {
+			return fmt.Errorf("%w: field %q cannot be set", ErrInvalidInput, field)
+		}
+	}
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("SetAuditField needs a struct pointer, got %T", v)
+	}
+	if !hasJSONField(target.Elem().Type(), field) {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidInput, field)
+	}
+
+	// Round-trip through JSON into a fresh value so null really clears the field
+	fields := map[string]json.RawMessage{}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if JSONEqual(value, []byte("null")) {
+		delete(fields, field)
+	} else {
+		fields[field] = value
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	fresh := reflect.New(target.Elem().Type())
+	if err := json.Unmarshal(data, fresh.Interface()); err != nil {
+		return fmt.Errorf("%w: invalid value for %s: %v", ErrInvalidInput, field, err)
+	}
+	target.Elem().Set(fresh.Elem())
+	return nil
+}
+
+func hasJSONField(t reflect.Type, field string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRevertible ensures the field still holds the value the entry changed it to,
+// so a revert never silently undoes a later change.
+func (e AuditEntry) CheckRevertible(current AuditFields) error {
+	if !JSONEqual(current.Value(e.Field), e.NewValue) {
+		return fmt.Errorf("%w: %s has changed again since this entry; revert the newer change first", ErrConflict, e.Field)
+	}
+	if JSONEqual(e.OldValue, e.NewValue) {
+		return fmt.Errorf("%w: entry %s changes nothing", ErrInvalidInput, e.ID)
+	}
+	return nil
+}
+
+// DecodeIDs reads an itemIds or kitIds value.
+func DecodeIDs(value json.RawMessage) ([]string, error) {
+	var ids []string
+	if err := json.Unmarshal(value, &ids); err != nil {
+		return nil, fmt.Errorf("%w: invalid id list: %v", ErrInvalidInput, err)
+	}
+	return ids, nil
+}
+
+// DecodeItemQuantities reads an itemQuantities value.
+func DecodeItemQuantities(value json.RawMessage) (map[string]int, error) {
+	quantities := map[string]int{}
+	if JSONEqual(value, []byte("null")) {
+		return quantities, nil
+	}
+	if err := json.Unmarshal(value, &quantities); err != nil {
+		return nil, fmt.Errorf("%w: invalid item quantities: %v", ErrInvalidInput, err)
+	}
+	return quantities, nil
+}
+
+type actorKey struct{}
+type revertOfKey struct{}
+
+// WithActor stores who is making the request; audit entries record it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the requesting actor, or DefaultActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
+
+// WithRevertOf marks changes made in ctx as reverting the audit entry entryID.
+func WithRevertOf(ctx context.Context, entryID string) context.Context {
+	return context.WithValue(ctx, revertOfKey{}, entryID)
+}
+
+// RevertOfFromContext returns the entry being reverted, or nil.
+func RevertOfFromContext(ctx context.Context) *string {
+	if id, ok := ctx.Value(revertOfKey{}).(string); ok && id != "" {
+		return &id
+	}
+	return nil
+}
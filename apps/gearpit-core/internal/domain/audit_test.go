@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDiffAudit(t *testing.T) {
+	price := 12000
+	before := Item{ID: "1", Name: "Tent", WeightGram: 1200, UsageCount: 14, UpdatedAt: time.Now()}
+	after := before
+	after.WeightGram = 1150
+	after.UsageCount = 0
+	after.PurchasePrice = &price
+	after.UpdatedAt = before.UpdatedAt.Add(time.Minute)
+
+	entries := DiffAudit(before.AuditFields(), after.AuditFields())
+
+	want := []struct{ field, old, new string }{
+		{"purchasePrice", "null", "12000"},
+		{"usageCount", "14", "0"},
+		{"weightGram", "1200", "1150"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("DiffAudit() = %+v, want %d entries", entries, len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Field != w.field || string(e.OldValue) != w.old || string(e.NewValue) != w.new {
+			t.Errorf("entry %d = %s %s -> %s, want %s %s -> %s", i, e.Field, e.OldValue, e.NewValue, w.field, w.old, w.new)
+		}
+	}
+}
+
+func TestDiffAudit_Associations(t *testing.T) {
+	before := Loadout{Name: "Alps", Items: []Item{{ID: "b"}, {ID: "a"}}}
+	after := Loadout{Name: "Alps", Items: []Item{{ID: "a"}, {ID: "b"}}, Kits: []Kit{{ID: "k"}}}
+
+	entries := DiffAudit(before.AuditFields(), after.AuditFields())
+
+	if len(entries) != 1 || entries[0].Field != AuditFieldKitIDs {
+		t.Errorf("DiffAudit() = %+v, want only kitIds (item order must not matter)", entries)
+	}
+}
+
+func TestSetAuditField(t *testing.T) {
+	price := 500
+	item := Item{ID: "1", Name: "Stove", PurchasePrice: &price, Currency: "JPY"}
+
+	if err := SetAuditField(&item, "purchasePrice", json.RawMessage("null")); err != nil {
+		t.Fatalf("SetAuditField() error = %v", err)
+	}
+	if item.PurchasePrice != nil {
+		t.Errorf("PurchasePrice = %v, want nil", *item.PurchasePrice)
+	}
+	if err := SetAuditField(&item, "weightGram", json.RawMessage("350")); err != nil || item.WeightGram != 350 {
+		t.Errorf("SetAuditField() weightGram = %v, %v", item.WeightGram, err)
+	}
+	if item.ID != "1" || item.Currency != "JPY" {
+		t.Errorf("other fields changed: %+v", item)
+	}
+
+	for _, field := range []string{"id", "display", "nope"} {
+		if err := SetAuditField(&item, field, json.RawMessage(`"x"`)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("SetAuditField(%q) error = %v, want ErrInvalidInput", field, err)
+		}
+	}
+}
+
+func TestAuditEntry_CheckRevertible(t *testing.T) {
+	entry := AuditEntry{Field: "weightGram", OldValue: []byte("1200"), NewValue: []byte("1150")}
+
+	if err := entry.CheckRevertible(Item{WeightGram: 1150}.AuditFields()); err != nil {
+		t.Errorf("CheckRevertible() error = %v", err)
+	}
+	if err := entry.CheckRevertible(Item{WeightGram: 1100}.AuditFields()); !errors.Is(err, ErrConflict) {
+		t.Errorf("CheckRevertible() error = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Backup & Restore ---
+
+// BackupFormatVersion is bumped when the archive layout itself changes
+// (not when a migration adds columns; see SchemaVersion).
+const BackupFormatVersion = 1
+
+type ConflictPolicy string
+
+const (
+	ConflictSkip      ConflictPolicy = "skip"      // Keep existing rows
+	ConflictOverwrite ConflictPolicy = "overwrite" // Replace existing rows with archived ones
+	ConflictFail      ConflictPolicy = "fail"      // Abort the whole restore
+)
+
+// Join table rows (many2many tables have no model of their own)
+
+type KitItemRow struct {
+	KitID  string `gorm:"type:uuid;primaryKey" json:"kitId"`
+	ItemID string `gorm:"type:uuid;primaryKey" json:"itemId"`
+}
+
+func (KitItemRow) TableName() string {
+	return "kit_items"
+}
+
+type LoadoutKitRow struct {
+	LoadoutID string `gorm:"type:uuid;primaryKey" json:"loadoutId"`
+	KitID     string `gorm:"type:uuid;primaryKey" json:"kitId"`
+}
+
+func (LoadoutKitRow) TableName() string {
+	return "loadout_kits"
+}
+
+type LoadoutItemRow struct {
+	LoadoutID string `gorm:"type:uuid;primaryKey" json:"loadoutId"`
+	ItemID    string `gorm:"type:uuid;primaryKey" json:"itemId"`
+}
+
+func (LoadoutItemRow) TableName() string {
+	return "loadout_items"
+}
+
+// BackupArchive is a full dump of the database.
+// SchemaVersion is the golang-migrate version of the source database.
+type BackupArchive struct {
+	FormatVersion int       `json:"formatVersion"`
+	SchemaVersion uint      `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+
+	UserProfiles      []UserProfile      `json:"userProfiles"`
+	PropertySchemas   []PropertySchema   `json:"propertySchemas"`
+	DepreciationRules []DepreciationRule `json:"depreciationRules"`
+	Locations         []Location         `json:"locations"`
+	Items             []Item             `json:"items"`
+	Kits              []Kit              `json:"kits"`
+	Loadouts          []Loadout          `json:"loadouts"`
+	MaintenanceLogs   []MaintenanceLog   `json:"maintenanceLogs"`
+	Trips             []Trip             `json:"trips"`
+	TripItems         []TripItem         `json:"tripItems"`
+	KitItems          []KitItemRow       `json:"kitItems"`
+	LoadoutKits       []LoadoutKitRow    `json:"loadoutKits"`
+	LoadoutItems      []LoadoutItemRow   `json:"loadoutItems"`
+	Attachments       []Attachment       `json:"attachments"` // Metadata only; file contents stay in attachment storage
+	ItemMoves         []ItemMove         `json:"itemMoves"`
+	Loans             []Loan             `json:"loans"`
+	AuditEntries      []AuditEntry       `json:"auditEntries"`
+	CatalogProducts   []CatalogProduct   `json:"catalogProducts"`
+	ItemComponents    []ItemComponent    `json:"itemComponents"`
+	PCBuilds          []PCBuild          `json:"pcBuilds"`
+	PCBuildParts      []BuildPart        `json:"pcBuildParts"`
+	OdometerReadings  []OdometerReading  `json:"odometerReadings"`
+	ServiceRules      []ServiceRule      `json:"serviceRules"`
+	UsageMeters       []UsageMeter       `json:"usageMeters"`
+	UsageEntries      []UsageEntry       `json:"usageEntries"`
+	TripMeterUsages   []TripMeterUsage   `json:"tripMeterUsages"`
+}
+
+type RestoreTableResult struct {
+	Table    string `json:"table"`
+	Archived int    `json:"archived"`
+	Restored int64  `json:"restored"` // Rows inserted or overwritten
+}
+
+type RestoreResult struct {
+	Policy        ConflictPolicy       `json:"policy"`
+	SchemaVersion uint                 `json:"schemaVersion"`
+	Tables        []RestoreTableResult `json:"tables"`
+}
+
+// ParseConflictPolicy validates a policy name (default: skip).
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch ConflictPolicy(s) {
+	case "", ConflictSkip:
+		return ConflictSkip, nil
+	case ConflictOverwrite:
+		return ConflictOverwrite, nil
+	case ConflictFail:
+		return ConflictFail, nil
+	}
+	return "", fmt.Errorf("%w: unknown conflict policy %q", ErrInvalidInput, s)
+}
+
+// PrepareArchiveForRestore checks that an archive can be restored into a database
+// at currentSchema and fills defaults for columns the source schema did not have yet.
+func PrepareArchiveForRestore(a *BackupArchive, currentSchema uint) error {
+	if a.FormatVersion < 1 || a.FormatVersion > BackupFormatVersion {
+		return fmt.Errorf("%w: unsupported archive format version %d", ErrInvalidInput, a.FormatVersion)
+	}
+	if a.SchemaVersion > currentSchema {
+		return fmt.Errorf("%w: archive schema version %d is newer than database schema version %d",
+			ErrInvalidInput, a.SchemaVersion, currentSchema)
+	}
+
+	// Zero values in older archives map to the column defaults of the current schema
+	for i := range a.UserProfiles {
+		if a.UserProfiles[i].UnitSystem == "" {
+			a.UserProfiles[i].UnitSystem = UnitSystemMetric
+		}
+	}
+	for i := range a.Items {
+		if a.Items[i].WeightType == "" {
+			a.Items[i].WeightType = WeightTypeBase
+		}
+		if a.Items[i].Status == "" {
+			a.Items[i].Status = ItemStatusActive
+		}
+	}
+	for i := range a.Trips {
+		if a.Trips[i].Status == "" {
+			a.Trips[i].Status = "planned"
+		}
+		if a.Trips[i].DurationDays < 1 {
+			a.Trips[i].DurationDays = 1
+		}
+	}
+	// Locations are inserted in batches; parents must not land in a later batch than their children
+	a.Locations = ParentsFirst(a.Locations)
+	for i := range a.TripItems {
+		if a.TripItems[i].Quantity < 1 {
+			a.TripItems[i].Quantity = 1
+		}
+	}
+	return nil
+}
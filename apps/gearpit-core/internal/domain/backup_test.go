@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestPrepareArchiveForRestore(t *testing.T) {
+	tests := []struct {
+		name          string
+		archive       BackupArchive
+		currentSchema uint
+		wantErr       bool
+	}{
+		{"Same Schema", BackupArchive{FormatVersion: 1, SchemaVersion: 1}, 1, false},
+		{"Older Schema", BackupArchive{FormatVersion: 1, SchemaVersion: 1}, 3, false},
+		{"Newer Schema", BackupArchive{FormatVersion: 1, SchemaVersion: 4}, 3, true},
+		{"Missing Format", BackupArchive{SchemaVersion: 1}, 1, true},
+		{"Future Format", BackupArchive{FormatVersion: BackupFormatVersion + 1, SchemaVersion: 1}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PrepareArchiveForRestore(&tt.archive, tt.currentSchema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrepareArchiveForRestore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrepareArchiveForRestore_FillsDefaults(t *testing.T) {
+	archive := BackupArchive{
+		FormatVersion: 1,
+		SchemaVersion: 1,
+		Items:         []Item{{Name: "Tent"}},
+		Trips:         []Trip{{Name: "Day Hike"}},
+		TripItems:     []TripItem{{TripID: "t", ItemID: "i"}},
+	}
+
+	if err := PrepareArchiveForRestore(&archive, 1); err != nil {
+		t.Fatalf("PrepareArchiveForRestore() error = %v", err)
+	}
+	if archive.Items[0].WeightType != WeightTypeBase {
+		t.Errorf("WeightType = %q, want base", archive.Items[0].WeightType)
+	}
+	if archive.Trips[0].Status != "planned" || archive.Trips[0].DurationDays != 1 {
+		t.Errorf("Trip = %s/%d, want planned/1", archive.Trips[0].Status, archive.Trips[0].DurationDays)
+	}
+	if archive.TripItems[0].Quantity != 1 {
+		t.Errorf("Quantity = %d, want 1", archive.TripItems[0].Quantity)
+	}
+}
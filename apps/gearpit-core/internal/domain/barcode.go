@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// --- Barcodes & Product Catalog ---
+
+// NormalizeBarcode validates a UPC/EAN/GTIN and returns it in stored form: digits
+// only, with 12-digit UPC-A codes widened to EAN-13 so both scans of a product
+// match. An empty input yields "".
+func NormalizeBarcode(s string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if digits == "" {
+		return "", nil
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: barcode %q must contain digits only", ErrInvalidInput, s)
+		}
+	}
+	switch len(digits) {
+	case 8, 13, 14:
+	case 12:
+		digits = "0" + digits
+	default:
+		return "", fmt.Errorf("%w: barcode %q must have 8, 12, 13 or 14 digits", ErrInvalidInput, s)
+	}
+	if !validGTINCheckDigit(digits) {
+		return "", fmt.Errorf("%w: barcode %q has a wrong check digit", ErrInvalidInput, s)
+	}
+	return digits, nil
+}
+
+// validGTINCheckDigit applies the GS1 mod-10 check: from the right, the digits
+// before the check digit are weighted 3, 1, 3, ...
+func validGTINCheckDigit(digits string) bool {
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
+
+// GearParams prefills a new item from the catalog entry.
+func (p CatalogProduct) GearParams() CreateGearParams {
+	params := CreateGearParams{
+		Name:         p.Name,
+		Description:  p.Description,
+		Manufacturer: p.Manufacturer,
+		WeightGram:   p.WeightGram,
+		WeightType:   WeightTypeBase,
+		Unit:         UnitGram,
+		Category:     p.Category,
+		Brand:        p.Brand,
+		Barcode:      p.Barcode,
+	}
+	if p.Price != nil {
+		price := *p.Price
+		params.PurchasePrice = &price
+		params.Currency = p.Currency
+	}
+	return params
+}
+
+// BarcodeLookup answers a scan: the catalog entry (if known), a prefilled
+// CreateGearParams for scan-to-add and the inventory items already carrying the code.
+type BarcodeLookup struct {
+	Barcode string
+	Product *CatalogProduct // nil when the catalog does not know the barcode
+	Params  CreateGearParams
+	Items   []Item
+}
+
+type CatalogFormat string
+
+const (
+	CatalogFormatCSV  CatalogFormat = "csv"
+	CatalogFormatJSON CatalogFormat = "json"
+)
+
+func ParseCatalogFormat(s string) (CatalogFormat, error) {
+	switch CatalogFormat(strings.ToLower(s)) {
+	case CatalogFormatCSV:
+		return CatalogFormatCSV, nil
+	case CatalogFormatJSON:
+		return CatalogFormatJSON, nil
+	}
+	return "", fmt.Errorf("%w: catalog format must be csv or json, not %q", ErrInvalidInput, s)
+}
+
+// CatalogImportResult counts imported products. Rows with errors are skipped.
+type CatalogImportResult struct {
+	DryRun    bool             `json:"dryRun"`
+	TotalRows int              `json:"totalRows"`
+	Imported  int              `json:"imported"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// ParseCatalog reads a catalog dump. CSV files have a header naming the columns
+// barcode, name, manufacturer, brand, category, description, weightGram, price
+// (decimal, e.g. "129.95") and currency; only barcode and name are required.
+// JSON files hold an array of CatalogProduct objects. Lines are 1-based; for JSON
+// they are array positions.
+func ParseCatalog(r io.Reader, format CatalogFormat) ([]CatalogProduct, []ImportRowError, error) {
+	if format == CatalogFormatJSON {
+		return parseCatalogJSON(r)
+	}
+	return parseCatalogCSV(r)
+}
+
+func parseCatalogJSON(r io.Reader) ([]CatalogProduct, []ImportRowError, error) {
+	var raw []CatalogProduct
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("%w: catalog must be a JSON array of products: %v", ErrInvalidInput, err)
+	}
+	var products []CatalogProduct
+	var rowErrors []ImportRowError
+	for i, p := range raw {
+		if err := p.normalize(); err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: i + 1, Message: err.Error()})
+			continue
+		}
+		products = append(products, p)
+	}
+	return products, rowErrors, nil
+}
+
+func parseCatalogCSV(r io.Reader) ([]CatalogProduct, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: csv is empty", ErrInvalidInput)
+		}
+		return nil, nil, fmt.Errorf("%w: failed to read csv header: %v", ErrInvalidInput, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"barcode", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: column %q not found in csv header", ErrInvalidInput, required)
+		}
+	}
+
+	var products []CatalogProduct
+	var rowErrors []ImportRowError
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		cell := func(name string) string {
+			idx, ok := columns[strings.ToLower(name)]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		product, err := catalogProductFromCSV(cell)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		products = append(products, product)
+	}
+	return products, rowErrors, nil
+}
+
+func catalogProductFromCSV(cell func(string) string) (CatalogProduct, error) {
+	p := CatalogProduct{
+		Barcode:      cell("barcode"),
+		Name:         cell("name"),
+		Manufacturer: cell("manufacturer"),
+		Brand:        cell("brand"),
+		Category:     cell("category"),
+		Description:  cell("description"),
+		Currency:     cell("currency"),
+	}
+	if raw := cell("weightGram"); raw != "" {
+		grams, err := strconv.Atoi(raw)
+		if err != nil {
+			return p, fmt.Errorf("invalid weightGram %q", raw)
+		}
+		p.WeightGram = grams
+	}
+	if raw := cell("price"); raw != "" {
+		currency, err := NormalizeCurrency(p.Currency)
+		if err != nil {
+			return p, fmt.Errorf("unknown currency %q", p.Currency)
+		}
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		price, err := ParseMoney(raw, currency)
+		if err != nil {
+			return p, fmt.Errorf("invalid price %q", raw)
+		}
+		p.Price = &price
+		p.Currency = currency
+	}
+	return p, p.normalize()
+}
+
+// normalize validates the product and brings barcode and currency into stored form.
+func (p *CatalogProduct) normalize() error {
+	barcode, err := NormalizeBarcode(p.Barcode)
+	if err != nil {
+		return err
+	}
+	if barcode == "" {
+		return errors.New("barcode is required")
+	}
+	p.Barcode = barcode
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if p.WeightGram < 0 {
+		return errors.New("weightGram must not be negative")
+	}
+	if p.Price != nil && *p.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	currency, err := NormalizeCurrency(p.Currency)
+	if err != nil {
+		return fmt.Errorf("unknown currency %q", p.Currency)
+	}
+	if currency == "" && p.Price != nil {
+		currency = DefaultCurrency
+	}
+	p.Currency = currency
+	return nil
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"Empty", "", "", false},
+		{"EAN-13", "4901234567894", "4901234567894", false},
+		{"UPC-A widened to EAN-13", "036000291452", "0036000291452", false},
+		{"EAN-8", "96385074", "96385074", false},
+		{"GTIN-14", "10012345678902", "10012345678902", false},
+		{"Spaces and dashes", " 4-901234-567894 ", "4901234567894", false},
+		{"Wrong check digit", "4901234567895", "", true},
+		{"Letters", "49012345678AB", "", true},
+		{"Wrong length", "12345", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBarcode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeBarcode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("NormalizeBarcode() error = %v, want ErrInvalidInput", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBarcode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCatalogCSV(t *testing.T) {
+	csv := `barcode,name,manufacturer,weightGram,price,currency
+036000291452,Headlamp,Nitecore,45,49.95,USD
+4901234567894,Gas Canister,,230,800,JPY
+4901234567895,Bad Check Digit,,,,
+96385074,,,,,
+10012345678902,Stove,,heavy,,
+`
+	products, rowErrors, err := ParseCatalog(strings.NewReader(csv), CatalogFormatCSV)
+	if err != nil {
+		t.Fatalf("ParseCatalog() error = %v", err)
+	}
+	if len(products) != 2 {
+		t.Fatalf("got %d products, want 2", len(products))
+	}
+	if len(rowErrors) != 3 {
+		t.Fatalf("got %d row errors, want 3", len(rowErrors))
+	}
+
+	headlamp := products[0]
+	if headlamp.Barcode != "0036000291452" {
+		t.Errorf("Barcode = %v, want %v", headlamp.Barcode, "0036000291452")
+	}
+	if headlamp.Price == nil || *headlamp.Price != 4995 || headlamp.Currency != "USD" {
+		t.Errorf("Price = %v %v, want 4995 USD", headlamp.Price, headlamp.Currency)
+	}
+	if headlamp.WeightGram != 45 {
+		t.Errorf("WeightGram = %v, want %v", headlamp.WeightGram, 45)
+	}
+	if rowErrors[0].Line != 4 || rowErrors[1].Line != 5 || rowErrors[2].Line != 6 {
+		t.Errorf("row error lines = %d, %d, %d, want 4, 5, 6", rowErrors[0].Line, rowErrors[1].Line, rowErrors[2].Line)
+	}
+
+	if _, _, err := ParseCatalog(strings.NewReader("name\nTent\n"), CatalogFormatCSV); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ParseCatalog() without barcode column error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestParseCatalogJSON(t *testing.T) {
+	data := `[
+		{"barcode": "4901234567894", "name": "Gas Canister", "price": 800},
+		{"barcode": "4901234567894", "name": ""}
+	]`
+	products, rowErrors, err := ParseCatalog(strings.NewReader(data), CatalogFormatJSON)
+	if err != nil {
+		t.Fatalf("ParseCatalog() error = %v", err)
+	}
+	if len(products) != 1 || len(rowErrors) != 1 {
+		t.Fatalf("got %d products and %d row errors, want 1 and 1", len(products), len(rowErrors))
+	}
+	if products[0].Currency != DefaultCurrency {
+		t.Errorf("Currency = %v, want %v", products[0].Currency, DefaultCurrency)
+	}
+	if rowErrors[0].Line != 2 {
+		t.Errorf("row error line = %d, want 2", rowErrors[0].Line)
+	}
+
+	if _, _, err := ParseCatalog(strings.NewReader(`{"barcode": "1"}`), CatalogFormatJSON); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ParseCatalog() with an object error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestCatalogProductGearParams(t *testing.T) {
+	price := 4995
+	p := CatalogProduct{Barcode: "0036000291452", Name: "Headlamp", WeightGram: 45, Price: &price, Currency: "USD"}
+	params := p.GearParams()
+	if params.Barcode != p.Barcode || params.Name != p.Name || params.WeightGram != 45 {
+		t.Errorf("GearParams() = %+v, want barcode, name and weight copied", params)
+	}
+	if params.PurchasePrice == nil || *params.PurchasePrice != price || params.Currency != "USD" {
+		t.Errorf("GearParams() price = %v %v, want 4995 USD", params.PurchasePrice, params.Currency)
+	}
+	price = 0
+	if *params.PurchasePrice != 4995 {
+		t.Errorf("GearParams() shares the product's price pointer")
+	}
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Composite Items (Bill of Materials) ---
+
+// ComponentNode is an item with its components, as returned for an assembly.
+// TotalWeightGram adds the item's own weight to Quantity x TotalWeightGram of each
+// component, so an assembly's own WeightGram should only hold what is not itemized.
+// Trip, loadout and dashboard totals count assemblies by this rolled-up weight.
+type ComponentNode struct {
+	Item            Item            `json:"item"`
+	Quantity        int             `json:"quantity"` // Per parent; 1 for the root
+	TotalWeightGram int             `json:"totalWeightGram"`
+	Components      []ComponentNode `json:"components"`
+	Display         *WeightDisplay  `json:"display,omitempty"` // Computed
+}
+
+// ValidateComponents checks a new bill of materials for parentID and returns it
+// with item IDs trimmed. edges must hold every existing link below the new
+// components, which is enough to tell whether parentID would become its own part.
+func ValidateComponents(parentID string, components []ItemComponent, edges []ItemComponent) ([]ItemComponent, error) {
+	seen := map[string]bool{}
+	cleaned := make([]ItemComponent, 0, len(components))
+	for _, c := range components {
+		id := strings.TrimSpace(c.ComponentID)
+		switch {
+		case id == "":
+			return nil, fmt.Errorf("%w: component itemId is required", ErrInvalidInput)
+		case id == parentID:
+			return nil, fmt.Errorf("%w: an item cannot be a component of itself", ErrInvalidInput)
+		case seen[id]:
+			return nil, fmt.Errorf("%w: component %s is listed twice", ErrInvalidInput, id)
+		case c.Quantity < 1:
+			return nil, fmt.Errorf("%w: quantity of component %s must be at least 1", ErrInvalidInput, id)
+		}
+		seen[id] = true
+		cleaned = append(cleaned, ItemComponent{ParentID: parentID, ComponentID: id, Quantity: c.Quantity})
+	}
+
+	children := componentChildren(edges)
+	visited := map[string]bool{}
+	var reaches func(id string) bool
+	reaches = func(id string) bool {
+		if id == parentID {
+			return true
+		}
+		if visited[id] {
+			return false
+		}
+		visited[id] = true
+		for _, child := range children[id] {
+			if reaches(child.ComponentID) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c := range cleaned {
+		if reaches(c.ComponentID) {
+			return nil, fmt.Errorf("%w: item %s already contains this item", ErrInvalidInput, c.ComponentID)
+		}
+	}
+	return cleaned, nil
+}
+
+// BuildComponentTree assembles the tree below rootID from the links and the items
+// they reference. Links to items that are missing (e.g. trashed) are left out.
+func BuildComponentTree(rootID string, items map[string]Item, edges []ItemComponent) (*ComponentNode, error) {
+	root, ok := items[rootID]
+	if !ok {
+		return nil, fmt.Errorf("%w: item %s", ErrNotFound, rootID)
+	}
+	children := componentChildren(edges)
+
+	// path guards against cycles written around ValidateComponents
+	path := map[string]bool{}
+	var build func(item Item, quantity int) ComponentNode
+	build = func(item Item, quantity int) ComponentNode {
+		node := ComponentNode{Item: item, Quantity: quantity, TotalWeightGram: item.WeightGram, Components: []ComponentNode{}}
+		path[item.ID] = true
+		for _, edge := range children[item.ID] {
+			child, ok := items[edge.ComponentID]
+			if !ok || path[child.ID] {
+				continue
+			}
+			childNode := build(child, edge.Quantity)
+			node.TotalWeightGram += childNode.TotalWeightGram * edge.Quantity
+			node.Components = append(node.Components, childNode)
+		}
+		delete(path, item.ID)
+		return node
+	}
+	tree := build(root, 1)
+	return &tree, nil
+}
+
+// RolledUpWeights returns the TotalWeightGram of each of rootIDs that has components,
+// computed as BuildComponentTree does.
+func RolledUpWeights(rootIDs []string, items map[string]Item, edges []ItemComponent) map[string]int {
+	children := componentChildren(edges)
+	weights := map[string]int{}
+	for _, id := range rootIDs {
+		if _, ok := items[id]; !ok || len(children[id]) == 0 {
+			continue
+		}
+		tree, err := BuildComponentTree(id, items, edges)
+		if err != nil {
+			continue
+		}
+		weights[id] = tree.TotalWeightGram
+	}
+	return weights
+}
+
+// PackWeightGram is what one of the item adds to a pack: the rolled-up weight of an
+// assembly when it was loaded, otherwise the item's own weight.
+func (i Item) PackWeightGram() int {
+	if i.TotalWeightGram != nil {
+		return *i.TotalWeightGram
+	}
+	return i.WeightGram
+}
+
+func componentChildren(edges []ItemComponent) map[string][]ItemComponent {
+	children := map[string][]ItemComponent{}
+	for _, e := range edges {
+		children[e.ParentID] = append(children[e.ParentID], e)
+	}
+	return children
+}
+
+// ApplyDisplayUnit fills Display for the rolled-up weight and every item in the tree.
+func (n *ComponentNode) ApplyDisplayUnit(unit WeightUnit) {
+	n.Item.ApplyDisplayUnit(unit)
+	for i := range n.Components {
+		n.Components[i].ApplyDisplayUnit(unit)
+	}
+	if unit == "" {
+		return
+	}
+	n.Display = newWeightDisplay(unit, map[string]int{"totalWeightGram": n.TotalWeightGram})
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateComponents(t *testing.T) {
+	// bike -> wheel -> spoke
+	edges := []ItemComponent{
+		{ParentID: "bike", ComponentID: "wheel", Quantity: 2},
+		{ParentID: "wheel", ComponentID: "spoke", Quantity: 32},
+	}
+	tests := []struct {
+		name       string
+		parentID   string
+		components []ItemComponent
+		wantErr    bool
+	}{
+		{"Valid", "spoke", []ItemComponent{{ComponentID: "nipple", Quantity: 1}}, false},
+		{"Empty list", "bike", nil, false},
+		{"Self", "bike", []ItemComponent{{ComponentID: "bike", Quantity: 1}}, true},
+		{"Zero quantity", "bike", []ItemComponent{{ComponentID: "wheel", Quantity: 0}}, true},
+		{"Listed twice", "bike", []ItemComponent{{ComponentID: "wheel", Quantity: 1}, {ComponentID: " wheel", Quantity: 1}}, true},
+		{"Missing ID", "bike", []ItemComponent{{ComponentID: " ", Quantity: 1}}, true},
+		{"Cycle through descendants", "spoke", []ItemComponent{{ComponentID: "bike", Quantity: 1}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateComponents(tt.parentID, tt.components, edges)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateComponents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Errorf("ValidateComponents() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			for _, c := range got {
+				if c.ParentID != tt.parentID {
+					t.Errorf("ValidateComponents() ParentID = %v, want %v", c.ParentID, tt.parentID)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildComponentTree(t *testing.T) {
+	items := map[string]Item{
+		"tent":  {ID: "tent", WeightGram: 0},
+		"body":  {ID: "body", WeightGram: 600},
+		"poles": {ID: "poles", WeightGram: 100},
+		"stake": {ID: "stake", WeightGram: 10},
+	}
+	edges := []ItemComponent{
+		{ParentID: "tent", ComponentID: "body", Quantity: 1},
+		{ParentID: "tent", ComponentID: "poles", Quantity: 2},
+		{ParentID: "tent", ComponentID: "stake", Quantity: 8},
+		{ParentID: "tent", ComponentID: "trashed", Quantity: 1},
+		{ParentID: "body", ComponentID: "tent", Quantity: 1}, // cycle is ignored
+	}
+
+	tree, err := BuildComponentTree("tent", items, edges)
+	if err != nil {
+		t.Fatalf("BuildComponentTree() error = %v", err)
+	}
+	if tree.TotalWeightGram != 880 {
+		t.Errorf("TotalWeightGram = %v, want %v", tree.TotalWeightGram, 880)
+	}
+	if len(tree.Components) != 3 {
+		t.Fatalf("got %d components, want 3", len(tree.Components))
+	}
+	if len(tree.Components[0].Components) != 0 {
+		t.Errorf("body has %d components, want 0", len(tree.Components[0].Components))
+	}
+	if tree.Components[2].Quantity != 8 {
+		t.Errorf("stake Quantity = %v, want %v", tree.Components[2].Quantity, 8)
+	}
+
+	if _, err := BuildComponentTree("missing", items, edges); !errors.Is(err, ErrNotFound) {
+		t.Errorf("BuildComponentTree() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRolledUpWeights(t *testing.T) {
+	items := map[string]Item{
+		"tent":  {ID: "tent", WeightGram: 0},
+		"body":  {ID: "body", WeightGram: 600},
+		"stake": {ID: "stake", WeightGram: 10},
+		"stove": {ID: "stove", WeightGram: 80},
+	}
+	edges := []ItemComponent{
+		{ParentID: "tent", ComponentID: "body", Quantity: 1},
+		{ParentID: "tent", ComponentID: "stake", Quantity: 8},
+	}
+
+	got := RolledUpWeights([]string{"tent", "stove", "trashed"}, items, edges)
+
+	if len(got) != 1 || got["tent"] != 680 {
+		t.Errorf("RolledUpWeights() = %v, want map[tent:680]", got)
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// --- Duplicate Detection ---
+
+const (
+	// DefaultDuplicateMinScore is the score a pair needs to be reported.
+	DefaultDuplicateMinScore = 0.7
+	// duplicateMinNameScore keeps unrelated items by the same maker at the same
+	// weight from pairing up on manufacturer and weight alone.
+	duplicateMinNameScore = 0.5
+
+	duplicateNameWeight         = 0.6
+	duplicateManufacturerWeight = 0.2
+	duplicateWeightWeight       = 0.2
+)
+
+// DuplicateReason explains what made a pair look alike.
+type DuplicateReason string
+
+const (
+	DuplicateSimilarName      DuplicateReason = "similarName"
+	DuplicateSameManufacturer DuplicateReason = "sameManufacturer"
+	DuplicateSimilarWeight    DuplicateReason = "similarWeight"
+)
+
+// DuplicatePair is two items that are probably the same thing. Item is the one
+// created first, the usual survivor of a merge.
+type DuplicatePair struct {
+	Item      Item              `json:"item"`
+	Duplicate Item              `json:"duplicate"`
+	Score     float64           `json:"score"` // 0..1
+	Reasons   []DuplicateReason `json:"reasons"`
+}
+
+// ValidateDuplicateMinScore rejects scores outside (0, 1].
+func ValidateDuplicateMinScore(score float64) error {
+	if score <= 0 || score > 1 {
+		return fmt.Errorf("%w: minScore must be between 0 and 1", ErrInvalidInput)
+	}
+	return nil
+}
+
+// duplicateKey is an item prepared for pairwise comparison.
+type duplicateKey struct {
+	item         Item
+	manufacturer string
+	bigrams      map[string]int
+}
+
+// FindDuplicates scores every pair of items and returns those reaching minScore,
+// best first. Names are compared without the manufacturer, so "Nitecore Headlamp"
+// by Nitecore matches "Headlamp".
+func FindDuplicates(items []Item, minScore float64) []DuplicatePair {
+	keys := make([]duplicateKey, len(items))
+	for i, item := range items {
+		manufacturer := strings.ToLower(strings.TrimSpace(item.Manufacturer))
+		keys[i] = duplicateKey{
+			item:         item,
+			manufacturer: manufacturer,
+			bigrams:      nameBigrams(item.Name, manufacturer),
+		}
+	}
+
+	pairs := []DuplicatePair{}
+	for i := range keys {
+		for j := i + 1; j < len(keys); j++ {
+			pair, ok := scoreDuplicate(keys[i], keys[j])
+			if ok && pair.Score >= minScore {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	return pairs
+}
+
+func scoreDuplicate(a, b duplicateKey) (DuplicatePair, bool) {
+	name := diceCoefficient(a.bigrams, b.bigrams)
+	if name < duplicateMinNameScore {
+		return DuplicatePair{}, false
+	}
+	reasons := []DuplicateReason{DuplicateSimilarName}
+
+	// Unknown values are neither evidence for nor against a match
+	manufacturer := 0.5
+	switch {
+	case a.manufacturer != "" && a.manufacturer == b.manufacturer:
+		manufacturer = 1
+		reasons = append(reasons, DuplicateSameManufacturer)
+	case a.manufacturer != "" && b.manufacturer != "":
+		manufacturer = 0
+	}
+
+	weight := 0.5
+	if wa, wb := a.item.WeightGram, b.item.WeightGram; wa > 0 && wb > 0 {
+		weight = 1 - math.Abs(float64(wa-wb))/math.Max(float64(wa), float64(wb))
+		if weight >= 0.95 {
+			weight = 1
+			reasons = append(reasons, DuplicateSimilarWeight)
+		}
+	}
+
+	first, second := a.item, b.item
+	if second.CreatedAt.Before(first.CreatedAt) {
+		first, second = second, first
+	}
+	score := duplicateNameWeight*name + duplicateManufacturerWeight*manufacturer + duplicateWeightWeight*weight
+	return DuplicatePair{
+		Item:      first,
+		Duplicate: second,
+		Score:     math.Round(score*100) / 100,
+		Reasons:   reasons,
+	}, true
+}
+
+// nameBigrams returns the character bigrams of the name's words, leaving out
+// words that repeat the manufacturer.
+func nameBigrams(name, manufacturer string) map[string]int {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var kept []string
+	for _, word := range words {
+		if word != manufacturer {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		kept = words
+	}
+
+	bigrams := map[string]int{}
+	for _, word := range kept {
+		runes := []rune(word)
+		if len(runes) == 1 {
+			bigrams[word]++
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			bigrams[string(runes[i:i+2])]++
+		}
+	}
+	return bigrams
+}
+
+// diceCoefficient is 2*|A∩B| / (|A|+|B|) over bigram multisets.
+func diceCoefficient(a, b map[string]int) float64 {
+	var total, shared int
+	for gram, n := range a {
+		total += n
+		if m, ok := b[gram]; ok {
+			shared += min(n, m)
+		}
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(shared) / float64(total)
+}
+
+// ValidateMerge checks that a merge names a survivor and at least one other item.
+// It returns the duplicate IDs without repeats.
+func ValidateMerge(survivorID string, duplicateIDs []string) ([]string, error) {
+	if survivorID == "" {
+		return nil, fmt.Errorf("%w: survivor item is required", ErrInvalidInput)
+	}
+	seen := map[string]bool{}
+	var ids []string
+	for _, id := range duplicateIDs {
+		id = strings.TrimSpace(id)
+		if id == survivorID {
+			return nil, fmt.Errorf("%w: an item cannot be merged into itself", ErrInvalidInput)
+		}
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: duplicateIds must name at least one item", ErrInvalidInput)
+	}
+	return ids, nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	now := time.Now()
+	items := []Item{
+		{ID: "1", Name: "Headlamp", WeightGram: 80, CreatedAt: now},
+		{ID: "2", Name: "Nitecore Headlamp", Manufacturer: "Nitecore", WeightGram: 82, CreatedAt: now.Add(-time.Hour)},
+		{ID: "3", Name: "Head Lamp", Manufacturer: "Petzl", WeightGram: 150, CreatedAt: now},
+		{ID: "4", Name: "Sleeping Bag", Manufacturer: "Nitecore", WeightGram: 80, CreatedAt: now},
+	}
+
+	pairs := FindDuplicates(items, DefaultDuplicateMinScore)
+
+	if len(pairs) != 2 {
+		t.Fatalf("FindDuplicates() = %+v, want 2 pairs", pairs)
+	}
+	best := pairs[0]
+	if best.Item.ID != "2" || best.Duplicate.ID != "1" {
+		t.Errorf("best pair = %s/%s, want the older item 2 first", best.Item.ID, best.Duplicate.ID)
+	}
+	if best.Score < 0.8 || len(best.Reasons) != 2 {
+		t.Errorf("Score = %v, Reasons = %v, want >= 0.8 with similar name and weight", best.Score, best.Reasons)
+	}
+	// "Head Lamp" still pairs with "Headlamp" despite the weight; the makers differ from Nitecore
+	if pairs[1].Item.ID != "1" || pairs[1].Duplicate.ID != "3" {
+		t.Errorf("second pair = %s/%s, want 1/3", pairs[1].Item.ID, pairs[1].Duplicate.ID)
+	}
+}
+
+func TestValidateMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		survivor   string
+		duplicates []string
+		want       int
+		wantErr    bool
+	}{
+		{"dedupes ids", "a", []string{"b", "b", " c "}, 2, false},
+		{"no duplicates", "a", []string{""}, 0, true},
+		{"merge into itself", "a", []string{"a"}, 0, true},
+		{"no survivor", "", []string{"b"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateMerge(tt.survivor, tt.duplicates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMerge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("ValidateMerge() = %v, want %d ids", got, tt.want)
+			}
+		})
+	}
+}
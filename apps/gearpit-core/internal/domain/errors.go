@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+// Sentinel errors shared across layers. Wrap them with fmt.Errorf("%w: ...")
+// so handlers can map them to HTTP status codes with errors.Is.
+var (
+	// ErrInvalidInput marks errors caused by bad user input (400).
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrConflict marks writes that collide with existing data (409).
+	ErrConflict = errors.New("conflict")
+	// ErrNotFound marks lookups that matched nothing (404).
+	ErrNotFound = errors.New("not found")
+)
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// --- Expiry ---
+
+// DefaultExpiryWindowDays is the look-ahead of the expiring items list.
+const DefaultExpiryWindowDays = 30
+
+// ExpiryAlert is an item that has expired or expires within the requested window.
+type ExpiryAlert struct {
+	Item     Item `json:"item"`
+	DaysLeft int  `json:"daysLeft"` // Negative once expired
+	Expired  bool `json:"expired"`
+}
+
+// ExpiryDaysLeft returns the whole days until the item expires (negative once
+// expired), or nil when it does not expire.
+func (i Item) ExpiryDaysLeft(now time.Time) *int {
+	if i.ExpiresAt == nil {
+		return nil
+	}
+	days := int(math.Ceil(i.ExpiresAt.Sub(now).Hours() / 24))
+	return &days
+}
+
+// --- Trip Warnings ---
+
+type TripWarningType string
+
+const (
+	TripWarningExpires TripWarningType = "expires" // Item expires before the trip ends
+)
+
+// TripWarning flags a problem with a trip's gear. Warnings never block planning.
+type TripWarning struct {
+	Type     TripWarningType `json:"type"`
+	ItemID   string          `json:"itemId"`
+	ItemName string          `json:"itemName"`
+	Message  string          `json:"message"`
+}
+
+// ExpiryWarnings lists trip items that expire before EndDate (or StartDate when no end is set).
+func (t Trip) ExpiryWarnings() []TripWarning {
+	end := t.EndDate
+	if end.IsZero() {
+		end = t.StartDate
+	}
+	if end.IsZero() {
+		return nil
+	}
+
+	var warnings []TripWarning
+	for _, ti := range t.TripItems {
+		expires := ti.Item.ExpiresAt
+		if expires == nil || !expires.Before(end) {
+			continue
+		}
+		name := ti.Item.Name
+		if ti.Item.LotNumber != "" {
+			name = fmt.Sprintf("%s (lot %s)", name, ti.Item.LotNumber)
+		}
+		warnings = append(warnings, TripWarning{
+			Type:     TripWarningExpires,
+			ItemID:   ti.Item.ID,
+			ItemName: ti.Item.Name,
+			Message:  fmt.Sprintf("%s expires on %s, before the trip ends", name, expires.Format("2006-01-02")),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrip_ExpiryWarnings(t *testing.T) {
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 3)
+	at := func(tm time.Time) *time.Time { return &tm }
+
+	items := []TripItem{
+		{Item: Item{ID: "fuel", Name: "Gas canister", ExpiresAt: at(start.AddDate(0, 0, 1)), LotNumber: "L42"}},
+		{Item: Item{ID: "meds", Name: "First aid kit", ExpiresAt: at(end)}},
+		{Item: Item{ID: "tent", Name: "Tent"}},
+	}
+
+	tests := []struct {
+		name string
+		trip Trip
+		want int
+	}{
+		{"expires before end", Trip{StartDate: start, EndDate: end, TripItems: items}, 1},
+		{"falls back to start date", Trip{StartDate: start, TripItems: items}, 0},
+		{"undated trip", Trip{TripItems: items}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.trip.ExpiryWarnings()
+			if len(got) != tt.want {
+				t.Fatalf("ExpiryWarnings() = %v, want %d warnings", got, tt.want)
+			}
+		})
+	}
+
+	warnings := Trip{EndDate: end, TripItems: items}.ExpiryWarnings()
+	if warnings[0].Type != TripWarningExpires || warnings[0].ItemID != "fuel" || !strings.Contains(warnings[0].Message, "lot L42") {
+		t.Errorf("ExpiryWarnings()[0] = %+v", warnings[0])
+	}
+}
+
+func TestItem_ExpiryDaysLeft(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	past := now.AddDate(0, 0, -2)
+
+	if got := (Item{}).ExpiryDaysLeft(now); got != nil {
+		t.Errorf("ExpiryDaysLeft() = %v, want nil", *got)
+	}
+	if got := (Item{ExpiresAt: &past}).ExpiryDaysLeft(now); got == nil || *got != -2 {
+		t.Errorf("ExpiryDaysLeft() = %v, want -2", got)
+	}
+}
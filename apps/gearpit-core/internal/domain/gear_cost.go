@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Money ---
+
+// Amounts (PurchasePrice, MaintenanceLog.Cost, SalePrice) are integers in the
+// currency's minor unit: yen for JPY, cents for USD.
+
+// DefaultCurrency applies when an item has a price but no currency.
+const DefaultCurrency = "JPY"
+
+// currencyDecimals lists minor unit exponents that differ from 2.
+var currencyDecimals = map[string]int{
+	"JPY": 0, "KRW": 0, "VND": 0, "ISK": 0, "CLP": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyDecimals returns the number of minor unit digits of a currency.
+func CurrencyDecimals(currency string) int {
+	if d, ok := currencyDecimals[currency]; ok {
+		return d
+	}
+	return 2
+}
+
+// NormalizeCurrency upper-cases and validates an ISO 4217 code. Empty stays empty.
+func NormalizeCurrency(s string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if code == "" {
+		return "", nil
+	}
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w: invalid currency %q", ErrInvalidInput, s)
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", fmt.Errorf("%w: invalid currency %q", ErrInvalidInput, s)
+		}
+	}
+	return code, nil
+}
+
+// ParseMoney converts a decimal amount such as "129.95" or "$1,200" to minor units.
+func ParseMoney(s, currency string) (int, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+		return -1
+	}, s)
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid amount %q", ErrInvalidInput, s)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("%w: amount must not be negative", ErrInvalidInput)
+	}
+	return int(math.Round(value * math.Pow10(CurrencyDecimals(currency)))), nil
+}
+
+// FormatMoney renders minor units as a decimal string ("129.95").
+func FormatMoney(amount int, currency string) string {
+	decimals := CurrencyDecimals(currency)
+	return strconv.FormatFloat(float64(amount)/math.Pow10(decimals), 'f', decimals, 64)
+}
+
+// --- Cost Analytics ---
+
+// ItemCostInput is the raw data behind an item's cost figures.
+type ItemCostInput struct {
+	ItemID          string
+	Name            string
+	Currency        string
+	PurchasePrice   *int
+	MaintenanceCost int // Sum of MaintenanceLog.Cost
+	Uses            int // UsageCount
+	DaysUsed        int // DurationDays of completed trips that carried the item
+}
+
+// ItemCost shows whether an item pays off. Per-use and per-day figures are nil
+// until the item has been used.
+type ItemCost struct {
+	ItemID          string   `json:"itemId"`
+	Name            string   `json:"name"`
+	Currency        string   `json:"currency"`
+	PurchasePrice   int      `json:"purchasePrice"`
+	MaintenanceCost int      `json:"maintenanceCost"`
+	TotalCost       int      `json:"totalCost"`
+	Uses            int      `json:"uses"`
+	DaysUsed        int      `json:"daysUsed"`
+	CostPerUse      *float64 `json:"costPerUse,omitempty"`
+	CostPerDay      *float64 `json:"costPerDay,omitempty"`
+}
+
+// CurrencyCostStat aggregates item costs of one currency; amounts are never mixed across currencies.
+// BestValue/WorstValue rank used items by cost per use.
+type CurrencyCostStat struct {
+	Currency        string     `json:"currency"`
+	ItemCount       int        `json:"itemCount"`
+	UnusedItems     int        `json:"unusedItems"`
+	PurchaseTotal   int        `json:"purchaseTotal"`
+	MaintenanceCost int        `json:"maintenanceCost"`
+	TotalCost       int        `json:"totalCost"`
+	Uses            int        `json:"uses"`
+	CostPerUse      *float64   `json:"costPerUse,omitempty"`
+	BestValue       []ItemCost `json:"bestValue"`
+	WorstValue      []ItemCost `json:"worstValue"`
+}
+
+// ComputeItemCost derives totals and per-use figures. Items without a currency use DefaultCurrency.
+func ComputeItemCost(in ItemCostInput) ItemCost {
+	cost := ItemCost{
+		ItemID:          in.ItemID,
+		Name:            in.Name,
+		Currency:        in.Currency,
+		MaintenanceCost: in.MaintenanceCost,
+		Uses:            in.Uses,
+		DaysUsed:        in.DaysUsed,
+	}
+	if cost.Currency == "" {
+		cost.Currency = DefaultCurrency
+	}
+	if in.PurchasePrice != nil {
+		cost.PurchasePrice = *in.PurchasePrice
+	}
+	cost.TotalCost = cost.PurchasePrice + cost.MaintenanceCost
+	if in.Uses > 0 {
+		v := roundCost(float64(cost.TotalCost) / float64(in.Uses))
+		cost.CostPerUse = &v
+	}
+	if in.DaysUsed > 0 {
+		v := roundCost(float64(cost.TotalCost) / float64(in.DaysUsed))
+		cost.CostPerDay = &v
+	}
+	return cost
+}
+
+// SummarizeCosts groups item costs by currency, keeping the topN best and worst
+// value items of each. Items without any cost are ignored.
+func SummarizeCosts(inputs []ItemCostInput, topN int) []CurrencyCostStat {
+	byCurrency := map[string]*CurrencyCostStat{}
+	ranked := map[string][]ItemCost{}
+
+	for _, in := range inputs {
+		cost := ComputeItemCost(in)
+		if cost.TotalCost == 0 {
+			continue
+		}
+		stat, ok := byCurrency[cost.Currency]
+		if !ok {
+			stat = &CurrencyCostStat{Currency: cost.Currency, BestValue: []ItemCost{}, WorstValue: []ItemCost{}}
+			byCurrency[cost.Currency] = stat
+		}
+		stat.ItemCount++
+		stat.PurchaseTotal += cost.PurchasePrice
+		stat.MaintenanceCost += cost.MaintenanceCost
+		stat.TotalCost += cost.TotalCost
+		stat.Uses += cost.Uses
+		if cost.Uses == 0 {
+			stat.UnusedItems++
+			continue
+		}
+		ranked[cost.Currency] = append(ranked[cost.Currency], cost)
+	}
+
+	stats := make([]CurrencyCostStat, 0, len(byCurrency))
+	for currency, stat := range byCurrency {
+		if stat.Uses > 0 {
+			v := roundCost(float64(stat.TotalCost) / float64(stat.Uses))
+			stat.CostPerUse = &v
+		}
+		items := ranked[currency]
+		sort.SliceStable(items, func(i, j int) bool { return *items[i].CostPerUse < *items[j].CostPerUse })
+		for i := 0; i < len(items) && i < topN; i++ {
+			stat.BestValue = append(stat.BestValue, items[i])
+			stat.WorstValue = append(stat.WorstValue, items[len(items)-1-i])
+		}
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Currency < stats[j].Currency })
+	return stats
+}
+
+func roundCost(v float64) float64 {
+	return math.Round(v*100) / 100
+}
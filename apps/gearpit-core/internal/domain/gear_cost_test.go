@@ -0,0 +1,110 @@
+package domain
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		want     int
+		wantErr  bool
+	}{
+		{"129.95", "USD", 12995, false},
+		{"$1,200", "USD", 120000, false},
+		{"15800", "JPY", 15800, false},
+		{"1.2345", "BHD", 1235, false},
+		{"-5", "USD", 0, true},
+		{"abc", "USD", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMoney(tt.input, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	if got := FormatMoney(12995, "USD"); got != "129.95" {
+		t.Errorf("FormatMoney() = %v, want %v", got, "129.95")
+	}
+	if got := FormatMoney(15800, "JPY"); got != "15800" {
+		t.Errorf("FormatMoney() = %v, want %v", got, "15800")
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	if got, err := NormalizeCurrency(" usd "); err != nil || got != "USD" {
+		t.Errorf("NormalizeCurrency() = %v, %v, want USD", got, err)
+	}
+	if _, err := NormalizeCurrency("dollars"); err == nil {
+		t.Error("NormalizeCurrency() expected error for invalid code")
+	}
+}
+
+func TestComputeItemCost(t *testing.T) {
+	price := 30000
+	cost := ComputeItemCost(ItemCostInput{PurchasePrice: &price, MaintenanceCost: 6000, Uses: 12, DaysUsed: 24})
+
+	if cost.Currency != DefaultCurrency {
+		t.Errorf("Currency = %v, want %v", cost.Currency, DefaultCurrency)
+	}
+	if cost.TotalCost != 36000 {
+		t.Errorf("TotalCost = %v, want %v", cost.TotalCost, 36000)
+	}
+	if cost.CostPerUse == nil || *cost.CostPerUse != 3000 {
+		t.Errorf("CostPerUse = %v, want 3000", cost.CostPerUse)
+	}
+	if cost.CostPerDay == nil || *cost.CostPerDay != 1500 {
+		t.Errorf("CostPerDay = %v, want 1500", cost.CostPerDay)
+	}
+
+	unused := ComputeItemCost(ItemCostInput{PurchasePrice: &price})
+	if unused.CostPerUse != nil || unused.CostPerDay != nil {
+		t.Errorf("unused item should have no per-use cost, got %v / %v", unused.CostPerUse, unused.CostPerDay)
+	}
+}
+
+func TestSummarizeCosts(t *testing.T) {
+	p := func(v int) *int { return &v }
+	inputs := []ItemCostInput{
+		{ItemID: "tent", Currency: "JPY", PurchasePrice: p(60000), Uses: 20},  // 3000/use
+		{ItemID: "stove", Currency: "JPY", PurchasePrice: p(8000), Uses: 40},  // 200/use
+		{ItemID: "skis", Currency: "JPY", PurchasePrice: p(120000), Uses: 10}, // 12000/use
+		{ItemID: "boots", Currency: "JPY", PurchasePrice: p(40000)},           // unused
+		{ItemID: "pack", Currency: "USD", PurchasePrice: p(25000), Uses: 5},   // separate currency
+		{ItemID: "spork", Currency: "JPY"},                                    // no cost
+	}
+
+	stats := SummarizeCosts(inputs, 2)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 currencies, got %d", len(stats))
+	}
+
+	jpy := stats[0]
+	if jpy.Currency != "JPY" {
+		t.Fatalf("stats[0].Currency = %v, want JPY", jpy.Currency)
+	}
+	if jpy.ItemCount != 4 || jpy.UnusedItems != 1 {
+		t.Errorf("ItemCount/UnusedItems = %d/%d, want 4/1", jpy.ItemCount, jpy.UnusedItems)
+	}
+	if jpy.TotalCost != 228000 {
+		t.Errorf("TotalCost = %v, want %v", jpy.TotalCost, 228000)
+	}
+	if len(jpy.BestValue) != 2 || jpy.BestValue[0].ItemID != "stove" {
+		t.Errorf("BestValue = %+v, want stove first", jpy.BestValue)
+	}
+	if len(jpy.WorstValue) != 2 || jpy.WorstValue[0].ItemID != "skis" {
+		t.Errorf("WorstValue = %+v, want skis first", jpy.WorstValue)
+	}
+
+	if stats[1].Currency != "USD" || stats[1].TotalCost != 25000 {
+		t.Errorf("USD stat = %+v", stats[1])
+	}
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Export ---
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV         ExportFormat = "csv"
+	ExportFormatJSON        ExportFormat = "json"
+	ExportFormatLighterPack ExportFormat = "lighterpack"
+	ExportFormatHTML        ExportFormat = "html" // Printable reports only
+)
+
+type ExportEntity string
+
+const (
+	ExportEntityItems    ExportEntity = "items"
+	ExportEntityKits     ExportEntity = "kits"
+	ExportEntityLoadouts ExportEntity = "loadouts"
+	ExportEntityTrips    ExportEntity = "trips"
+)
+
+// ExportFile is a rendered export ready to be sent as a download.
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// PackingListRow is one line of a flattened packing list.
+// Group is the kit, loadout or trip name (empty for a plain inventory export).
+type PackingListRow struct {
+	Group    string
+	Item     Item
+	Quantity int
+}
+
+var itemsCSVHeader = []string{
+	"group", "id", "name", "description", "manufacturer", "category", "brand", "tags",
+	"weightGram", "weightType", "quantity", "usageCount", "maintenanceInterval",
+	"purchasePrice", "currency", "purchaseDate", "vendor", "storage",
+	"serialNumber", "warrantyExpiresAt", "expiresAt", "lotNumber",
+	"stockQuantity", "reorderThreshold", "barcode",
+}
+
+// WriteItemsCSV writes packing list rows with every Item column.
+func WriteItemsCSV(w io.Writer, rows []PackingListRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(itemsCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		props := row.Item.PropertyMap()
+		record := []string{
+			row.Group,
+			row.Item.ID,
+			row.Item.Name,
+			row.Item.Description,
+			row.Item.Manufacturer,
+			stringProperty(props, "category"),
+			stringProperty(props, "brand"),
+			strings.Join(stringSliceProperty(props, "tags"), ";"),
+			strconv.Itoa(row.Item.WeightGram),
+			string(row.Item.WeightType),
+			strconv.Itoa(row.Quantity),
+			strconv.Itoa(row.Item.UsageCount),
+			strconv.Itoa(row.Item.MaintenanceInterval),
+			lighterPackPrice(row.Item),
+			row.Item.Currency,
+			formatDate(row.Item.PurchaseDate),
+			row.Item.Vendor,
+			row.Item.Storage,
+			row.Item.SerialNumber,
+			formatDate(row.Item.WarrantyExpiresAt),
+			formatDate(row.Item.ExpiresAt),
+			row.Item.LotNumber,
+			optionalInt(row.Item.StockQuantity),
+			strconv.Itoa(row.Item.ReorderThreshold),
+			row.Item.Barcode,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+var lighterPackHeader = []string{"Item Name", "Category", "desc", "qty", "weight", "unit", "url", "price", "worn", "consumable"}
+
+// WriteLighterPackCSV writes rows in the format accepted by LighterPack's CSV import.
+// ParseLighterPackCSV reads the same format back.
+func WriteLighterPackCSV(w io.Writer, rows []PackingListRow) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(lighterPackHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		category := row.Item.PropertyString("category")
+		if category == "" {
+			category = row.Group
+		}
+		var worn, consumable string
+		switch row.Item.WeightType {
+		case WeightTypeWorn:
+			worn = "Worn"
+		case WeightTypeConsumable:
+			consumable = "Consumable"
+		}
+		record := []string{
+			row.Item.Name,
+			category,
+			row.Item.Description,
+			strconv.Itoa(row.Quantity),
+			strconv.Itoa(row.Item.WeightGram),
+			string(UnitGram),
+			"",
+			lighterPackPrice(row.Item),
+			worn,
+			consumable,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// lighterPackPrice renders the purchase price as a plain decimal (LighterPack has no currency column).
+func lighterPackPrice(item Item) string {
+	if item.PurchasePrice == nil {
+		return ""
+	}
+	currency := item.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return FormatMoney(*item.PurchasePrice, currency)
+}
+
+// ParseExportFormat validates a format query parameter (default: json).
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch ExportFormat(strings.ToLower(s)) {
+	case "", ExportFormatJSON:
+		return ExportFormatJSON, nil
+	case ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatLighterPack:
+		return ExportFormatLighterPack, nil
+	case ExportFormatHTML:
+		return ExportFormatHTML, nil
+	}
+	return "", fmt.Errorf("%w: unsupported export format %q", ErrInvalidInput, s)
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func stringProperty(props map[string]interface{}, key string) string {
+	if v, ok := props[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func stringSliceProperty(props map[string]interface{}, key string) []string {
+	raw, ok := props[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
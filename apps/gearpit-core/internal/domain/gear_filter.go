@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// --- Gear Listing ---
+
+// MaxGearPageSize caps Limit so a single page stays cheap to render.
+const MaxGearPageSize = 200
+
+type GearSortField string
+
+const (
+	GearSortName       GearSortField = "name"
+	GearSortWeight     GearSortField = "weight"
+	GearSortCreatedAt  GearSortField = "createdAt"
+	GearSortUsageCount GearSortField = "usageCount"
+)
+
+// GearFilter narrows and orders the item list. Zero values mean "no constraint".
+type GearFilter struct {
+	Query          string // ILIKE on name, description and manufacturer
+	WeightTypes    []WeightType
+	Category       string
+	Brand          string
+	Tags           []string // Items must carry all tags
+	MinWeightGram  *int
+	MaxWeightGram  *int
+	MinUsageCount  *int
+	MaintenanceDue bool // usageCount has reached a non-zero maintenanceInterval
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	LocationID     string // Includes sub-locations; NoLocation selects items without one
+	// Statuses selects lifecycle states. When empty, retired/sold items are
+	// left out unless IncludeInactive is set.
+	Statuses        []ItemStatus
+	IncludeInactive bool
+
+	SortBy   GearSortField
+	SortDesc bool
+	Limit    int    // 0 returns all matches
+	Cursor   string // NextCursor of the previous page
+}
+
+type GearPage struct {
+	Items      []Item `json:"items"`
+	Total      int64  `json:"total"` // Matches across all pages
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// Normalize applies defaults (newest first) and validates sort, limit and ranges.
+func (f *GearFilter) Normalize() error {
+	if f.SortBy == "" {
+		f.SortBy = GearSortCreatedAt
+		f.SortDesc = true
+	}
+	switch f.SortBy {
+	case GearSortName, GearSortWeight, GearSortCreatedAt, GearSortUsageCount:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidInput, f.SortBy)
+	}
+	if f.Limit < 0 || f.Limit > MaxGearPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxGearPageSize)
+	}
+	if f.Cursor != "" && f.Limit == 0 {
+		return fmt.Errorf("%w: cursor requires a limit", ErrInvalidInput)
+	}
+	for _, wt := range f.WeightTypes {
+		if _, ok := ParseWeightType(string(wt)); !ok {
+			return fmt.Errorf("%w: unknown weight type %q", ErrInvalidInput, wt)
+		}
+	}
+	for _, status := range f.Statuses {
+		if _, err := ParseItemStatus(string(status)); err != nil {
+			return err
+		}
+	}
+	if f.MinWeightGram != nil && f.MaxWeightGram != nil && *f.MinWeightGram > *f.MaxWeightGram {
+		return fmt.Errorf("%w: minWeight is greater than maxWeight", ErrInvalidInput)
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && f.CreatedAfter.After(*f.CreatedBefore) {
+		return fmt.Errorf("%w: createdAfter is later than createdBefore", ErrInvalidInput)
+	}
+	return nil
+}
+
+// gearCursor is the keyset position after the last item of a page.
+// Sort is stored so a cursor cannot be replayed against a different ordering.
+type gearCursor struct {
+	Sort  GearSortField   `json:"s"`
+	Desc  bool            `json:"d"`
+	Value json.RawMessage `json:"v"`
+	ID    string          `json:"id"`
+}
+
+// EncodeCursor returns the cursor that continues after item.
+func (f GearFilter) EncodeCursor(item Item) string {
+	var value interface{}
+	switch f.SortBy {
+	case GearSortName:
+		value = item.Name
+	case GearSortWeight:
+		value = item.WeightGram
+	case GearSortUsageCount:
+		value = item.UsageCount
+	default:
+		// A NULL created_at sorts as the epoch (see gearSortColumns in the repository)
+		created := item.CreatedAt
+		if created.IsZero() {
+			created = time.Unix(0, 0).UTC()
+		}
+		value = created
+	}
+	raw, _ := json.Marshal(value)
+	data, _ := json.Marshal(gearCursor{Sort: f.SortBy, Desc: f.SortDesc, Value: raw, ID: item.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor returns the typed sort value and item ID stored in f.Cursor.
+func (f GearFilter) DecodeCursor() (interface{}, string, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+	data, err := base64.RawURLEncoding.DecodeString(f.Cursor)
+	if err != nil {
+		return nil, "", invalid
+	}
+	var c gearCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, "", invalid
+	}
+	if c.Sort != f.SortBy || c.Desc != f.SortDesc {
+		return nil, "", fmt.Errorf("%w: cursor was issued for a different sort order", ErrInvalidInput)
+	}
+
+	var value interface{}
+	switch f.SortBy {
+	case GearSortName:
+		var v string
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	case GearSortWeight, GearSortUsageCount:
+		var v int
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	default:
+		var v time.Time
+		err = json.Unmarshal(c.Value, &v)
+		value = v
+	}
+	if err != nil {
+		return nil, "", invalid
+	}
+	return value, c.ID, nil
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGearFilter_Normalize(t *testing.T) {
+	minW, maxW := 500, 100
+	tests := []struct {
+		name    string
+		filter  GearFilter
+		wantErr bool
+	}{
+		{"Defaults", GearFilter{}, false},
+		{"Valid Sort", GearFilter{SortBy: GearSortWeight, Limit: 50}, false},
+		{"Unknown Sort", GearFilter{SortBy: "price"}, true},
+		{"Limit Too Large", GearFilter{Limit: MaxGearPageSize + 1}, true},
+		{"Cursor Without Limit", GearFilter{Cursor: "abc"}, true},
+		{"Unknown Weight Type", GearFilter{WeightTypes: []WeightType{"heavy"}}, true},
+		{"Inverted Weight Range", GearFilter{MinWeightGram: &minW, MaxWeightGram: &maxW}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	f := GearFilter{}
+	_ = f.Normalize()
+	if f.SortBy != GearSortCreatedAt || !f.SortDesc {
+		t.Errorf("Normalize() default sort = %v desc=%v, want createdAt desc", f.SortBy, f.SortDesc)
+	}
+}
+
+func TestGearFilter_CursorRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 123000, time.UTC)
+	item := Item{ID: "item-1", Name: "Tent", WeightGram: 950, UsageCount: 3, CreatedAt: created}
+
+	tests := []struct {
+		name   string
+		filter GearFilter
+		want   interface{}
+	}{
+		{"Name", GearFilter{SortBy: GearSortName}, "Tent"},
+		{"Weight", GearFilter{SortBy: GearSortWeight, SortDesc: true}, 950},
+		{"UsageCount", GearFilter{SortBy: GearSortUsageCount}, 3},
+		{"CreatedAt", GearFilter{SortBy: GearSortCreatedAt, SortDesc: true}, created},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Cursor = tt.filter.EncodeCursor(item)
+			value, id, err := tt.filter.DecodeCursor()
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if id != "item-1" {
+				t.Errorf("DecodeCursor() id = %v, want item-1", id)
+			}
+			if ts, ok := value.(time.Time); ok {
+				if !ts.Equal(created) {
+					t.Errorf("DecodeCursor() value = %v, want %v", ts, created)
+				}
+				return
+			}
+			if value != tt.want {
+				t.Errorf("DecodeCursor() value = %v, want %v", value, tt.want)
+			}
+		})
+	}
+
+	t.Run("Missing CreatedAt", func(t *testing.T) {
+		f := GearFilter{SortBy: GearSortCreatedAt}
+		f.Cursor = f.EncodeCursor(Item{ID: "item-2"})
+		value, _, err := f.DecodeCursor()
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		if ts := value.(time.Time); !ts.Equal(time.Unix(0, 0)) {
+			t.Errorf("DecodeCursor() value = %v, want the epoch", ts)
+		}
+	})
+
+	t.Run("Different Sort", func(t *testing.T) {
+		f := GearFilter{SortBy: GearSortName}
+		f.Cursor = f.EncodeCursor(item)
+		f.SortDesc = true
+		if _, _, err := f.DecodeCursor(); err == nil {
+			t.Errorf("DecodeCursor() expected error for changed sort order")
+		}
+	})
+
+	t.Run("Garbage", func(t *testing.T) {
+		f := GearFilter{SortBy: GearSortName, Cursor: "not-a-cursor"}
+		if _, _, err := f.DecodeCursor(); err == nil {
+			t.Errorf("DecodeCursor() expected error for invalid cursor")
+		}
+	})
+}
@@ -0,0 +1,312 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Bulk Import ---
+
+type ImportFormat string
+
+const (
+	ImportFormatLighterPack ImportFormat = "lighterpack"
+	ImportFormatCSV         ImportFormat = "csv"
+)
+
+// ColumnMapping maps Item fields to header names of a generic CSV file.
+// Empty fields are not imported.
+type ColumnMapping struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Manufacturer string `json:"manufacturer"`
+	Weight       string `json:"weight"`
+	Unit         string `json:"unit"`
+	WeightType   string `json:"weightType"`
+	Category     string `json:"category"`
+	Brand        string `json:"brand"`
+	Tags         string `json:"tags"`  // Cell values separated by ";"
+	Price        string `json:"price"` // Decimal amount, e.g. "129.95"
+	Currency     string `json:"currency"`
+	Vendor       string `json:"vendor"`
+	PurchaseDate string `json:"purchaseDate"` // YYYY-MM-DD
+	Barcode      string `json:"barcode"`      // UPC/EAN
+}
+
+// ImportRow is a successfully parsed CSV line.
+type ImportRow struct {
+	Line   int
+	Params CreateGearParams
+}
+
+// ImportRowError reports why a CSV line could not be imported.
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	DryRun    bool             `json:"dryRun"`
+	TotalRows int              `json:"totalRows"`
+	Imported  int              `json:"imported"`
+	Items     []Item           `json:"items"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// lighterPackMapping matches the header of LighterPack's "Export to CSV".
+// Worn/consumable flags are handled separately.
+var lighterPackMapping = ColumnMapping{
+	Name:        "Item Name",
+	Description: "desc",
+	Weight:      "weight",
+	Unit:        "unit",
+	Category:    "Category",
+	Price:       "price",
+}
+
+// ParseLighterPackCSV parses a LighterPack CSV export. Prices are read in defaultCurrency.
+func ParseLighterPackCSV(r io.Reader, defaultCurrency string) ([]ImportRow, []ImportRowError, error) {
+	return parseCSV(r, lighterPackMapping, csvDefaults{unit: UnitGram, currency: defaultCurrency}, true)
+}
+
+// ParseGenericCSV parses an arbitrary CSV file using the given column mapping.
+// defaultUnit and defaultCurrency are used when the mapping has no such column or the cell is empty.
+func ParseGenericCSV(r io.Reader, mapping ColumnMapping, defaultUnit WeightUnit, defaultCurrency string) ([]ImportRow, []ImportRowError, error) {
+	if mapping.Name == "" {
+		return nil, nil, fmt.Errorf("%w: column mapping requires a name column", ErrInvalidInput)
+	}
+	return parseCSV(r, mapping, csvDefaults{unit: defaultUnit, currency: defaultCurrency}, false)
+}
+
+type csvDefaults struct {
+	unit     WeightUnit
+	currency string
+}
+
+func parseCSV(r io.Reader, mapping ColumnMapping, defaults csvDefaults, lighterPack bool) ([]ImportRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: csv is empty", ErrInvalidInput)
+		}
+		return nil, nil, fmt.Errorf("%w: failed to read csv header: %v", ErrInvalidInput, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{mapping.Name, mapping.Weight, mapping.Unit, mapping.WeightType, mapping.Category,
+		mapping.Description, mapping.Manufacturer, mapping.Brand, mapping.Tags,
+		mapping.Price, mapping.Currency, mapping.Vendor, mapping.PurchaseDate, mapping.Barcode} {
+		if required == "" {
+			continue
+		}
+		if _, ok := columns[strings.ToLower(required)]; !ok {
+			return nil, nil, fmt.Errorf("%w: column %q not found in csv header", ErrInvalidInput, required)
+		}
+	}
+
+	var rows []ImportRow
+	var rowErrors []ImportRowError
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		cell := func(name string) string {
+			if name == "" {
+				return ""
+			}
+			idx, ok := columns[strings.ToLower(name)]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		params, copies, err := buildImportParams(cell, mapping, defaults, lighterPack)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		for i := 0; i < copies; i++ {
+			rows = append(rows, ImportRow{Line: line, Params: params})
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+// maxImportCopies bounds how many items one LighterPack row with a qty may create.
+const maxImportCopies = 100
+
+// buildImportParams returns the item of one CSV row and how many of it to create.
+func buildImportParams(cell func(string) string, mapping ColumnMapping, defaults csvDefaults, lighterPack bool) (CreateGearParams, int, error) {
+	params := CreateGearParams{
+		Name:         cell(mapping.Name),
+		Description:  cell(mapping.Description),
+		Manufacturer: cell(mapping.Manufacturer),
+		Category:     cell(mapping.Category),
+		Brand:        cell(mapping.Brand),
+		Vendor:       cell(mapping.Vendor),
+		WeightType:   WeightTypeBase,
+	}
+	if params.Name == "" {
+		return params, 0, errors.New("name is required")
+	}
+	barcode, err := NormalizeBarcode(cell(mapping.Barcode))
+	if err != nil {
+		return params, 0, fmt.Errorf("invalid barcode %q", cell(mapping.Barcode))
+	}
+	params.Barcode = barcode
+
+	if tags := cell(mapping.Tags); tags != "" {
+		for _, tag := range strings.Split(tags, ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				params.Tags = append(params.Tags, tag)
+			}
+		}
+	}
+
+	if err := parseImportPurchase(cell, mapping, defaults.currency, &params); err != nil {
+		return params, 0, err
+	}
+
+	unit := defaults.unit
+	if raw := cell(mapping.Unit); raw != "" {
+		parsed, err := ParseWeightUnit(raw)
+		if err != nil {
+			return params, 0, fmt.Errorf("unknown unit %q", raw)
+		}
+		unit = parsed
+	}
+	if raw := cell(mapping.Weight); raw != "" {
+		value, err := strconv.ParseFloat(strings.ReplaceAll(raw, ",", ""), 64)
+		if err != nil {
+			return params, 0, fmt.Errorf("invalid weight %q", raw)
+		}
+		grams, err := ToGrams(value, unit)
+		if err != nil {
+			return params, 0, fmt.Errorf("invalid weight %q", raw)
+		}
+		params.WeightGram = grams
+		params.Unit = unit
+	}
+
+	if lighterPack {
+		qty := 1
+		if raw := cell("qty"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 1 {
+				return params, 0, fmt.Errorf("invalid qty %q", raw)
+			}
+			qty = parsed
+		}
+		// LighterPack marks flags with the column name itself ("Worn", "Consumable")
+		switch {
+		case cell("worn") != "":
+			params.WeightType = WeightTypeWorn
+		case cell("consumable") != "":
+			params.WeightType = WeightTypeConsumable
+		}
+		// Consumables keep qty as their stock; any other qty becomes that many items
+		if params.WeightType == WeightTypeConsumable {
+			params.StockQuantity = &qty
+			return params, 1, nil
+		}
+		if qty > maxImportCopies {
+			return params, 0, fmt.Errorf("qty %d is more than %d separate items", qty, maxImportCopies)
+		}
+		return params, qty, nil
+	}
+
+	if raw := cell(mapping.WeightType); raw != "" {
+		weightType, ok := ParseWeightType(raw)
+		if !ok {
+			return params, 0, fmt.Errorf("unknown weight type %q", raw)
+		}
+		params.WeightType = weightType
+	}
+	return params, 1, nil
+}
+
+func parseImportPurchase(cell func(string) string, mapping ColumnMapping, defaultCurrency string, params *CreateGearParams) error {
+	currency, err := NormalizeCurrency(cell(mapping.Currency))
+	if err != nil {
+		return fmt.Errorf("unknown currency %q", cell(mapping.Currency))
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	if raw := cell(mapping.Price); raw != "" {
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		price, err := ParseMoney(raw, currency)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", raw)
+		}
+		// LighterPack writes 0 for items without a price
+		if price > 0 {
+			params.PurchasePrice = &price
+			params.Currency = currency
+		}
+	}
+
+	if raw := cell(mapping.PurchaseDate); raw != "" {
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return fmt.Errorf("invalid purchase date %q", raw)
+		}
+		params.PurchaseDate = &date
+	}
+	return nil
+}
+
+// ParseWeightType returns the WeightType for a case-insensitive name.
+func ParseWeightType(s string) (WeightType, bool) {
+	switch WeightType(strings.ToLower(strings.TrimSpace(s))) {
+	case WeightTypeBase:
+		return WeightTypeBase, true
+	case WeightTypeConsumable:
+		return WeightTypeConsumable, true
+	case WeightTypeWorn:
+		return WeightTypeWorn, true
+	case WeightTypeLong:
+		return WeightTypeLong, true
+	case WeightTypeAccessory:
+		return WeightTypeAccessory, true
+	}
+	return "", false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLighterPackCSV(t *testing.T) {
+	csv := `Item Name,Category,desc,qty,weight,unit,url,price,worn,consumable
+Tent,Shelter,UL 1P,1,17.6,oz,,,,
+Rain Jacket,Clothing,,1,254,gram,,,Worn,
+Dinner,Food,,1,0.2,kg,,,,Consumable
+,Shelter,missing name,1,10,g,,,,
+Stakes,Shelter,,1,abc,g,,,,
+Pegs,Shelter,,2,10,g,,,,
+Gas,Kitchen,,3,100,g,,,,Consumable
+Tarp,Shelter,,0,300,g,,,,
+`
+	rows, rowErrors, err := ParseLighterPackCSV(strings.NewReader(csv), "USD")
+	if err != nil {
+		t.Fatalf("ParseLighterPackCSV() error = %v", err)
+	}
+
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want 6", len(rows))
+	}
+	if len(rowErrors) != 3 {
+		t.Fatalf("got %d row errors, want 3", len(rowErrors))
+	}
+
+	tests := []struct {
+		name       string
+		row        ImportRow
+		weightGram int
+		weightType WeightType
+		category   string
+	}{
+		{"Ounces to grams", rows[0], 499, WeightTypeBase, "Shelter"},
+		{"Worn flag", rows[1], 254, WeightTypeWorn, "Clothing"},
+		{"Consumable flag", rows[2], 200, WeightTypeConsumable, "Food"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.row.Params.WeightGram != tt.weightGram {
+				t.Errorf("WeightGram = %v, want %v", tt.row.Params.WeightGram, tt.weightGram)
+			}
+			if tt.row.Params.WeightType != tt.weightType {
+				t.Errorf("WeightType = %v, want %v", tt.row.Params.WeightType, tt.weightType)
+			}
+			if tt.row.Params.Category != tt.category {
+				t.Errorf("Category = %v, want %v", tt.row.Params.Category, tt.category)
+			}
+		})
+	}
+
+	if rowErrors[0].Line != 5 || rowErrors[1].Line != 6 || rowErrors[2].Line != 9 {
+		t.Errorf("row error lines = %d, %d, %d, want 5, 6, 9", rowErrors[0].Line, rowErrors[1].Line, rowErrors[2].Line)
+	}
+
+	// qty: separate items, or stock for consumables
+	if rows[3].Params.Name != "Pegs" || rows[4].Params.Name != "Pegs" || rows[3].Line != 7 {
+		t.Errorf("rows 4-5 = %s/%s on line %d, want two Pegs from line 7", rows[3].Params.Name, rows[4].Params.Name, rows[3].Line)
+	}
+	if stock := rows[5].Params.StockQuantity; rows[5].Params.Name != "Gas" || stock == nil || *stock != 3 {
+		t.Errorf("row 6 = %s with stock %v, want Gas with stock 3", rows[5].Params.Name, stock)
+	}
+}
+
+func TestParseGenericCSV(t *testing.T) {
+	csv := `Product,Maker,Grams,Type,Labels
+Headlamp,Nitecore,45,base,electronics; night
+Trail Runners,Altra,1.32,worn,
+`
+	mapping := ColumnMapping{
+		Name:         "Product",
+		Manufacturer: "Maker",
+		Weight:       "Grams",
+		WeightType:   "Type",
+		Tags:         "Labels",
+	}
+
+	rows, rowErrors, err := ParseGenericCSV(strings.NewReader(csv), mapping, UnitPound, "")
+	if err != nil {
+		t.Fatalf("ParseGenericCSV() error = %v", err)
+	}
+	if len(rowErrors) != 0 {
+		t.Fatalf("unexpected row errors: %v", rowErrors)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if got := rows[0].Params.Tags; len(got) != 2 || got[0] != "electronics" || got[1] != "night" {
+		t.Errorf("Tags = %v, want [electronics night]", got)
+	}
+	if rows[1].Params.WeightGram != 599 || rows[1].Params.WeightType != WeightTypeWorn {
+		t.Errorf("row 2 = %d/%s, want 599/worn", rows[1].Params.WeightGram, rows[1].Params.WeightType)
+	}
+
+	if _, _, err := ParseGenericCSV(strings.NewReader(csv), ColumnMapping{Name: "Missing"}, UnitGram, ""); err == nil {
+		t.Error("expected error for unknown mapped column")
+	}
+}
+
+func TestLighterPackRoundTrip(t *testing.T) {
+	rows := []PackingListRow{
+		{Group: "Cookset", Item: Item{Name: "Stove", WeightGram: 25, WeightType: WeightTypeBase, Properties: []byte(`{"category":"Kitchen"}`)}, Quantity: 1},
+		{Group: "Cookset", Item: Item{Name: "Gas", WeightGram: 200, WeightType: WeightTypeConsumable}, Quantity: 2},
+		{Group: "Cookset", Item: Item{Name: "Shoes", WeightGram: 600, WeightType: WeightTypeWorn}, Quantity: 1},
+	}
+
+	var buf strings.Builder
+	if err := WriteLighterPackCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteLighterPackCSV() error = %v", err)
+	}
+
+	parsed, rowErrors, err := ParseLighterPackCSV(strings.NewReader(buf.String()), DefaultCurrency)
+	if err != nil || len(rowErrors) > 0 {
+		t.Fatalf("ParseLighterPackCSV() error = %v, row errors = %v", err, rowErrors)
+	}
+	if len(parsed) != len(rows) {
+		t.Fatalf("got %d rows, want %d", len(parsed), len(rows))
+	}
+	for i, row := range rows {
+		got := parsed[i].Params
+		if got.Name != row.Item.Name || got.WeightGram != row.Item.WeightGram || got.WeightType != row.Item.WeightType {
+			t.Errorf("row %d = %+v, want %s/%d/%s", i, got, row.Item.Name, row.Item.WeightGram, row.Item.WeightType)
+		}
+	}
+	if stock := parsed[1].Params.StockQuantity; stock == nil || *stock != 2 {
+		t.Errorf("Gas stock = %v, want 2", stock)
+	}
+	if parsed[0].Params.Category != "Kitchen" || parsed[1].Params.Category != "Cookset" {
+		t.Errorf("categories = %q, %q, want Kitchen, Cookset", parsed[0].Params.Category, parsed[1].Params.Category)
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"html"
+	"strings"
+)
+
+// --- Full-Text Search ---
+
+const (
+	// DefaultSearchLimit and MaxSearchLimit bound the number of ranked hits.
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+	// SearchFuzzyThreshold is the pg_trgm word similarity a typo must reach to match.
+	SearchFuzzyThreshold = 0.4
+
+	// HighlightStart/HighlightStop delimit matches in raw ts_headline output.
+	// Control characters never occur in user text, so they survive HTML escaping.
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
+
+// SearchHit is an item ranked against a query. Highlights hold HTML-escaped
+// snippets of "name" and "description" with matches wrapped in <mark>.
+type SearchHit struct {
+	Item       Item              `json:"item"`
+	Rank       float64           `json:"rank"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
+
+// NormalizeSearchLimit clamps limit to (0, MaxSearchLimit].
+func NormalizeSearchLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		return MaxSearchLimit
+	}
+	return limit
+}
+
+// RenderHighlight escapes a raw headline and turns the delimiters into <mark> tags.
+// It returns "" when the headline contains no match.
+func RenderHighlight(raw string) string {
+	if !strings.Contains(raw, HighlightStart) {
+		return ""
+	}
+	escaped := html.EscapeString(raw)
+	escaped = strings.ReplaceAll(escaped, HighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, HighlightStop, "</mark>")
+}
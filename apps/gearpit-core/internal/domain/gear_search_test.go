@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRenderHighlight(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"No Match", "Sawyer Squeeze", ""},
+		{"Match", "\x02Sawyer\x03 Squeeze", "<mark>Sawyer</mark> Squeeze"},
+		{"Escapes HTML", "<b>\x02tent\x03</b>", "&lt;b&gt;<mark>tent</mark>&lt;/b&gt;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderHighlight(tt.raw); got != tt.want {
+				t.Errorf("RenderHighlight() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
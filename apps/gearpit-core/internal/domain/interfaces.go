@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -13,47 +14,218 @@ type CreateGearParams struct {
 	Manufacturer        string
 	WeightGram          int
 	WeightType          WeightType
+	Unit                WeightUnit // Preferred display unit (input is already normalized to grams)
 	Category            string
 	Brand               string
 	Tags                []string
+	Attributes          map[string]interface{} // Category-specific properties, validated against the PropertySchema
 	UsageCount          int
 	MaintenanceInterval int
+	MaintenanceLogType  string // Log type that restarts MaintenanceInterval
+	PurchasePrice       *int
+	PurchaseDate        *time.Time
+	Currency            string
+	Vendor              string
+	Storage             string
+	SerialNumber        string
+	WarrantyExpiresAt   *time.Time
+	ExpiresAt           *time.Time
+	LotNumber           string
+	StockQuantity       *int
+	ReorderThreshold    int
+	Barcode             string // Any UPC/EAN form; see NormalizeBarcode
 }
 
 type UpdateGearParams struct {
-	Name                string
-	Description         string
-	Manufacturer        string
-	WeightGram          int
-	WeightType          WeightType
-	Category            string
-	Brand               string
-	Tags                []string
-	UsageCount          int
-	MaintenanceInterval int
+	Name                   string
+	Description            string
+	Manufacturer           string
+	WeightGram             int
+	WeightType             WeightType
+	Unit                   WeightUnit // Preferred display unit (input is already normalized to grams)
+	Category               string
+	Brand                  string
+	Tags                   []string
+	Attributes             map[string]interface{} // nil keeps the current attributes
+	UsageCount             int
+	MaintenanceInterval    int
+	MaintenanceLogType     *string // Log type that restarts MaintenanceInterval; nil keeps the current one
+	PurchasePrice          *int
+	PurchaseDate           *time.Time
+	Currency               string
+	Vendor                 string
+	Storage                string
+	SerialNumber           *string    // nil keeps the current serial, "" clears it
+	WarrantyExpiresAt      *time.Time // nil keeps the current date
+	ClearWarrantyExpiresAt bool       // Removes the warranty date
+	ExpiresAt              *time.Time // nil keeps the current date
+	ClearExpiresAt         bool       // Removes the expiry date
+	LotNumber              *string    // nil keeps the current lot, "" clears it
+	StockQuantity          *int       // nil keeps the current stock
+	ReorderThreshold       *int
+	Barcode                *string // nil keeps the current barcode, "" clears it
+	MaintenanceMeterID     *string // One of the item's meters; nil keeps the current meter, "" counts UsageCount again
+}
+
+type ImportGearParams struct {
+	Format          ImportFormat
+	Data            io.Reader
+	Mapping         ColumnMapping // Only used for ImportFormatCSV
+	DefaultUnit     WeightUnit
+	DefaultCurrency string // For prices without a currency column (default: DefaultCurrency)
+	DryRun          bool   // Validate and preview without writing
 }
 
 type GearRepository interface {
 	Create(ctx context.Context, item *Item) error
 	GetByID(ctx context.Context, id string) (*Item, error)
 	List(ctx context.Context) ([]Item, error)
+	// ListFiltered expects a normalized filter (see GearFilter.Normalize).
+	ListFiltered(ctx context.Context, filter GearFilter) (*GearPage, error)
 	Update(ctx context.Context, item *Item) error
 	Delete(ctx context.Context, id string) error
 	Search(ctx context.Context, query string) ([]Item, error)
+	GetCostInput(ctx context.Context, id string) (*ItemCostInput, error)
+	// FullTextSearch ranks items by relevance, tolerating typos.
+	FullTextSearch(ctx context.Context, query string, limit int) ([]SearchHit, error)
+	// FindByProperties matches typed values in Properties (filter values are already converted).
+	FindByProperties(ctx context.Context, category string, filters []PropertyFilter) ([]Item, error)
+	// FindBySerialNumber expects a normalized serial. Different makers can reuse serials, so several items may match.
+	FindBySerialNumber(ctx context.Context, serial string) ([]Item, error)
+	// FindByBarcode expects a normalized barcode.
+	FindByBarcode(ctx context.Context, barcode string) ([]Item, error)
+	// ListWarrantiesExpiring returns owned items whose warranty ends in [from, to], soonest first.
+	ListWarrantiesExpiring(ctx context.Context, from, to time.Time) ([]Item, error)
+	// ListExpiring returns owned items that expire before the given time, including already expired ones, soonest first.
+	ListExpiring(ctx context.Context, before time.Time) ([]Item, error)
+	// Merge moves every reference to the duplicates onto the survivor, adds their usage
+	// counts (and stock, when the survivor keeps stock) to it and trashes them, in one transaction.
+	// It returns ErrConflict when the merge would make the survivor a component of itself
+	// or fold meters of the same name that count in different units.
+	Merge(ctx context.Context, survivorID string, duplicateIDs []string) error
+	// SetComponents replaces the bill of materials of an assembly. It rejects components
+	// that do not exist or that would make the assembly part of itself.
+	SetComponents(ctx context.Context, parentID string, components []ItemComponent) error
+	// GetComponentTree returns the item with every component below it.
+	GetComponentTree(ctx context.Context, id string) (*ComponentNode, error)
 
 	// Transaction helper
 	DoInTransaction(ctx context.Context, fn func(txRepo GearRepository) error) error
 	// Maintenance
 	AddMaintenanceLog(ctx context.Context, log *MaintenanceLog) error
+	// MarkMeterServiced restarts the meter's count towards maintenance and returns its total.
+	MarkMeterServiced(ctx context.Context, meterID string) (float64, error)
+
+	// Usage meters
+	CreateMeter(ctx context.Context, meter *UsageMeter) error
+	// GetMeter returns ErrNotFound for unknown meters.
+	GetMeter(ctx context.Context, id string) (*UsageMeter, error)
+	ListMeters(ctx context.Context, itemID string) ([]UsageMeter, error)
+	UpdateMeter(ctx context.Context, meter *UsageMeter) error
+	// DeleteMeter removes the meter with its entries. An item counting maintenance on it
+	// falls back to UsageCount.
+	DeleteMeter(ctx context.Context, id string) error
+	// RecordUsage stores the entry and adds its amount to the meter's total.
+	RecordUsage(ctx context.Context, entry *UsageEntry) error
+	// ListUsageEntries returns the meter's entries, newest first.
+	ListUsageEntries(ctx context.Context, meterID string) ([]UsageEntry, error)
 }
 
 type GearService interface {
 	CreateItem(ctx context.Context, params CreateGearParams) (*Item, error)
 	GetItem(ctx context.Context, id string) (*Item, error)
 	ListItems(ctx context.Context) ([]Item, error)
+	ListItemsFiltered(ctx context.Context, filter GearFilter) (*GearPage, error)
 	UpdateItem(ctx context.Context, id string, params UpdateGearParams) (*Item, error)
 	DeleteItem(ctx context.Context, id string) error
 	SearchItems(ctx context.Context, query string) ([]Item, error)
+	SearchRanked(ctx context.Context, query string, limit int) ([]SearchHit, error)
+	ImportItems(ctx context.Context, params ImportGearParams) (*ImportResult, error)
+	ChangeItemStatus(ctx context.Context, id string, params ChangeItemStatusParams) (*Item, error)
+	GetItemCost(ctx context.Context, id string) (*ItemCost, error)
+	FindByProperties(ctx context.Context, category string, filters []PropertyFilter) ([]Item, error)
+	FindBySerialNumber(ctx context.Context, serial string) ([]Item, error)
+	// FindByBarcode finds inventory items by a scanned barcode in any UPC/EAN form.
+	FindByBarcode(ctx context.Context, barcode string) ([]Item, error)
+	ListExpiringWarranties(ctx context.Context, days int) ([]WarrantyAlert, error)
+	ListExpiringItems(ctx context.Context, days int) ([]ExpiryAlert, error)
+	FindDuplicates(ctx context.Context, minScore float64) ([]DuplicatePair, error)
+	// MergeItems folds the duplicates into the survivor and returns the updated survivor.
+	MergeItems(ctx context.Context, survivorID string, duplicateIDs []string) (*Item, error)
+	GetComponents(ctx context.Context, id string) (*ComponentNode, error)
+	// SetComponents replaces the item's components and returns the new tree.
+	SetComponents(ctx context.Context, id string, components []ItemComponent) (*ComponentNode, error)
+
+	CreateMeter(ctx context.Context, itemID, name string, unit MeterUnit) (*UsageMeter, error)
+	ListMeters(ctx context.Context, itemID string) ([]UsageMeter, error)
+	UpdateMeter(ctx context.Context, id, name string, unit MeterUnit) (*UsageMeter, error)
+	DeleteMeter(ctx context.Context, id string) error
+	// RecordUsage adds a manual entry to the meter. recordedAt defaults to now.
+	RecordUsage(ctx context.Context, meterID string, amount float64, recordedAt time.Time, note string) (*UsageEntry, error)
+	ListUsageEntries(ctx context.Context, meterID string) ([]UsageEntry, error)
+}
+
+// --- Product Catalog ---
+
+type CatalogRepository interface {
+	// Upsert inserts products and overwrites existing ones with the same barcode.
+	Upsert(ctx context.Context, products []CatalogProduct) error
+	// GetByBarcode returns ErrNotFound for unknown barcodes.
+	GetByBarcode(ctx context.Context, barcode string) (*CatalogProduct, error)
+}
+
+type CatalogService interface {
+	ImportCatalog(ctx context.Context, format CatalogFormat, data io.Reader, dryRun bool) (*CatalogImportResult, error)
+	// LookupBarcode resolves a scan against the catalog and the inventory. Unknown
+	// barcodes still return a lookup with only the barcode prefilled.
+	LookupBarcode(ctx context.Context, barcode string) (*BarcodeLookup, error)
+}
+
+// --- PC Builds ---
+
+type PCBuildRepository interface {
+	Create(ctx context.Context, build *PCBuild) error
+	// GetByID loads the parts with their items. Unknown IDs return ErrNotFound.
+	GetByID(ctx context.Context, id string) (*PCBuild, error)
+	List(ctx context.Context) ([]PCBuild, error)
+	// Update saves the build and replaces its parts with build.Parts.
+	Update(ctx context.Context, build *PCBuild) error
+	Delete(ctx context.Context, id string) error
+}
+
+type SavePCBuildParams struct {
+	Name        string
+	Description string
+	Parts       []BuildPart // ItemID and Slot of each part
+}
+
+type PCBuildService interface {
+	CreateBuild(ctx context.Context, params SavePCBuildParams) (*PCBuild, error)
+	GetBuild(ctx context.Context, id string) (*PCBuild, error)
+	ListBuilds(ctx context.Context) ([]PCBuild, error)
+	UpdateBuild(ctx context.Context, id string, params SavePCBuildParams) (*PCBuild, error)
+	DeleteBuild(ctx context.Context, id string) error
+	// GetReport checks the build's parts against the compatibility rules.
+	GetReport(ctx context.Context, id string) (*BuildReport, error)
+}
+
+// --- Property Schemas ---
+
+type PropertySchemaRepository interface {
+	Create(ctx context.Context, schema *PropertySchema) error
+	// GetByCategory returns ErrNotFound when the category has no schema.
+	GetByCategory(ctx context.Context, category string) (*PropertySchema, error)
+	List(ctx context.Context) ([]PropertySchema, error)
+	Update(ctx context.Context, schema *PropertySchema) error
+	Delete(ctx context.Context, category string) error
+}
+
+type PropertySchemaService interface {
+	CreateSchema(ctx context.Context, category, description string, fields []PropertyField) (*PropertySchema, error)
+	GetSchema(ctx context.Context, category string) (*PropertySchema, error)
+	ListSchemas(ctx context.Context) ([]PropertySchema, error)
+	UpdateSchema(ctx context.Context, category, description string, fields []PropertyField) (*PropertySchema, error)
+	DeleteSchema(ctx context.Context, category string) error
 }
 
 // --- Kits / Containers ---
@@ -64,6 +236,9 @@ type KitRepository interface {
 	List(ctx context.Context) ([]Kit, error)
 	AddItem(ctx context.Context, kitID, itemID string) error
 	RemoveItem(ctx context.Context, kitID, itemID string) error
+	// SetItems replaces the kit's items in one recorded change.
+	SetItems(ctx context.Context, kitID string, itemIDs []string) error
+	Delete(ctx context.Context, id string) error
 }
 
 type KitService interface {
@@ -72,6 +247,7 @@ type KitService interface {
 	ListKits(ctx context.Context) ([]Kit, error)
 	AddItemToKit(ctx context.Context, kitID, itemID string) error
 	RemoveItemFromKit(ctx context.Context, kitID, itemID string) error
+	DeleteKit(ctx context.Context, id string) error
 }
 
 // --- Loadout (Template) ---
@@ -96,26 +272,209 @@ type LoadoutService interface {
 
 type MaintenanceRepository interface {
 	Create(ctx context.Context, log *MaintenanceLog) error
+	GetByID(ctx context.Context, id string) (*MaintenanceLog, error)
 	GetByItemID(ctx context.Context, itemID string) ([]MaintenanceLog, error)
 	Update(ctx context.Context, log *MaintenanceLog) error
 	Delete(ctx context.Context, id string) error
+
+	AddOdometerReading(ctx context.Context, reading *OdometerReading) error
+	// ListOdometerReadings returns the item's readings, oldest first.
+	ListOdometerReadings(ctx context.Context, itemID string) ([]OdometerReading, error)
+
+	CreateRule(ctx context.Context, rule *ServiceRule) error
+	GetRule(ctx context.Context, id string) (*ServiceRule, error)
+	// ListRules returns the rules of an item, or of all items when itemID is empty, with their items.
+	ListRules(ctx context.Context, itemID string) ([]ServiceRule, error)
+	// ListCategoryRules returns the rules of a category, or of all categories when category is empty.
+	ListCategoryRules(ctx context.Context, category string) ([]ServiceRule, error)
+	UpdateRule(ctx context.Context, rule *ServiceRule) error
+	DeleteRule(ctx context.Context, id string) error
+}
+
+type SaveServiceRuleParams struct {
+	ItemID           string // ItemID or Category; both are ignored on update
+	Category         string
+	Name             string
+	LogType          string
+	IntervalKm       *int
+	MeterName        string
+	IntervalUsage    *float64
+	IntervalMonths   *int
+	GraceUsage       *float64
+	GraceDays        *int
+	LastServiceKm    *int       // Item rules; defaults to the odometer at LastServiceAt
+	LastServiceUsage *float64   // Item rules; defaults to the meter at LastServiceAt
+	LastServiceAt    *time.Time // Defaults to now on create
 }
 
 type MaintenanceService interface {
+	// AddLog restarts the item's rules of logType. The item's MaintenanceInterval counter
+	// restarts for its MaintenanceLogType, or, without one, for logs matching no rule.
 	AddLog(ctx context.Context, itemID, logType, description string, cost int, performedAt time.Time) (*MaintenanceLog, error)
 	GetItemLogs(ctx context.Context, itemID string) ([]MaintenanceLog, error)
 	UpdateLog(ctx context.Context, id, logType, description string, cost int, performedAt time.Time) (*MaintenanceLog, error)
 	DeleteLog(ctx context.Context, id string) error
+
+	AddOdometerReading(ctx context.Context, itemID string, km int, readAt time.Time, note string) (*OdometerReading, error)
+	ListOdometerReadings(ctx context.Context, itemID string) ([]OdometerReading, error)
+	CreateServiceRule(ctx context.Context, params SaveServiceRuleParams) (*ServiceRule, error)
+	UpdateServiceRule(ctx context.Context, id string, params SaveServiceRuleParams) (*ServiceRule, error)
+	DeleteServiceRule(ctx context.Context, id string) error
+	// ListServiceRules returns the rules of a category, or of all categories when category is empty.
+	ListServiceRules(ctx context.Context, category string) ([]ServiceRule, error)
+	// GetServiceStatus evaluates the item's own and category rules, most urgent first.
+	GetServiceStatus(ctx context.Context, itemID string) ([]ServiceStatus, error)
+	// ListDueServices returns the services of items in use that are due soon, due or overdue, most urgent first.
+	ListDueServices(ctx context.Context) ([]ServiceStatus, error)
 }
 
 // --- Dashboard ---
 
 type DashboardRepository interface {
-	GetStats(ctx context.Context) (*DashboardStats, error)
+	// GetStats skips retired/sold items unless includeInactive is set.
+	GetStats(ctx context.Context, includeInactive bool) (*DashboardStats, error)
+	ListCostInputs(ctx context.Context, includeInactive bool) ([]ItemCostInput, error)
+	// ListValuationInputs skips sold and lost items.
+	ListValuationInputs(ctx context.Context) ([]ValuationInput, error)
 }
 
 type DashboardService interface {
-	GetDashboardStats(ctx context.Context) (*DashboardStats, error)
+	GetDashboardStats(ctx context.Context, includeInactive bool) (*DashboardStats, error)
+}
+
+// --- Valuation ---
+
+type DepreciationRuleRepository interface {
+	// Save creates or replaces the rule of rule.Category.
+	Save(ctx context.Context, rule *DepreciationRule) error
+	// GetByCategory returns ErrNotFound when the category has no rule.
+	GetByCategory(ctx context.Context, category string) (*DepreciationRule, error)
+	List(ctx context.Context) ([]DepreciationRule, error)
+	Delete(ctx context.Context, category string) error
+}
+
+type ValuationService interface {
+	ListRules(ctx context.Context) ([]DepreciationRule, error)
+	GetRule(ctx context.Context, category string) (*DepreciationRule, error)
+	SaveRule(ctx context.Context, rule DepreciationRule) (*DepreciationRule, error)
+	DeleteRule(ctx context.Context, category string) error
+	GetReport(ctx context.Context, groupBy ValuationGroupBy) (*ValuationReport, error)
+	// ExportReport renders the report as csv or printable html.
+	ExportReport(ctx context.Context, groupBy ValuationGroupBy, format ExportFormat) (*ExportFile, error)
+}
+
+// --- Attachments ---
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	// GetByID returns ErrNotFound for unknown IDs.
+	GetByID(ctx context.Context, id string) (*Attachment, error)
+	ListByOwner(ctx context.Context, owner AttachmentOwner, ownerID string) ([]Attachment, error)
+	Delete(ctx context.Context, id string) error
+	// OwnerExists reports whether the owner exists and is not in the trash.
+	OwnerExists(ctx context.Context, owner AttachmentOwner, ownerID string) (bool, error)
+	// DeleteOrphans removes rows whose owner was purged and returns them.
+	DeleteOrphans(ctx context.Context) ([]Attachment, error)
+}
+
+type UploadAttachmentParams struct {
+	Owner    AttachmentOwner
+	OwnerID  string
+	FileName string
+	Data     []byte // At most MaxAttachmentSize
+}
+
+type AttachmentService interface {
+	Upload(ctx context.Context, params UploadAttachmentParams) (*Attachment, error)
+	List(ctx context.Context, owner AttachmentOwner, ownerID string) ([]Attachment, error)
+	// Open returns the content (or its thumbnail); the caller closes the reader.
+	Open(ctx context.Context, id string, thumbnail bool) (*Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, id string) error
+	AttachmentCleaner
+}
+
+// AttachmentCleaner removes attachments whose owner no longer exists.
+// Trashed owners keep their attachments so a restore brings them back.
+type AttachmentCleaner interface {
+	CleanupOrphans(ctx context.Context) (int, error)
+}
+
+// --- Locations ---
+
+type LocationRepository interface {
+	Create(ctx context.Context, location *Location) error
+	// GetByID returns ErrNotFound for unknown IDs.
+	GetByID(ctx context.Context, id string) (*Location, error)
+	List(ctx context.Context) ([]Location, error)
+	Update(ctx context.Context, location *Location) error
+	// Delete returns ErrConflict while the location has sub-locations or items.
+	Delete(ctx context.Context, id string) error
+	// MoveItem sets the item's location and records the move in one transaction.
+	MoveItem(ctx context.Context, move *ItemMove) error
+	// ListMoves returns the move history of an item, newest first.
+	ListMoves(ctx context.Context, itemID string) ([]ItemMove, error)
+}
+
+type SaveLocationParams struct {
+	Name        string
+	Description string
+	ParentID    *string
+}
+
+type MoveItemParams struct {
+	LocationID *string // nil takes the item out of any location
+	Note       string
+}
+
+type LocationService interface {
+	CreateLocation(ctx context.Context, params SaveLocationParams) (*Location, error)
+	GetLocation(ctx context.Context, id string) (*Location, error)
+	// ListLocations returns all locations with paths, sorted by path.
+	ListLocations(ctx context.Context) ([]Location, error)
+	UpdateLocation(ctx context.Context, id string, params SaveLocationParams) (*Location, error)
+	DeleteLocation(ctx context.Context, id string) error
+	MoveItem(ctx context.Context, itemID string, params MoveItemParams) (*ItemMove, error)
+	ListMoves(ctx context.Context, itemID string) ([]ItemMove, error)
+	// WhereIs finds items by name and tells where each one is kept.
+	WhereIs(ctx context.Context, query string) ([]ItemWhereabouts, error)
+	// Locate tells where one item is kept.
+	Locate(ctx context.Context, itemID string) (*ItemWhereabouts, error)
+}
+
+// --- Loans ---
+
+type LoanRepository interface {
+	Create(ctx context.Context, loan *Loan) error
+	// GetByID returns ErrNotFound for unknown IDs. Item and BorrowerProfile are loaded.
+	GetByID(ctx context.Context, id string) (*Loan, error)
+	// List returns loans newest first with Item and BorrowerProfile loaded.
+	List(ctx context.Context, filter LoanFilter) ([]Loan, error)
+	Update(ctx context.Context, loan *Loan) error
+	Delete(ctx context.Context, id string) error
+	// ListOverdue returns open loans due before now, most overdue first.
+	ListOverdue(ctx context.Context, now time.Time) ([]Loan, error)
+	// ListByItems returns all loans of the items with Item loaded.
+	ListByItems(ctx context.Context, itemIDs []string) ([]Loan, error)
+}
+
+type SaveLoanParams struct {
+	ItemID            string // Ignored on update
+	BorrowerProfileID *string
+	BorrowerContact   string
+	LoanedAt          *time.Time // Defaults to now; nil keeps the stored value on update
+	DueAt             *time.Time // nil leaves the loan without a due date
+	Note              string
+}
+
+type LoanService interface {
+	CreateLoan(ctx context.Context, params SaveLoanParams) (*Loan, error)
+	GetLoan(ctx context.Context, id string) (*Loan, error)
+	ListLoans(ctx context.Context, filter LoanFilter) ([]Loan, error)
+	UpdateLoan(ctx context.Context, id string, params SaveLoanParams) (*Loan, error)
+	// ReturnLoan closes the loan at returnedAt (default: now).
+	ReturnLoan(ctx context.Context, id string, returnedAt *time.Time) (*Loan, error)
+	DeleteLoan(ctx context.Context, id string) error
+	ListOverdue(ctx context.Context) ([]OverdueLoan, error)
 }
 
 // --- User Profile ---
@@ -129,10 +488,10 @@ type ProfileRepository interface {
 }
 
 type ProfileService interface {
-	CreateProfile(ctx context.Context, name string, height, weight float64, age int, gender string) (*UserProfile, error)
+	CreateProfile(ctx context.Context, name string, height, weight float64, age int, gender string, unitSystem UnitSystem) (*UserProfile, error)
 	GetProfile(ctx context.Context, id string) (*UserProfile, error)
 	ListProfiles(ctx context.Context) ([]UserProfile, error)
-	UpdateProfile(ctx context.Context, id, name string, height, weight float64, age int, gender string) (*UserProfile, error)
+	UpdateProfile(ctx context.Context, id, name string, height, weight float64, age int, gender string, unitSystem UnitSystem) (*UserProfile, error)
 	DeleteProfile(ctx context.Context, id string) error
 }
 
@@ -142,16 +501,38 @@ type TripRepository interface {
 	Create(ctx context.Context, trip *Trip) error
 	GetByID(ctx context.Context, id string) (*Trip, error)
 	List(ctx context.Context) ([]Trip, error)
+	// ListWithItems lists trips with their packing lists preloaded, like GetByID.
+	ListWithItems(ctx context.Context) ([]Trip, error)
 	Update(ctx context.Context, trip *Trip) error
 	Delete(ctx context.Context, id string) error
 
 	// Quantity対応版
 	UpsertItem(ctx context.Context, tripID string, itemID string, quantity int) error
 	RemoveItem(ctx context.Context, tripID string, itemID string) error
+	// SetItems replaces the packing list (item ID to quantity) in one recorded change.
+	SetItems(ctx context.Context, tripID string, quantities map[string]int) error
 
 	// Transaction helper
 	DoInTransaction(ctx context.Context, fn func(txRepo TripRepository) error) error
 	IncrementItemUsages(ctx context.Context, tripID string, increment int) error
+	// DecrementConsumableStock takes the trip quantities of stocked consumables out of stock, stopping at zero.
+	DecrementConsumableStock(ctx context.Context, tripID string) error
+
+	// Usage meters
+	UpsertMeterUsage(ctx context.Context, usage *TripMeterUsage) error
+	RemoveMeterUsage(ctx context.Context, tripID, meterID string) error
+	// ListMeterUsages returns the usage recorded on the trip with its meters.
+	ListMeterUsages(ctx context.Context, tripID string) ([]TripMeterUsage, error)
+	// ListTripMeters returns the meters of the trip's items and of their components.
+	ListTripMeters(ctx context.Context, tripID string) ([]UsageMeter, error)
+	// RecordMeterUsages stores the entries and adds their amounts to the meter totals.
+	RecordMeterUsages(ctx context.Context, entries []UsageEntry) error
+
+	// Shopping list
+	// ListStockedConsumables leaves out inactive items (see ItemStatus.IsInactive).
+	ListStockedConsumables(ctx context.Context) ([]Item, error)
+	// ListConsumableNeeds returns stocked consumables packed for trips that are not completed and start in [from, to).
+	ListConsumableNeeds(ctx context.Context, from, to time.Time) ([]ConsumableNeed, error)
 }
 
 type TripService interface {
@@ -162,6 +543,75 @@ type TripService interface {
 	DeleteTrip(ctx context.Context, id string) error
 	CompleteTrip(ctx context.Context, id string) error
 
-	AddOrUpdateItem(ctx context.Context, tripID string, itemID string, quantity int) error
+	// AddOrUpdateItem returns warnings (e.g. the item is lent out during the trip); they never block the change.
+	AddOrUpdateItem(ctx context.Context, tripID string, itemID string, quantity int) ([]TripWarning, error)
 	RemoveItemFromTrip(ctx context.Context, tripID string, itemID string) error
+	// SetMeterUsage records what the trip puts on a meter of one of its items, counted
+	// when the trip is completed. Zero removes it.
+	SetMeterUsage(ctx context.Context, tripID, meterID string, amount float64) ([]TripMeterUsage, error)
+	ListMeterUsages(ctx context.Context, tripID string) ([]TripMeterUsage, error)
+	ShoppingList(ctx context.Context, days int) ([]ShoppingListLine, error)
+}
+
+// --- Export ---
+
+type ExportParams struct {
+	Entity ExportEntity
+	Format ExportFormat
+	ID     string // Optional: export a single kit, loadout or trip
+}
+
+type ExportService interface {
+	Export(ctx context.Context, params ExportParams) (*ExportFile, error)
+}
+
+// --- Backup & Restore ---
+
+type BackupRepository interface {
+	// SchemaVersion returns the current golang-migrate version of the database.
+	SchemaVersion(ctx context.Context) (uint, error)
+	Dump(ctx context.Context) (*BackupArchive, error)
+	// Restore writes all archived rows in one transaction, preserving IDs.
+	Restore(ctx context.Context, archive *BackupArchive, policy ConflictPolicy) (*RestoreResult, error)
+}
+
+type BackupService interface {
+	CreateBackup(ctx context.Context) (*BackupArchive, error)
+	RestoreBackup(ctx context.Context, archive *BackupArchive, policy ConflictPolicy) (*RestoreResult, error)
+}
+
+// --- Trash ---
+
+type TrashRepository interface {
+	List(ctx context.Context) ([]TrashEntry, error)
+	// Restore and Purge return ErrNotFound when the row is not in the trash.
+	Restore(ctx context.Context, entity TrashEntity, id string) error
+	Purge(ctx context.Context, entity TrashEntity, id string) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (PurgeResult, error)
+}
+
+type TrashService interface {
+	ListTrash(ctx context.Context) ([]TrashEntry, error)
+	Restore(ctx context.Context, entity TrashEntity, id string) error
+	Purge(ctx context.Context, entity TrashEntity, id string) error
+	// PurgeExpired removes rows that have been in the trash longer than the retention window.
+	PurgeExpired(ctx context.Context) (PurgeResult, error)
+}
+
+// --- Audit Log ---
+
+// AuditRepository reads the history that the entity repositories record in their
+// update transactions; see AuditEntry.
+type AuditRepository interface {
+	// List returns the entity's changes, newest first.
+	List(ctx context.Context, entity AuditEntity, entityID string) ([]AuditEntry, error)
+	GetByID(ctx context.Context, id string) (*AuditEntry, error)
+}
+
+type AuditService interface {
+	GetHistory(ctx context.Context, entity AuditEntity, entityID string) ([]AuditEntry, error)
+	// RevertChange sets the entry's field back to its old value and returns the
+	// entries recorded for the revert. It fails with ErrConflict when the field
+	// has changed again since.
+	RevertChange(ctx context.Context, entity AuditEntity, entityID, entryID string) ([]AuditEntry, error)
 }
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Item Lifecycle ---
+
+type ItemStatus string
+
+const (
+	ItemStatusActive  ItemStatus = "active"
+	ItemStatusRetired ItemStatus = "retired"
+	ItemStatusLost    ItemStatus = "lost"
+	ItemStatusSold    ItemStatus = "sold"
+	ItemStatusBroken  ItemStatus = "broken"
+)
+
+// InactiveItemStatuses are hidden from item pickers and dashboard totals by default.
+var InactiveItemStatuses = []ItemStatus{ItemStatusRetired, ItemStatusSold}
+
+// itemStatusTransitions lists the allowed next states. Sold is final.
+var itemStatusTransitions = map[ItemStatus][]ItemStatus{
+	ItemStatusActive:  {ItemStatusRetired, ItemStatusLost, ItemStatusSold, ItemStatusBroken},
+	ItemStatusBroken:  {ItemStatusActive, ItemStatusRetired, ItemStatusSold, ItemStatusLost},
+	ItemStatusLost:    {ItemStatusActive, ItemStatusRetired},
+	ItemStatusRetired: {ItemStatusActive, ItemStatusSold},
+	ItemStatusSold:    {},
+}
+
+// ParseItemStatus validates a status name.
+func ParseItemStatus(s string) (ItemStatus, error) {
+	status := ItemStatus(s)
+	if _, ok := itemStatusTransitions[status]; !ok {
+		return "", fmt.Errorf("%w: unknown item status %q", ErrInvalidInput, s)
+	}
+	return status, nil
+}
+
+// CanTransitionTo reports whether an item in status s may move to next.
+func (s ItemStatus) CanTransitionTo(next ItemStatus) bool {
+	for _, allowed := range itemStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInactive reports whether the status is hidden by default.
+func (s ItemStatus) IsInactive() bool {
+	for _, inactive := range InactiveItemStatuses {
+		if s == inactive {
+			return true
+		}
+	}
+	return false
+}
+
+type ChangeItemStatusParams struct {
+	Status    ItemStatus
+	Reason    string
+	SalePrice *int       // Only for ItemStatusSold
+	At        *time.Time // Defaults to now
+}
+
+// TransitionTo moves the item to a new lifecycle status. Leaving active records
+// RetiredAt; returning to active clears it and the sale price.
+func (i *Item) TransitionTo(params ChangeItemStatusParams, now time.Time) error {
+	current := i.Status
+	if current == "" {
+		current = ItemStatusActive
+	}
+	if !current.CanTransitionTo(params.Status) {
+		return fmt.Errorf("%w: cannot change status from %s to %s", ErrInvalidInput, current, params.Status)
+	}
+	if params.SalePrice != nil {
+		if params.Status != ItemStatusSold {
+			return fmt.Errorf("%w: sale price is only allowed when selling", ErrInvalidInput)
+		}
+		if *params.SalePrice < 0 {
+			return fmt.Errorf("%w: sale price must not be negative", ErrInvalidInput)
+		}
+	}
+
+	at := now
+	if params.At != nil {
+		at = *params.At
+	}
+
+	i.Status = params.Status
+	i.StatusReason = params.Reason
+	i.StatusChangedAt = &at
+	switch {
+	case params.Status == ItemStatusActive:
+		i.RetiredAt = nil
+		i.SalePrice = nil
+	case current == ItemStatusActive:
+		i.RetiredAt = &at
+	}
+	if params.Status == ItemStatusSold {
+		i.SalePrice = params.SalePrice
+	}
+	return nil
+}
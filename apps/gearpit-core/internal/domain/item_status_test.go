@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestItemStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from ItemStatus
+		to   ItemStatus
+		want bool
+	}{
+		{ItemStatusActive, ItemStatusRetired, true},
+		{ItemStatusActive, ItemStatusSold, true},
+		{ItemStatusBroken, ItemStatusActive, true},
+		{ItemStatusLost, ItemStatusActive, true},
+		{ItemStatusLost, ItemStatusSold, false},
+		{ItemStatusSold, ItemStatusActive, false},
+		{ItemStatusActive, ItemStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItem_TransitionTo(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	price := 12000
+
+	t.Run("Selling records date and price", func(t *testing.T) {
+		item := Item{Status: ItemStatusActive}
+		err := item.TransitionTo(ChangeItemStatusParams{Status: ItemStatusSold, Reason: "upgraded", SalePrice: &price}, now)
+		if err != nil {
+			t.Fatalf("TransitionTo() error = %v", err)
+		}
+		if item.RetiredAt == nil || !item.RetiredAt.Equal(now) {
+			t.Errorf("TransitionTo() RetiredAt = %v, want %v", item.RetiredAt, now)
+		}
+		if item.SalePrice == nil || *item.SalePrice != price {
+			t.Errorf("TransitionTo() SalePrice = %v, want %v", item.SalePrice, price)
+		}
+	})
+
+	t.Run("Sale price requires sold", func(t *testing.T) {
+		item := Item{Status: ItemStatusActive}
+		if err := item.TransitionTo(ChangeItemStatusParams{Status: ItemStatusRetired, SalePrice: &price}, now); err == nil {
+			t.Errorf("TransitionTo() expected error for sale price on retire")
+		}
+	})
+
+	t.Run("Reactivation clears retirement", func(t *testing.T) {
+		item := Item{Status: ItemStatusActive}
+		_ = item.TransitionTo(ChangeItemStatusParams{Status: ItemStatusBroken}, now)
+		if err := item.TransitionTo(ChangeItemStatusParams{Status: ItemStatusActive, Reason: "repaired"}, now.Add(time.Hour)); err != nil {
+			t.Fatalf("TransitionTo() error = %v", err)
+		}
+		if item.RetiredAt != nil {
+			t.Errorf("TransitionTo() RetiredAt = %v, want nil", item.RetiredAt)
+		}
+	})
+
+	t.Run("Empty status counts as active", func(t *testing.T) {
+		item := Item{}
+		if err := item.TransitionTo(ChangeItemStatusParams{Status: ItemStatusLost}, now); err != nil {
+			t.Errorf("TransitionTo() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// --- Loans ---
+
+type LoanStatus string
+
+const (
+	LoanStatusOpen     LoanStatus = "open"
+	LoanStatusReturned LoanStatus = "returned"
+)
+
+func ParseLoanStatus(s string) (LoanStatus, error) {
+	switch LoanStatus(s) {
+	case "", LoanStatusOpen, LoanStatusReturned:
+		return LoanStatus(s), nil
+	}
+	return "", fmt.Errorf("%w: loan status must be open or returned, not %q", ErrInvalidInput, s)
+}
+
+// LoanFilter narrows the loan list. Zero values mean "no constraint".
+type LoanFilter struct {
+	ItemID            string
+	BorrowerProfileID string
+	Status            LoanStatus
+}
+
+// Validate checks the borrower and the dates. A loan goes either to a
+// UserProfile or to a free-text contact, not both.
+func (l Loan) Validate() error {
+	hasProfile := l.BorrowerProfileID != nil && *l.BorrowerProfileID != ""
+	hasContact := strings.TrimSpace(l.BorrowerContact) != ""
+	if hasProfile == hasContact {
+		return fmt.Errorf("%w: a loan needs either borrowerProfileId or borrowerContact", ErrInvalidInput)
+	}
+	if l.LoanedAt.IsZero() {
+		return fmt.Errorf("%w: loanedAt is required", ErrInvalidInput)
+	}
+	if l.DueAt != nil && l.DueAt.Before(l.LoanedAt) {
+		return fmt.Errorf("%w: dueAt is before loanedAt", ErrInvalidInput)
+	}
+	if l.ReturnedAt != nil && l.ReturnedAt.Before(l.LoanedAt) {
+		return fmt.Errorf("%w: returnedAt is before loanedAt", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (l Loan) IsOpen() bool {
+	return l.ReturnedAt == nil
+}
+
+func (l Loan) IsOverdue(now time.Time) bool {
+	return l.IsOpen() && l.DueAt != nil && l.DueAt.Before(now)
+}
+
+// Overlaps reports whether the item is away during [from, to]. A loan lasts
+// until it is returned; open loans are expected back at DueAt, or never
+// when no due date is set.
+func (l Loan) Overlaps(from, to time.Time) bool {
+	if l.LoanedAt.After(to) {
+		return false
+	}
+	back := l.ReturnedAt
+	if back == nil {
+		back = l.DueAt
+	}
+	return back == nil || !back.Before(from)
+}
+
+// ItemName is the name of the loaded Item, or its ID.
+func (l Loan) ItemName() string {
+	if l.Item != nil {
+		return l.Item.Name
+	}
+	return l.ItemID
+}
+
+// BorrowerName is the profile name or the free-text contact.
+func (l Loan) BorrowerName() string {
+	if l.BorrowerProfile != nil {
+		return l.BorrowerProfile.Name
+	}
+	return l.BorrowerContact
+}
+
+// OverdueLoan is an open loan past its due date.
+type OverdueLoan struct {
+	Loan        Loan `json:"loan"`
+	DaysOverdue int  `json:"daysOverdue"`
+}
+
+func NewOverdueLoan(loan Loan, now time.Time) OverdueLoan {
+	days := int(math.Floor(now.Sub(*loan.DueAt).Hours() / 24))
+	return OverdueLoan{Loan: loan, DaysOverdue: days}
+}
+
+// TripWarningLoaned flags trip items that are lent out while the trip takes place.
+const TripWarningLoaned TripWarningType = "loaned"
+
+// LoanWarnings lists the loans of items that overlap the trip's dates.
+// Trips without dates cannot overlap anything.
+func (t Trip) LoanWarnings(loans []Loan) []TripWarning {
+	from, to := t.StartDate, t.EndDate
+	if to.IsZero() {
+		to = from
+	}
+	if from.IsZero() {
+		return nil
+	}
+
+	var warnings []TripWarning
+	for _, loan := range loans {
+		if !loan.Overlaps(from, to) {
+			continue
+		}
+		message := fmt.Sprintf("%s is lent to %s", loan.ItemName(), loan.BorrowerName())
+		if loan.DueAt != nil && loan.IsOpen() {
+			message += fmt.Sprintf(" until %s", loan.DueAt.Format("2006-01-02"))
+		}
+		warnings = append(warnings, TripWarning{
+			Type:     TripWarningLoaned,
+			ItemID:   loan.ItemID,
+			ItemName: loan.ItemName(),
+			Message:  message,
+		})
+	}
+	return warnings
+}
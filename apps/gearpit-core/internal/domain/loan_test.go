@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoan_Overlaps(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 8, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d int) *time.Time { tm := day(d); return &tm }
+	tripStart, tripEnd := day(10), day(12)
+
+	tests := []struct {
+		name string
+		loan Loan
+		want bool
+	}{
+		{"open without due date", Loan{LoanedAt: day(1)}, true},
+		{"due during trip", Loan{LoanedAt: day(1), DueAt: at(11)}, true},
+		{"due before trip", Loan{LoanedAt: day(1), DueAt: at(9)}, false},
+		{"returned before trip", Loan{LoanedAt: day(1), DueAt: at(20), ReturnedAt: at(5)}, false},
+		{"overdue and still out", Loan{LoanedAt: day(1), DueAt: at(3), ReturnedAt: at(11)}, true},
+		{"lent after trip", Loan{LoanedAt: day(13)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.loan.Overlaps(tripStart, tripEnd); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoan_Validate(t *testing.T) {
+	now := time.Now()
+	earlier := now.AddDate(0, 0, -1)
+	profile := "p1"
+
+	tests := []struct {
+		name    string
+		loan    Loan
+		wantErr bool
+	}{
+		{"contact", Loan{BorrowerContact: "Aki", LoanedAt: now}, false},
+		{"profile", Loan{BorrowerProfileID: &profile, LoanedAt: now}, false},
+		{"no borrower", Loan{LoanedAt: now}, true},
+		{"both borrowers", Loan{BorrowerProfileID: &profile, BorrowerContact: "Aki", LoanedAt: now}, true},
+		{"due before loaned", Loan{BorrowerContact: "Aki", LoanedAt: now, DueAt: &earlier}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.loan.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrip_LoanWarnings(t *testing.T) {
+	start := time.Date(2025, 8, 10, 0, 0, 0, 0, time.UTC)
+	loans := []Loan{{ItemID: "tent", Item: &Item{Name: "Tent"}, BorrowerProfile: &UserProfile{Name: "Ren"}, LoanedAt: start.AddDate(0, 0, -1)}}
+
+	warnings := Trip{StartDate: start}.LoanWarnings(loans)
+	if len(warnings) != 1 || warnings[0].Message != "Tent is lent to Ren" {
+		t.Errorf("LoanWarnings() = %+v", warnings)
+	}
+	if got := (Trip{}).LoanWarnings(loans); got != nil {
+		t.Errorf("LoanWarnings() for undated trip = %+v, want none", got)
+	}
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- Locations ---
+
+// NoLocation is the GearFilter.LocationID value that selects items without a location.
+const NoLocation = "none"
+
+// LocationPathSeparator joins location names into a path, e.g. "House / Garage / Shelf 2".
+const LocationPathSeparator = " / "
+
+// LocationTree indexes locations by ID to resolve paths without querying per item.
+type LocationTree map[string]Location
+
+func NewLocationTree(locations []Location) LocationTree {
+	tree := make(LocationTree, len(locations))
+	for _, l := range locations {
+		tree[l.ID] = l
+	}
+	return tree
+}
+
+// Ancestry returns the location and its parents, root first. The walk stops
+// at unknown parents and at cycles so broken data cannot hang a request.
+func (t LocationTree) Ancestry(id string) []Location {
+	var chain []Location
+	seen := map[string]bool{}
+	for current, ok := t[id]; ok && !seen[current.ID]; current, ok = t[derefString(current.ParentID)] {
+		seen[current.ID] = true
+		chain = append(chain, current)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// Path returns the full name of a location, or "" when it is unknown.
+func (t LocationTree) Path(id string) string {
+	chain := t.Ancestry(id)
+	names := make([]string, len(chain))
+	for i, l := range chain {
+		names[i] = l.Name
+	}
+	return strings.Join(names, LocationPathSeparator)
+}
+
+// WithPaths returns every location with Path filled, sorted by path.
+func (t LocationTree) WithPaths() []Location {
+	locations := make([]Location, 0, len(t))
+	for id, l := range t {
+		l.Path = t.Path(id)
+		locations = append(locations, l)
+	}
+	sort.Slice(locations, func(i, j int) bool { return locations[i].Path < locations[j].Path })
+	return locations
+}
+
+// ValidateParent rejects a parent that is the location itself or one of its
+// descendants, which would turn the tree into a cycle.
+func (t LocationTree) ValidateParent(id string, parentID *string) error {
+	if parentID == nil {
+		return nil
+	}
+	if _, ok := t[*parentID]; !ok {
+		return fmt.Errorf("%w: parent location %s", ErrNotFound, *parentID)
+	}
+	for _, ancestor := range t.Ancestry(*parentID) {
+		if ancestor.ID == id {
+			return fmt.Errorf("%w: a location cannot be moved inside itself", ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// ParentsFirst orders locations so every parent precedes its children, as
+// the parent_id foreign key requires when rows are inserted in several statements.
+func ParentsFirst(locations []Location) []Location {
+	tree := NewLocationTree(locations)
+	ordered := make([]Location, 0, len(locations))
+	added := map[string]bool{}
+	for _, l := range locations {
+		for _, ancestor := range tree.Ancestry(l.ID) {
+			if !added[ancestor.ID] {
+				added[ancestor.ID] = true
+				ordered = append(ordered, ancestor)
+			}
+		}
+	}
+	return ordered
+}
+
+// Validate checks the user-editable fields.
+func (l Location) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return fmt.Errorf("%w: location name is required", ErrInvalidInput)
+	}
+	if strings.Contains(l.Name, LocationPathSeparator) {
+		return fmt.Errorf("%w: location name cannot contain %q", ErrInvalidInput, LocationPathSeparator)
+	}
+	return nil
+}
+
+// ItemWhereabouts answers "where is it" for one item.
+type ItemWhereabouts struct {
+	Item      Item       `json:"item"`
+	Path      string     `json:"path"`      // Empty when the item has no location
+	Locations []Location `json:"locations"` // Root first
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func testLocations() []Location {
+	id := func(s string) *string { return &s }
+	return []Location{
+		{ID: "bin", Name: "Bin A", ParentID: id("shelf")},
+		{ID: "shelf", Name: "Shelf 2", ParentID: id("garage")},
+		{ID: "garage", Name: "Garage", ParentID: id("house")},
+		{ID: "house", Name: "House"},
+		{ID: "car", Name: "Car"},
+	}
+}
+
+func TestLocationTree_Path(t *testing.T) {
+	tree := NewLocationTree(testLocations())
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"bin", "House / Garage / Shelf 2 / Bin A"},
+		{"house", "House"},
+		{"unknown", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := tree.Path(tt.id); got != tt.want {
+				t.Errorf("Path() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocationTree_ValidateParent(t *testing.T) {
+	tree := NewLocationTree(testLocations())
+	id := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		id       string
+		parentID *string
+		wantErr  error
+	}{
+		{"top level", "garage", nil, nil},
+		{"other branch", "shelf", id("car"), nil},
+		{"new location", "", id("bin"), nil},
+		{"itself", "garage", id("garage"), ErrInvalidInput},
+		{"own descendant", "garage", id("bin"), ErrInvalidInput},
+		{"unknown parent", "garage", id("attic"), ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tree.ValidateParent(tt.id, tt.parentID)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateParent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocationTree_CycleDoesNotHang(t *testing.T) {
+	a, b := "a", "b"
+	tree := NewLocationTree([]Location{{ID: "a", Name: "A", ParentID: &b}, {ID: "b", Name: "B", ParentID: &a}})
+
+	if got := len(tree.Ancestry("a")); got != 2 {
+		t.Errorf("len(Ancestry()) = %v, want 2", got)
+	}
+}
+
+func TestParentsFirst(t *testing.T) {
+	ordered := ParentsFirst(testLocations())
+
+	if len(ordered) != 5 {
+		t.Fatalf("len(ParentsFirst()) = %v, want 5", len(ordered))
+	}
+	seen := map[string]bool{}
+	for _, l := range ordered {
+		if l.ParentID != nil && !seen[*l.ParentID] {
+			t.Errorf("%s comes before its parent %s", l.ID, *l.ParentID)
+		}
+		seen[l.ID] = true
+	}
+}
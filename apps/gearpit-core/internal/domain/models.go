@@ -1,9 +1,11 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"gorm.io/datatypes"
+	"gorm.io/gorm"
 )
 
 // --- Enums & Value Types ---
@@ -27,40 +29,101 @@ type Item struct {
 	Manufacturer string         `json:"manufacturer"`
 	WeightGram   int            `json:"weightGram"`
 	WeightType   WeightType     `gorm:"default:'base';not null" json:"weightType"` // "base", "consumable", "worn"
-	Unit         string         `json:"unit"`                                      // "g", "kg", "oz"
+	Unit         string         `json:"unit"`                                      // Preferred display unit: "g", "kg", "oz", "lb"
 	Properties   datatypes.JSON `json:"properties"`                                // Flexible fields (color, size, category, brand, etc.)
 
 	// Maintenance Tracking
 	UsageCount          int `gorm:"default:0" json:"usageCount"`
 	MaintenanceInterval int `gorm:"default:0" json:"maintenanceInterval"` // 0 means no tracking
+	// Log type that restarts the interval; empty restarts it on every log matching no rule
+	MaintenanceLogType string `gorm:"not null;default:''" json:"maintenanceLogType,omitempty"`
+	// Meter the interval counts on (see usage_meters.go); nil counts UsageCount
+	MaintenanceMeterID *string `gorm:"type:uuid" json:"maintenanceMeterId,omitempty"`
 
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	// Purchase (amounts in minor units of Currency, see gear_cost.go)
+	PurchasePrice *int       `json:"purchasePrice,omitempty"`
+	PurchaseDate  *time.Time `json:"purchaseDate,omitempty"`
+	Currency      string     `json:"currency,omitempty"` // ISO 4217, e.g. "JPY"
+	Vendor        string     `json:"vendor,omitempty"`
+	Storage       string     `json:"storage,omitempty"` // Free-text place, superseded by LocationID
+
+	// Warranty (the retailer is Vendor)
+	SerialNumber      string     `gorm:"index" json:"serialNumber,omitempty"` // Stored normalized, see NormalizeSerialNumber
+	WarrantyExpiresAt *time.Time `json:"warrantyExpiresAt,omitempty"`
+
+	// Barcode is a UPC/EAN/GTIN in NormalizeBarcode form, matched against the product catalog
+	Barcode string `gorm:"index" json:"barcode,omitempty"`
+
+	// Expiry of perishables and consumables (fuel, batteries, first-aid kits, food)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	LotNumber string     `json:"lotNumber,omitempty"` // Lot or batch printed on the package
+
+	// Where the item is kept; change it through LocationService.MoveItem so the move is recorded
+	LocationID *string `gorm:"type:uuid;index" json:"locationId,omitempty"`
+
+	// Stock of consumables (see stock.go)
+	StockQuantity    *int `json:"stockQuantity,omitempty"` // nil means stock is not tracked
+	ReorderThreshold int  `gorm:"default:0" json:"reorderThreshold"`
+
+	// Lifecycle (see TransitionTo)
+	Status          ItemStatus `gorm:"default:'active';not null;index" json:"status"`
+	StatusReason    string     `json:"statusReason,omitempty"`
+	StatusChangedAt *time.Time `json:"statusChangedAt,omitempty"`
+	RetiredAt       *time.Time `json:"retiredAt,omitempty"` // When the item left active service
+	SalePrice       *int       `json:"salePrice,omitempty"`
+
+	// Rolled-up weight of an assembly (see components.go); set where pack weights are totalled
+	TotalWeightGram *int           `gorm:"-" json:"totalWeightGram,omitempty"`
+	Display         *WeightDisplay `gorm:"-" json:"display,omitempty"` // Computed
+
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"` // Soft delete (trash)
+}
+
+// PropertyMap decodes Properties. Invalid or empty JSON yields an empty map.
+func (i Item) PropertyMap() map[string]interface{} {
+	props := map[string]interface{}{}
+	if len(i.Properties) > 0 {
+		_ = json.Unmarshal(i.Properties, &props)
+	}
+	return props
+}
+
+// PropertyString returns a string property such as "category" or "brand".
+func (i Item) PropertyString(key string) string {
+	if v, ok := i.PropertyMap()[key].(string); ok {
+		return v
+	}
+	return ""
 }
 
 type Kit struct {
-	ID          string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name        string    `gorm:"not null" json:"name"`
-	Description string    `json:"description"`
-	Items       []Item    `gorm:"many2many:kit_items;" json:"items"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Description string         `json:"description"`
+	Items       []Item         `gorm:"many2many:kit_items;" json:"items"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
 type Loadout struct {
-	ID                   string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name                 string    `gorm:"not null" json:"name"`
-	ActivityType         string    `json:"activityType"` // hiking, camping, climbing
-	Kits                 []Kit     `gorm:"many2many:loadout_kits;" json:"kits"`
-	Items                []Item    `gorm:"many2many:loadout_items;" json:"items"`
-	TargetWeightGram     *int      `json:"targetWeightGram"`              // User defined budget (nullable)
-	TotalWeightGram      int       `json:"totalWeightGram"`               // Computed
-	BaseWeightGram       int       `json:"baseWeightGram" gorm:"-"`       // Computed
-	ConsumableWeightGram int       `json:"consumableWeightGram" gorm:"-"` // Computed
-	WornWeightGram       int       `json:"wornWeightGram" gorm:"-"`       // Computed
-	LongWeightGram       int       `json:"longWeightGram" gorm:"-"`       // Computed
-	CreatedAt            time.Time `json:"createdAt"`
-	UpdatedAt            time.Time `json:"updatedAt"`
+	ID                   string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name                 string         `gorm:"not null" json:"name"`
+	ActivityType         string         `json:"activityType"` // hiking, camping, climbing
+	Kits                 []Kit          `gorm:"many2many:loadout_kits;" json:"kits"`
+	Items                []Item         `gorm:"many2many:loadout_items;" json:"items"`
+	TargetWeightGram     *int           `json:"targetWeightGram"`              // User defined budget (nullable)
+	TotalWeightGram      int            `json:"totalWeightGram"`               // Computed
+	BaseWeightGram       int            `json:"baseWeightGram" gorm:"-"`       // Computed
+	ConsumableWeightGram int            `json:"consumableWeightGram" gorm:"-"` // Computed
+	WornWeightGram       int            `json:"wornWeightGram" gorm:"-"`       // Computed
+	LongWeightGram       int            `json:"longWeightGram" gorm:"-"`       // Computed
+	Display              *WeightDisplay `json:"display,omitempty" gorm:"-"`    // Computed
+	CreatedAt            time.Time      `json:"createdAt"`
+	UpdatedAt            time.Time      `json:"updatedAt"`
+	DeletedAt            gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
 type MaintenanceLog struct {
@@ -71,20 +134,25 @@ type MaintenanceLog struct {
 	Cost        int    `json:"cost"`
 
 	PerformedAt   time.Time `gorm:"not null" json:"performedAt"`
-	SnapshotUsage int       `json:"snapshotUsage"` // Records usage_count at the time of maintenance
+	SnapshotUsage int       `json:"snapshotUsage"`        // Records usage_count at the time of maintenance
+	OdometerKm    *int      `json:"odometerKm,omitempty"` // Vehicles: odometer at the time of maintenance
+	MeterTotal    *float64  `json:"meterTotal,omitempty"` // Total of the item's maintenance meter at the time
 
-	CreatedAt time.Time `json:"createdAt"`
+	CreatedAt time.Time      `json:"createdAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
 type UserProfile struct {
-	ID        string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name      string    `gorm:"not null" json:"name"`
-	HeightCm  float64   `json:"heightCm"`
-	WeightKg  float64   `json:"weightKg"`
-	Age       int       `json:"age"`
-	Gender    string    `json:"gender"` // "male", "female", "other"
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name       string         `gorm:"not null" json:"name"`
+	HeightCm   float64        `json:"heightCm"`
+	WeightKg   float64        `json:"weightKg"`
+	Age        int            `json:"age"`
+	Gender     string         `json:"gender"`                             // "male", "female", "other"
+	UnitSystem UnitSystem     `gorm:"default:'metric'" json:"unitSystem"` // Weight display preference
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
 type Trip struct {
@@ -103,6 +171,11 @@ type Trip struct {
 	// Computed Stats (Not persisted)
 	PredictedHydrationML int `gorm:"-" json:"predictedHydrationML"`
 	PredictedCalories    int `gorm:"-" json:"predictedCalories"`
+	PackWeightGram       int `gorm:"-" json:"packWeightGram"`
+
+	Warnings []TripWarning `gorm:"-" json:"warnings,omitempty"`
+
+	Display *WeightDisplay `gorm:"-" json:"display,omitempty"`
 
 	// User Profile Link
 	UserProfileID *string      `gorm:"type:uuid" json:"userProfileId,omitempty"` // Nullable
@@ -112,8 +185,9 @@ type Trip struct {
 	Items     []Item     `gorm:"many2many:trip_items;" json:"-"`
 	TripItems []TripItem `gorm:"foreignKey:TripID" json:"tripItems,omitempty"`
 
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
 // GORM Join Table
@@ -131,19 +205,247 @@ func (TripItem) TableName() string {
 	return "trip_items"
 }
 
+// ItemComponent puts Quantity of one item into an assembly (bill of materials).
+// Components are items themselves, so they keep their own usage and maintenance.
+type ItemComponent struct {
+	ParentID    string    `gorm:"type:uuid;primaryKey" json:"parentId"`
+	ComponentID string    `gorm:"type:uuid;primaryKey;index" json:"componentId"`
+	Quantity    int       `gorm:"default:1;not null" json:"quantity"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (ItemComponent) TableName() string {
+	return "item_components"
+}
+
+// --- Property Schemas ---
+
+// PropertySchema defines the typed attributes that items of a category carry in Properties.
+type PropertySchema struct {
+	ID          string                             `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Category    string                             `gorm:"uniqueIndex;not null" json:"category"`
+	Description string                             `json:"description"`
+	Fields      datatypes.JSONSlice[PropertyField] `gorm:"type:jsonb" json:"fields"`
+	CreatedAt   time.Time                          `json:"createdAt"`
+	UpdatedAt   time.Time                          `json:"updatedAt"`
+}
+
+// Attachment is a file (photo, receipt, manual) belonging to an item or maintenance log.
+// The content lives in BlobStorage under StorageKey.
+type Attachment struct {
+	ID           string          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OwnerType    AttachmentOwner `gorm:"not null;index:idx_attachments_owner" json:"ownerType"`
+	OwnerID      string          `gorm:"type:uuid;not null;index:idx_attachments_owner" json:"ownerId"`
+	FileName     string          `gorm:"not null" json:"fileName"`
+	ContentType  string          `gorm:"not null" json:"contentType"`
+	Size         int64           `json:"size"`
+	HasThumbnail bool            `gorm:"default:false" json:"hasThumbnail"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// Location is a place gear is kept. Locations nest: house > garage > shelf > bin.
+type Location struct {
+	ID          string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Description string    `json:"description"`
+	ParentID    *string   `gorm:"type:uuid;index" json:"parentId,omitempty"` // nil for top-level places
+	Path        string    `gorm:"-" json:"path,omitempty"`                   // Computed, e.g. "House / Garage / Shelf 2"
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// ItemMove records an item changing location. Paths are kept as text so the
+// history stays readable after locations are renamed or deleted.
+type ItemMove struct {
+	ID             string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ItemID         string    `gorm:"type:uuid;not null;index" json:"itemId"`
+	FromLocationID *string   `gorm:"type:uuid" json:"fromLocationId,omitempty"`
+	ToLocationID   *string   `gorm:"type:uuid" json:"toLocationId,omitempty"`
+	FromPath       string    `json:"fromPath"`
+	ToPath         string    `json:"toPath"`
+	Note           string    `json:"note,omitempty"`
+	MovedAt        time.Time `gorm:"not null" json:"movedAt"`
+}
+
+// Loan lends an item to a team member (BorrowerProfile) or to someone outside the app (BorrowerContact).
+type Loan struct {
+	ID                string       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ItemID            string       `gorm:"type:uuid;not null;index" json:"itemId"`
+	Item              *Item        `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+	BorrowerProfileID *string      `gorm:"type:uuid;index" json:"borrowerProfileId,omitempty"`
+	BorrowerProfile   *UserProfile `gorm:"foreignKey:BorrowerProfileID" json:"borrowerProfile,omitempty"`
+	BorrowerContact   string       `json:"borrowerContact,omitempty"` // Name, phone or email
+	LoanedAt          time.Time    `gorm:"not null" json:"loanedAt"`
+	DueAt             *time.Time   `json:"dueAt,omitempty"`
+	ReturnedAt        *time.Time   `gorm:"index" json:"returnedAt,omitempty"` // nil while the item is out
+	Note              string       `json:"note,omitempty"`
+	CreatedAt         time.Time    `json:"createdAt"`
+	UpdatedAt         time.Time    `json:"updatedAt"`
+}
+
+// OdometerReading records a vehicle's odometer at a point in time (see service_rules.go).
+type OdometerReading struct {
+	ID        string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ItemID    string    `gorm:"type:uuid;not null;index" json:"itemId"`
+	Km        int       `gorm:"not null" json:"km"`
+	ReadAt    time.Time `gorm:"not null" json:"readAt"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ServiceRule schedules maintenance of LogType for one item, or for every item of a
+// Category. The usage interval runs on the odometer (IntervalKm) or on the item's usage
+// meter named MeterName (IntervalUsage); IntervalMonths adds a calendar interval and
+// whichever comes first is due. Only a maintenance log of LogType restarts the rule.
+type ServiceRule struct {
+	ID       string  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ItemID   *string `gorm:"type:uuid;index" json:"itemId,omitempty"`
+	Item     *Item   `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+	Category string  `gorm:"index" json:"category,omitempty"` // Item rules replace category rules of the same LogType
+	Name     string  `gorm:"not null" json:"name"`            // "Oil change"
+	LogType  string  `gorm:"not null" json:"logType"`         // MaintenanceLog.Type that completes the service, e.g. "oil"
+
+	IntervalKm     *int     `json:"intervalKm,omitempty"`
+	MeterName      string   `json:"meterName,omitempty"`     // e.g. "Ski days"
+	IntervalUsage  *float64 `json:"intervalUsage,omitempty"` // In the meter's unit
+	IntervalMonths *int     `json:"intervalMonths,omitempty"`
+
+	// How far past due the service may run before it is overdue
+	GraceUsage *float64 `json:"graceUsage,omitempty"` // In km or the meter's unit
+	GraceDays  *int     `json:"graceDays,omitempty"`
+
+	// Where the intervals start until a matching log exists (default: when the rule was
+	// created, at the odometer and meter of that time). Km and usage are for item rules only.
+	LastServiceKm    *int       `json:"lastServiceKm,omitempty"`
+	LastServiceUsage *float64   `json:"lastServiceUsage,omitempty"`
+	LastServiceAt    *time.Time `json:"lastServiceAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// UsageMeter counts the use of an item in one unit, e.g. ski days or rope falls (see usage_meters.go).
+type UsageMeter struct {
+	ID     string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ItemID string    `gorm:"type:uuid;not null;uniqueIndex:idx_usage_meters_item_name" json:"itemId"`
+	Name   string    `gorm:"not null;uniqueIndex:idx_usage_meters_item_name" json:"name"` // "Falls", "Burn time"
+	Unit   MeterUnit `gorm:"not null" json:"unit"`
+
+	Total           float64 `gorm:"not null;default:0" json:"total"`           // Lifetime usage
+	ServicedAtTotal float64 `gorm:"not null;default:0" json:"servicedAtTotal"` // Total when the item was last maintained
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// UsageEntry is one increment of a meter, entered by hand or by a completed trip.
+type UsageEntry struct {
+	ID         string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	MeterID    string    `gorm:"type:uuid;not null;index" json:"meterId"`
+	TripID     *string   `gorm:"type:uuid;index" json:"tripId,omitempty"`
+	Amount     float64   `gorm:"not null" json:"amount"`
+	Note       string    `json:"note,omitempty"`
+	RecordedAt time.Time `gorm:"not null" json:"recordedAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// TripMeterUsage is the use a trip puts on a meter, recorded when the trip is completed.
+type TripMeterUsage struct {
+	TripID  string      `gorm:"type:uuid;primaryKey" json:"tripId"`
+	MeterID string      `gorm:"type:uuid;primaryKey" json:"meterId"`
+	Meter   *UsageMeter `gorm:"foreignKey:MeterID" json:"meter,omitempty"`
+	Amount  float64     `gorm:"not null" json:"amount"`
+}
+
+func (TripMeterUsage) TableName() string {
+	return "trip_meter_usages"
+}
+
+// PCBuild is a PC configuration: inventory items assigned to typed slots (see pc_build.go).
+type PCBuild struct {
+	ID          string      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name        string      `gorm:"not null" json:"name"`
+	Description string      `json:"description"`
+	Parts       []BuildPart `gorm:"foreignKey:BuildID" json:"parts"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+func (PCBuild) TableName() string {
+	return "pc_builds"
+}
+
+type BuildPart struct {
+	BuildID string    `gorm:"type:uuid;primaryKey" json:"buildId"`
+	ItemID  string    `gorm:"type:uuid;primaryKey;index" json:"itemId"`
+	Slot    BuildSlot `gorm:"not null" json:"slot"`
+	Item    *Item     `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+}
+
+func (BuildPart) TableName() string {
+	return "pc_build_parts"
+}
+
+// AuditEntry records one field of an audited entity changing. Values are JSON,
+// so a change can be shown and reverted without knowing the field's type.
+type AuditEntry struct {
+	ID         string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	EntityType AuditEntity    `gorm:"not null;index:idx_audit_entries_entity" json:"entityType"`
+	EntityID   string         `gorm:"type:uuid;not null;index:idx_audit_entries_entity" json:"entityId"`
+	Field      string         `gorm:"not null" json:"field"` // JSON name, e.g. "weightGram"
+	OldValue   datatypes.JSON `gorm:"type:jsonb" json:"oldValue"`
+	NewValue   datatypes.JSON `gorm:"type:jsonb" json:"newValue"`
+	Actor      string         `gorm:"not null" json:"actor"`
+	RevertOfID *string        `gorm:"type:uuid" json:"revertOfId,omitempty"` // Entry this change reverted
+	ChangedAt  time.Time      `gorm:"not null;index" json:"changedAt"`
+}
+
+// CatalogProduct is a store product known by barcode, imported from a catalog dump.
+// Scanning its barcode prefills a new item. Price is in minor units of Currency.
+type CatalogProduct struct {
+	Barcode      string    `gorm:"primaryKey" json:"barcode"` // NormalizeBarcode form
+	Name         string    `gorm:"not null" json:"name"`
+	Manufacturer string    `json:"manufacturer,omitempty"`
+	Brand        string    `json:"brand,omitempty"`
+	Category     string    `json:"category,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	WeightGram   int       `json:"weightGram,omitempty"`
+	Price        *int      `json:"price,omitempty"`
+	Currency     string    `json:"currency,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// DepreciationRule sets how items of a category lose value in the valuation report.
+type DepreciationRule struct {
+	ID                string             `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Category          string             `gorm:"uniqueIndex;not null" json:"category"`
+	Method            DepreciationMethod `gorm:"not null" json:"method"`
+	LifeMonths        int                `json:"lifeMonths,omitempty"`        // straightLine: months until salvage value
+	AnnualRatePercent float64            `json:"annualRatePercent,omitempty"` // decliningBalance: value lost per year
+	SalvagePercent    float64            `json:"salvagePercent"`              // Floor as percent of purchase price
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+}
+
 // --- Dashboard Stats ---
 
 type CategoryStat struct {
 	Category    string `json:"category"`
 	Count       int    `json:"count"`
 	TotalWeight int    `json:"totalWeight"`
+
+	Display *WeightDisplay `json:"display,omitempty"` // Computed
 }
 
 type DashboardStats struct {
-	TotalItems    int64          `json:"totalItems"`
-	TotalWeight   int            `json:"totalWeight"`
-	TotalLoadouts int64          `json:"totalLoadouts"`
-	TotalCost     int            `json:"totalCost"`
-	LongWeight    int            `json:"longWeight"`
-	CategoryStats []CategoryStat `json:"categoryStats"`
+	TotalItems    int64              `json:"totalItems"`
+	TotalWeight   int                `json:"totalWeight"`
+	TotalLoadouts int64              `json:"totalLoadouts"`
+	TotalCost     int                `json:"totalCost"`
+	LongWeight    int                `json:"longWeight"`
+	CategoryStats []CategoryStat     `json:"categoryStats"`
+	Costs         []CurrencyCostStat `json:"costs"` // Purchase + maintenance per currency
+
+	Display *WeightDisplay `json:"display,omitempty"` // Computed
 }
@@ -0,0 +1,287 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// --- PC Builds ---
+
+type BuildSlot string
+
+const (
+	BuildSlotCPU         BuildSlot = "cpu"
+	BuildSlotMotherboard BuildSlot = "motherboard"
+	BuildSlotRAM         BuildSlot = "ram"
+	BuildSlotGPU         BuildSlot = "gpu"
+	BuildSlotPSU         BuildSlot = "psu"
+	BuildSlotCase        BuildSlot = "case"
+)
+
+// BuildSlots lists every slot in report order. A complete build fills all but the GPU.
+var BuildSlots = []BuildSlot{BuildSlotCPU, BuildSlotMotherboard, BuildSlotRAM, BuildSlotGPU, BuildSlotPSU, BuildSlotCase}
+
+func ParseBuildSlot(s string) (BuildSlot, error) {
+	for _, slot := range BuildSlots {
+		if BuildSlot(strings.ToLower(strings.TrimSpace(s))) == slot {
+			return slot, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown build slot %q", ErrInvalidInput, s)
+}
+
+// AllowsMultiple reports whether the slot takes several items (RAM modules, GPUs).
+func (s BuildSlot) AllowsMultiple() bool {
+	return s == BuildSlotRAM || s == BuildSlotGPU
+}
+
+// Item.Properties keys the compatibility rules read. Values may be JSON numbers or
+// strings; string values are compared ignoring case, spaces and dashes.
+const (
+	PCPropSocket     = "socket"     // CPU, motherboard: "AM5", "LGA1700"
+	PCPropMemoryType = "memoryType" // Motherboard, RAM: "DDR4", "DDR5"
+	PCPropFormFactor = "formFactor" // Motherboard, case: "Mini-ITX", "Micro-ATX", "ATX", "E-ATX"
+	PCPropTDP        = "tdpW"       // Any part drawing power, in watts
+	PCPropWattage    = "wattageW"   // PSU rated output, in watts
+)
+
+// PSUHeadroom is how far the PSU rating should exceed the summed TDP.
+const PSUHeadroom = 1.2
+
+// formFactorSizes ranks board sizes; a case fits boards up to its own size.
+var formFactorSizes = map[string]int{
+	"miniitx": 1, "itx": 1,
+	"microatx": 2, "matx": 2, "uatx": 2,
+	"atx":  3,
+	"eatx": 4, "extendedatx": 4,
+}
+
+type CompatibilityRule string
+
+const (
+	RuleRequiredSlots CompatibilityRule = "requiredSlots"
+	RuleSocket        CompatibilityRule = "socket"
+	RuleMemoryType    CompatibilityRule = "memoryType"
+	RulePSUWattage    CompatibilityRule = "psuWattage"
+	RuleFormFactor    CompatibilityRule = "formFactor"
+)
+
+type CheckStatus string
+
+const (
+	CheckPass    CheckStatus = "pass"
+	CheckWarning CheckStatus = "warning"
+	CheckFail    CheckStatus = "fail"
+	CheckUnknown CheckStatus = "unknown" // A property the rule needs is missing
+)
+
+type CompatibilityCheck struct {
+	Rule    CompatibilityRule `json:"rule"`
+	Status  CheckStatus       `json:"status"`
+	Message string            `json:"message"`
+	ItemIDs []string          `json:"itemIds"`
+}
+
+// BuildReport is the result of validating a build. Compatible is false only for
+// failed checks; warnings and unknowns are left to the builder.
+type BuildReport struct {
+	BuildID      string               `json:"buildId"`
+	Compatible   bool                 `json:"compatible"`
+	Complete     bool                 `json:"complete"`
+	MissingSlots []BuildSlot          `json:"missingSlots"`
+	TotalTDPW    float64              `json:"totalTdpW"`
+	PSUWattageW  float64              `json:"psuWattageW"`
+	Checks       []CompatibilityCheck `json:"checks"`
+}
+
+// ValidateBuildParts checks slots and cardinality and returns the parts with item
+// IDs trimmed and slots normalized.
+func ValidateBuildParts(parts []BuildPart) ([]BuildPart, error) {
+	seenItems := map[string]bool{}
+	seenSlots := map[BuildSlot]bool{}
+	cleaned := make([]BuildPart, 0, len(parts))
+	for _, p := range parts {
+		slot, err := ParseBuildSlot(string(p.Slot))
+		if err != nil {
+			return nil, err
+		}
+		id := strings.TrimSpace(p.ItemID)
+		switch {
+		case id == "":
+			return nil, fmt.Errorf("%w: part itemId is required", ErrInvalidInput)
+		case seenItems[id]:
+			return nil, fmt.Errorf("%w: item %s is used twice", ErrInvalidInput, id)
+		case seenSlots[slot] && !slot.AllowsMultiple():
+			return nil, fmt.Errorf("%w: a build has only one %s", ErrInvalidInput, slot)
+		}
+		seenItems[id] = true
+		seenSlots[slot] = true
+		cleaned = append(cleaned, BuildPart{ItemID: id, Slot: slot})
+	}
+	return cleaned, nil
+}
+
+// Report evaluates the compatibility rules against the parts' properties.
+// Parts whose item is not loaded (e.g. trashed) are ignored.
+func (b PCBuild) Report() BuildReport {
+	bySlot := map[BuildSlot][]Item{}
+	for _, p := range b.Parts {
+		if p.Item != nil {
+			bySlot[p.Slot] = append(bySlot[p.Slot], *p.Item)
+		}
+	}
+	report := BuildReport{BuildID: b.ID, MissingSlots: []BuildSlot{}, Checks: []CompatibilityCheck{}}
+
+	for _, slot := range BuildSlots {
+		if slot != BuildSlotGPU && len(bySlot[slot]) == 0 {
+			report.MissingSlots = append(report.MissingSlots, slot)
+		}
+	}
+	report.Complete = len(report.MissingSlots) == 0
+	if !report.Complete {
+		report.Checks = append(report.Checks, CompatibilityCheck{
+			Rule:    RuleRequiredSlots,
+			Status:  CheckWarning,
+			Message: fmt.Sprintf("missing %s", joinSlots(report.MissingSlots)),
+			ItemIDs: []string{},
+		})
+	}
+
+	cpu, hasCPU := firstItem(bySlot[BuildSlotCPU])
+	board, hasBoard := firstItem(bySlot[BuildSlotMotherboard])
+	pcCase, hasCase := firstItem(bySlot[BuildSlotCase])
+	psu, hasPSU := firstItem(bySlot[BuildSlotPSU])
+
+	if hasCPU && hasBoard {
+		report.Checks = append(report.Checks, matchCheck(RuleSocket, PCPropSocket, cpu, board))
+	}
+	if hasBoard {
+		for _, ram := range bySlot[BuildSlotRAM] {
+			report.Checks = append(report.Checks, matchCheck(RuleMemoryType, PCPropMemoryType, ram, board))
+		}
+	}
+	if hasBoard && hasCase {
+		report.Checks = append(report.Checks, formFactorCheck(board, pcCase))
+	}
+
+	var tdpIDs, unknownTDP []string
+	for _, slot := range BuildSlots {
+		if slot == BuildSlotPSU {
+			continue
+		}
+		for _, item := range bySlot[slot] {
+			if tdp, ok := numberProperty(item, PCPropTDP); ok {
+				report.TotalTDPW += tdp
+				tdpIDs = append(tdpIDs, item.ID)
+			} else if slot == BuildSlotCPU || slot == BuildSlotGPU {
+				unknownTDP = append(unknownTDP, item.Name)
+			}
+		}
+	}
+	if hasPSU {
+		check := CompatibilityCheck{Rule: RulePSUWattage, ItemIDs: append([]string{psu.ID}, tdpIDs...)}
+		wattage, ok := numberProperty(psu, PCPropWattage)
+		report.PSUWattageW = wattage
+		switch {
+		case !ok:
+			check.Status = CheckUnknown
+			check.Message = fmt.Sprintf("%s has no %s property", psu.Name, PCPropWattage)
+		case wattage < report.TotalTDPW:
+			check.Status = CheckFail
+			check.Message = fmt.Sprintf("PSU delivers %gW but the parts draw %gW", wattage, report.TotalTDPW)
+		case len(unknownTDP) > 0:
+			check.Status = CheckUnknown
+			check.Message = fmt.Sprintf("%s has no %s property", strings.Join(unknownTDP, ", "), PCPropTDP)
+		case wattage < report.TotalTDPW*PSUHeadroom:
+			check.Status = CheckWarning
+			check.Message = fmt.Sprintf("PSU delivers %gW, less than %g%% headroom over %gW", wattage, (PSUHeadroom-1)*100, report.TotalTDPW)
+		default:
+			check.Status = CheckPass
+			check.Message = fmt.Sprintf("PSU delivers %gW for %gW", wattage, report.TotalTDPW)
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	report.Compatible = true
+	for _, c := range report.Checks {
+		if c.Status == CheckFail {
+			report.Compatible = false
+		}
+	}
+	return report
+}
+
+// matchCheck requires both items to have the same value for key.
+func matchCheck(rule CompatibilityRule, key string, a, b Item) CompatibilityCheck {
+	check := CompatibilityCheck{Rule: rule, ItemIDs: []string{a.ID, b.ID}}
+	va, vb := a.PropertyString(key), b.PropertyString(key)
+	switch {
+	case va == "" || vb == "":
+		check.Status = CheckUnknown
+		check.Message = fmt.Sprintf("%s needs %s on %s and %s", rule, key, a.Name, b.Name)
+	case normalizePCValue(va) != normalizePCValue(vb):
+		check.Status = CheckFail
+		check.Message = fmt.Sprintf("%s is %s but %s is %s", a.Name, va, b.Name, vb)
+	default:
+		check.Status = CheckPass
+		check.Message = fmt.Sprintf("both %s", va)
+	}
+	return check
+}
+
+func formFactorCheck(board, pcCase Item) CompatibilityCheck {
+	check := CompatibilityCheck{Rule: RuleFormFactor, ItemIDs: []string{board.ID, pcCase.ID}}
+	boardValue, caseValue := board.PropertyString(PCPropFormFactor), pcCase.PropertyString(PCPropFormFactor)
+	boardSize, okBoard := formFactorSizes[normalizePCValue(boardValue)]
+	caseSize, okCase := formFactorSizes[normalizePCValue(caseValue)]
+	switch {
+	case !okBoard || !okCase:
+		check.Status = CheckUnknown
+		check.Message = fmt.Sprintf("unknown form factor %q or %q", boardValue, caseValue)
+	case boardSize > caseSize:
+		check.Status = CheckFail
+		check.Message = fmt.Sprintf("%s board does not fit a %s case", boardValue, caseValue)
+	default:
+		check.Status = CheckPass
+		check.Message = fmt.Sprintf("%s board fits a %s case", boardValue, caseValue)
+	}
+	return check
+}
+
+// numberProperty reads a numeric property stored as a JSON number or numeric string.
+func numberProperty(item Item, key string) (float64, bool) {
+	switch v := item.PropertyMap()[key].(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(v), "W")), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func normalizePCValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+func firstItem(items []Item) (Item, bool) {
+	if len(items) == 0 {
+		return Item{}, false
+	}
+	return items[0], true
+}
+
+func joinSlots(slots []BuildSlot) string {
+	names := make([]string, len(slots))
+	for i, s := range slots {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func pcPart(t *testing.T, id string, slot BuildSlot, props map[string]interface{}) BuildPart {
+	t.Helper()
+	raw, err := json.Marshal(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return BuildPart{ItemID: id, Slot: slot, Item: &Item{ID: id, Name: id, Properties: raw}}
+}
+
+func TestValidateBuildParts(t *testing.T) {
+	tests := []struct {
+		name    string
+		parts   []BuildPart
+		wantErr bool
+	}{
+		{"Valid", []BuildPart{{ItemID: "cpu", Slot: "CPU"}, {ItemID: "ram1", Slot: BuildSlotRAM}, {ItemID: "ram2", Slot: BuildSlotRAM}}, false},
+		{"Unknown slot", []BuildPart{{ItemID: "fan", Slot: "cooler"}}, true},
+		{"Two CPUs", []BuildPart{{ItemID: "a", Slot: BuildSlotCPU}, {ItemID: "b", Slot: BuildSlotCPU}}, true},
+		{"Item used twice", []BuildPart{{ItemID: "a", Slot: BuildSlotRAM}, {ItemID: "a", Slot: BuildSlotGPU}}, true},
+		{"Missing item", []BuildPart{{ItemID: " ", Slot: BuildSlotCase}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateBuildParts(tt.parts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateBuildParts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("ValidateBuildParts() error = %v, want ErrInvalidInput", err)
+			}
+			if err == nil && got[0].Slot != BuildSlotCPU {
+				t.Errorf("ValidateBuildParts() slot = %v, want %v", got[0].Slot, BuildSlotCPU)
+			}
+		})
+	}
+}
+
+func TestPCBuildReport(t *testing.T) {
+	cpu := pcPart(t, "cpu", BuildSlotCPU, map[string]interface{}{"socket": "AM5", "tdpW": 120})
+	board := pcPart(t, "board", BuildSlotMotherboard, map[string]interface{}{"socket": "am5", "memoryType": "DDR5", "formFactor": "Micro-ATX"})
+	ram := pcPart(t, "ram", BuildSlotRAM, map[string]interface{}{"memoryType": "DDR5"})
+	gpu := pcPart(t, "gpu", BuildSlotGPU, map[string]interface{}{"tdpW": "320"})
+	psu := pcPart(t, "psu", BuildSlotPSU, map[string]interface{}{"wattageW": 750})
+	pcCase := pcPart(t, "case", BuildSlotCase, map[string]interface{}{"formFactor": "ATX"})
+
+	statuses := func(r BuildReport) map[CompatibilityRule]CheckStatus {
+		got := map[CompatibilityRule]CheckStatus{}
+		for _, c := range r.Checks {
+			got[c.Rule] = c.Status
+		}
+		return got
+	}
+
+	t.Run("Compatible build", func(t *testing.T) {
+		report := PCBuild{Parts: []BuildPart{cpu, board, ram, gpu, psu, pcCase}}.Report()
+		if !report.Compatible || !report.Complete {
+			t.Fatalf("Report() compatible = %v, complete = %v, want true, true", report.Compatible, report.Complete)
+		}
+		if report.TotalTDPW != 440 {
+			t.Errorf("TotalTDPW = %v, want %v", report.TotalTDPW, 440)
+		}
+		for rule, status := range statuses(report) {
+			if status != CheckPass {
+				t.Errorf("%s = %v, want %v", rule, status, CheckPass)
+			}
+		}
+	})
+
+	t.Run("Incompatible parts", func(t *testing.T) {
+		intel := pcPart(t, "cpu", BuildSlotCPU, map[string]interface{}{"socket": "LGA 1700", "tdpW": 125})
+		ddr4 := pcPart(t, "ram", BuildSlotRAM, map[string]interface{}{"memoryType": "DDR4"})
+		small := pcPart(t, "psu", BuildSlotPSU, map[string]interface{}{"wattageW": 400})
+		itx := pcPart(t, "case", BuildSlotCase, map[string]interface{}{"formFactor": "Mini-ITX"})
+
+		report := PCBuild{Parts: []BuildPart{intel, board, ddr4, gpu, small, itx}}.Report()
+		if report.Compatible {
+			t.Fatal("Report() compatible = true, want false")
+		}
+		want := map[CompatibilityRule]CheckStatus{
+			RuleSocket:     CheckFail,
+			RuleMemoryType: CheckFail,
+			RulePSUWattage: CheckFail,
+			RuleFormFactor: CheckFail,
+		}
+		got := statuses(report)
+		for rule, status := range want {
+			if got[rule] != status {
+				t.Errorf("%s = %v, want %v", rule, got[rule], status)
+			}
+		}
+	})
+
+	t.Run("Tight PSU and missing data", func(t *testing.T) {
+		tight := pcPart(t, "psu", BuildSlotPSU, map[string]interface{}{"wattageW": 480})
+		bare := pcPart(t, "board", BuildSlotMotherboard, map[string]interface{}{})
+
+		report := PCBuild{Parts: []BuildPart{cpu, bare, gpu, tight}}.Report()
+		if !report.Compatible || report.Complete {
+			t.Fatalf("Report() compatible = %v, complete = %v, want true, false", report.Compatible, report.Complete)
+		}
+		want := map[CompatibilityRule]CheckStatus{
+			RuleRequiredSlots: CheckWarning,
+			RuleSocket:        CheckUnknown,
+			RulePSUWattage:    CheckWarning,
+		}
+		got := statuses(report)
+		for rule, status := range want {
+			if got[rule] != status {
+				t.Errorf("%s = %v, want %v", rule, got[rule], status)
+			}
+		}
+		if len(report.MissingSlots) != 2 {
+			t.Errorf("MissingSlots = %v, want ram and case", report.MissingSlots)
+		}
+	})
+}
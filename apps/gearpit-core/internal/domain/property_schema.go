@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type PropertyType string
+
+const (
+	PropertyTypeNumber  PropertyType = "number"
+	PropertyTypeInteger PropertyType = "integer"
+	PropertyTypeString  PropertyType = "string"
+	PropertyTypeBoolean PropertyType = "boolean"
+	PropertyTypeEnum    PropertyType = "enum"
+)
+
+// PropertyField is one typed attribute of a PropertySchema.
+type PropertyField struct {
+	Key      string       `json:"key"`
+	Label    string       `json:"label"`
+	Type     PropertyType `json:"type"`
+	Unit     string       `json:"unit,omitempty"` // Informational, e.g. "mm", "W", "GB"
+	Required bool         `json:"required"`
+	Min      *float64     `json:"min,omitempty"`
+	Max      *float64     `json:"max,omitempty"`
+	Options  []string     `json:"options,omitempty"` // Allowed values for enum
+}
+
+// reservedPropertyKeys are maintained by gearService and cannot be schema fields.
+var reservedPropertyKeys = map[string]bool{"category": true, "brand": true, "tags": true}
+
+// IsReservedPropertyKey reports whether key is managed outside of schemas.
+func IsReservedPropertyKey(key string) bool {
+	return reservedPropertyKeys[key]
+}
+
+// ValidateDefinition checks that the schema itself is well formed.
+func (s PropertySchema) ValidateDefinition() error {
+	if strings.TrimSpace(s.Category) == "" {
+		return fmt.Errorf("%w: schema category is required", ErrInvalidInput)
+	}
+	seen := map[string]bool{}
+	for _, f := range s.Fields {
+		if f.Key == "" {
+			return fmt.Errorf("%w: field key is required", ErrInvalidInput)
+		}
+		if reservedPropertyKeys[f.Key] {
+			return fmt.Errorf("%w: field key %q is reserved", ErrInvalidInput, f.Key)
+		}
+		if seen[f.Key] {
+			return fmt.Errorf("%w: duplicate field key %q", ErrInvalidInput, f.Key)
+		}
+		seen[f.Key] = true
+
+		switch f.Type {
+		case PropertyTypeNumber, PropertyTypeInteger, PropertyTypeString, PropertyTypeBoolean:
+		case PropertyTypeEnum:
+			if len(f.Options) == 0 {
+				return fmt.Errorf("%w: enum field %q needs options", ErrInvalidInput, f.Key)
+			}
+		default:
+			return fmt.Errorf("%w: field %q has unknown type %q", ErrInvalidInput, f.Key, f.Type)
+		}
+		if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+			return fmt.Errorf("%w: field %q has min greater than max", ErrInvalidInput, f.Key)
+		}
+	}
+	return nil
+}
+
+// Validate checks item attributes against the schema. Keys not defined in the schema are allowed.
+func (s PropertySchema) Validate(attrs map[string]interface{}) error {
+	var problems []string
+	for _, f := range s.Fields {
+		value, ok := attrs[f.Key]
+		if !ok || value == nil {
+			if f.Required {
+				problems = append(problems, fmt.Sprintf("%s is required", f.Key))
+			}
+			continue
+		}
+		if err := f.check(value); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s properties: %s", ErrInvalidInput, s.Category, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (f PropertyField) check(value interface{}) error {
+	switch f.Type {
+	case PropertyTypeNumber, PropertyTypeInteger:
+		n, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("%s must be a number", f.Key)
+		}
+		if f.Type == PropertyTypeInteger && n != math.Trunc(n) {
+			return fmt.Errorf("%s must be an integer", f.Key)
+		}
+		if f.Min != nil && n < *f.Min {
+			return fmt.Errorf("%s must be >= %v", f.Key, *f.Min)
+		}
+		if f.Max != nil && n > *f.Max {
+			return fmt.Errorf("%s must be <= %v", f.Key, *f.Max)
+		}
+	case PropertyTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", f.Key)
+		}
+	case PropertyTypeString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s must be a string", f.Key)
+		}
+	case PropertyTypeEnum:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be one of %s", f.Key, strings.Join(f.Options, ", "))
+		}
+		for _, option := range f.Options {
+			if s == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s", f.Key, strings.Join(f.Options, ", "))
+	}
+	return nil
+}
+
+// Field returns the schema field for key.
+func (s PropertySchema) Field(key string) (PropertyField, bool) {
+	for _, f := range s.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return PropertyField{}, false
+}
+
+// --- Property Queries ---
+
+type PropertyOperator string
+
+const (
+	PropertyOpEq  PropertyOperator = "eq"
+	PropertyOpGt  PropertyOperator = "gt"
+	PropertyOpGte PropertyOperator = "gte"
+	PropertyOpLt  PropertyOperator = "lt"
+	PropertyOpLte PropertyOperator = "lte"
+)
+
+// PropertyFilter matches items whose Properties[Key] compares to Value.
+// Range operators only match numeric values.
+type PropertyFilter struct {
+	Key   string
+	Op    PropertyOperator
+	Value interface{}
+}
+
+// ParsePropertyFilter parses a query value such as "gte:100" or "true" (eq).
+// The raw value stays a string; use TypedValue to convert it.
+func ParsePropertyFilter(key, raw string) (PropertyFilter, error) {
+	filter := PropertyFilter{Key: key, Op: PropertyOpEq, Value: raw}
+	if op, value, found := strings.Cut(raw, ":"); found {
+		switch PropertyOperator(op) {
+		case PropertyOpEq, PropertyOpGt, PropertyOpGte, PropertyOpLt, PropertyOpLte:
+			filter.Op = PropertyOperator(op)
+			filter.Value = value
+		}
+	}
+	if filter.Op != PropertyOpEq {
+		if _, err := strconv.ParseFloat(fmt.Sprint(filter.Value), 64); err != nil {
+			return filter, fmt.Errorf("%w: %s needs a numeric value", ErrInvalidInput, key)
+		}
+	}
+	return filter, nil
+}
+
+// TypedValue converts a raw string value to the JSON type the schema declares.
+// Without a field definition, numbers and booleans are guessed from the text.
+func (f PropertyFilter) TypedValue(field *PropertyField) (interface{}, error) {
+	raw := fmt.Sprint(f.Value)
+	if field != nil {
+		switch field.Type {
+		case PropertyTypeNumber, PropertyTypeInteger:
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s must be a number", ErrInvalidInput, f.Key)
+			}
+			return n, nil
+		case PropertyTypeBoolean:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s must be a boolean", ErrInvalidInput, f.Key)
+			}
+			return b, nil
+		default:
+			return raw, nil
+		}
+	}
+	if n, err := strconv.ParseFloat(raw, 64); err == nil {
+		return n, nil
+	}
+	if b, err := strconv.ParseBool(raw); err == nil {
+		return b, nil
+	}
+	return raw, nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Attributes returns the schema-managed properties of an item (everything except category/brand/tags).
+func (i Item) Attributes() map[string]interface{} {
+	attrs := i.PropertyMap()
+	for key := range reservedPropertyKeys {
+		delete(attrs, key)
+	}
+	return attrs
+}
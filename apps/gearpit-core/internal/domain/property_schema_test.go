@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestPropertySchema_Validate(t *testing.T) {
+	minLength, maxLength := 50.0, 230.0
+	schema := PropertySchema{
+		Category: "Skis",
+		Fields: []PropertyField{
+			{Key: "lengthCm", Type: PropertyTypeNumber, Required: true, Min: &minLength, Max: &maxLength},
+			{Key: "cores", Type: PropertyTypeInteger},
+			{Key: "dryTreated", Type: PropertyTypeBoolean},
+			{Key: "profile", Type: PropertyTypeEnum, Options: []string{"camber", "rocker"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		attrs   map[string]interface{}
+		wantErr bool
+	}{
+		{"Valid", map[string]interface{}{"lengthCm": 177.0, "cores": 2.0, "dryTreated": true, "profile": "rocker"}, false},
+		{"Unknown Keys Allowed", map[string]interface{}{"lengthCm": 177.0, "color": "red"}, false},
+		{"Missing Required", map[string]interface{}{"cores": 2.0}, true},
+		{"Below Min", map[string]interface{}{"lengthCm": 20.0}, true},
+		{"Above Max", map[string]interface{}{"lengthCm": 300.0}, true},
+		{"Wrong Type", map[string]interface{}{"lengthCm": "177"}, true},
+		{"Fractional Integer", map[string]interface{}{"lengthCm": 177.0, "cores": 1.5}, true},
+		{"Bad Boolean", map[string]interface{}{"lengthCm": 177.0, "dryTreated": "yes"}, true},
+		{"Bad Enum", map[string]interface{}{"lengthCm": 177.0, "profile": "flat"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.attrs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPropertySchema_ValidateDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  PropertySchema
+		wantErr bool
+	}{
+		{"Valid", PropertySchema{Category: "Rope", Fields: []PropertyField{{Key: "diameterMm", Type: PropertyTypeNumber}}}, false},
+		{"Missing Category", PropertySchema{Fields: []PropertyField{{Key: "a", Type: PropertyTypeString}}}, true},
+		{"Reserved Key", PropertySchema{Category: "Rope", Fields: []PropertyField{{Key: "brand", Type: PropertyTypeString}}}, true},
+		{"Duplicate Key", PropertySchema{Category: "Rope", Fields: []PropertyField{{Key: "a", Type: PropertyTypeString}, {Key: "a", Type: PropertyTypeString}}}, true},
+		{"Unknown Type", PropertySchema{Category: "Rope", Fields: []PropertyField{{Key: "a", Type: "date"}}}, true},
+		{"Enum Without Options", PropertySchema{Category: "Rope", Fields: []PropertyField{{Key: "a", Type: PropertyTypeEnum}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.ValidateDefinition()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDefinition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePropertyFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantOp  PropertyOperator
+		wantErr bool
+	}{
+		{"Plain Value", "true", PropertyOpEq, false},
+		{"Range", "gte:100", PropertyOpGte, false},
+		{"Non Numeric Range", "lt:abc", PropertyOpLt, true},
+		{"Colon In Value", "model:x", PropertyOpEq, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePropertyFilter("key", tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePropertyFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Op != tt.wantOp {
+				t.Errorf("ParsePropertyFilter() op = %v, want %v", got.Op, tt.wantOp)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Odometer & Service Rules ---
+
+// ServiceDueSoonFraction is the share of an interval left when a service becomes due soon.
+const ServiceDueSoonFraction = 0.1
+
+type ServiceState string
+
+const (
+	ServiceOK      ServiceState = "ok"
+	ServiceDueSoon ServiceState = "dueSoon"
+	ServiceDue     ServiceState = "due" // Past due but within the grace period
+	ServiceOverdue ServiceState = "overdue"
+	ServiceUnknown ServiceState = "unknown" // Only usage intervals and no odometer readings or meter
+)
+
+// serviceStateRank orders states from most to least urgent.
+var serviceStateRank = map[ServiceState]int{ServiceOverdue: 0, ServiceDue: 1, ServiceDueSoon: 2, ServiceOK: 3, ServiceUnknown: 4}
+
+// IsDue reports whether the service needs attention: due soon, due or overdue.
+func (s ServiceState) IsDue() bool {
+	return s == ServiceDueSoon || s == ServiceDue || s == ServiceOverdue
+}
+
+type ServiceThreshold string
+
+const (
+	ServiceByKm    ServiceThreshold = "km"
+	ServiceByUsage ServiceThreshold = "usage" // The rule's usage meter
+	ServiceByTime  ServiceThreshold = "time"
+)
+
+// ServiceStatus tells when a rule's service is due next on one item. DueBy names the
+// threshold that is most urgent, or with the smaller share of its interval left.
+type ServiceStatus struct {
+	Rule             ServiceRule      `json:"rule"`
+	ItemID           string           `json:"itemId"`
+	Item             *Item            `json:"item,omitempty"`
+	State            ServiceState     `json:"state"`
+	DueBy            ServiceThreshold `json:"dueBy,omitempty"`
+	LastServiceAt    time.Time        `json:"lastServiceAt"`
+	LastServiceKm    *int             `json:"lastServiceKm,omitempty"`
+	CurrentKm        *int             `json:"currentKm,omitempty"`
+	NextDueKm        *int             `json:"nextDueKm,omitempty"`
+	RemainingKm      *int             `json:"remainingKm,omitempty"`
+	LastServiceUsage *float64         `json:"lastServiceUsage,omitempty"`
+	CurrentUsage     *float64         `json:"currentUsage,omitempty"`
+	NextDueUsage     *float64         `json:"nextDueUsage,omitempty"`
+	RemainingUsage   *float64         `json:"remainingUsage,omitempty"`
+	NextDueAt        *time.Time       `json:"nextDueAt,omitempty"`
+	RemainingDays    *int             `json:"remainingDays,omitempty"`
+}
+
+// ServiceHistory is what the rules of one item are measured against.
+type ServiceHistory struct {
+	Item     Item
+	Logs     []MaintenanceLog
+	Readings []OdometerReading
+	Meters   []UsageMeter
+	Entries  []UsageEntry // Of the meters the rules count on
+}
+
+// Matches reports whether a maintenance log of logType completes the rule.
+func (r ServiceRule) Matches(logType string) bool {
+	return strings.EqualFold(strings.TrimSpace(logType), r.LogType)
+}
+
+// Validate checks the rule's text fields and that it has at least one positive interval.
+// The scope (item or category) is checked on create.
+func (r *ServiceRule) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.LogType = strings.TrimSpace(r.LogType)
+	r.Category = strings.TrimSpace(r.Category)
+	r.MeterName = strings.TrimSpace(r.MeterName)
+	if r.Name == "" {
+		return fmt.Errorf("%w: rule name is required", ErrInvalidInput)
+	}
+	if r.LogType == "" {
+		return fmt.Errorf("%w: rule logType is required", ErrInvalidInput)
+	}
+	if r.IntervalKm == nil && r.IntervalUsage == nil && r.IntervalMonths == nil {
+		return fmt.Errorf("%w: rule needs intervalKm, intervalUsage or intervalMonths", ErrInvalidInput)
+	}
+	if r.IntervalKm != nil && r.IntervalUsage != nil {
+		return fmt.Errorf("%w: rule counts either km or a meter, not both", ErrInvalidInput)
+	}
+	if (r.MeterName == "") != (r.IntervalUsage == nil) {
+		return fmt.Errorf("%w: intervalUsage and meterName go together", ErrInvalidInput)
+	}
+	if (r.IntervalKm != nil && *r.IntervalKm <= 0) || (r.IntervalMonths != nil && *r.IntervalMonths <= 0) ||
+		(r.IntervalUsage != nil && !(*r.IntervalUsage > 0 && !math.IsInf(*r.IntervalUsage, 0))) {
+		return fmt.Errorf("%w: intervals must be positive", ErrInvalidInput)
+	}
+	if r.GraceUsage != nil && (*r.GraceUsage < 0 || (r.IntervalKm == nil && r.IntervalUsage == nil)) {
+		return fmt.Errorf("%w: graceUsage must not be negative and needs intervalKm or intervalUsage", ErrInvalidInput)
+	}
+	if r.GraceDays != nil && (*r.GraceDays < 0 || r.IntervalMonths == nil) {
+		return fmt.Errorf("%w: graceDays must not be negative and needs intervalMonths", ErrInvalidInput)
+	}
+	if (r.LastServiceKm != nil && *r.LastServiceKm < 0) || (r.LastServiceUsage != nil && *r.LastServiceUsage < 0) {
+		return fmt.Errorf("%w: last service km and usage must not be negative", ErrInvalidInput)
+	}
+	if r.Category != "" && (r.LastServiceKm != nil || r.LastServiceUsage != nil) {
+		return fmt.Errorf("%w: category rules start from each item's own odometer and meters", ErrInvalidInput)
+	}
+	return nil
+}
+
+// RulesForItem picks the rules that apply to item: its own rules and the rules of its
+// category, unless one of its own rules has the same LogType.
+func RulesForItem(item Item, itemRules, categoryRules []ServiceRule) []ServiceRule {
+	rules := make([]ServiceRule, 0, len(itemRules)+len(categoryRules))
+	for _, r := range itemRules {
+		if r.ItemID != nil && *r.ItemID == item.ID {
+			rules = append(rules, r)
+		}
+	}
+	own := len(rules)
+	category := item.PropertyString("category")
+	for _, r := range categoryRules {
+		if r.ItemID != nil || category == "" || r.Category != category {
+			continue
+		}
+		if !AnyRuleMatches(rules[:own], r.LogType) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// AnyRuleMatches reports whether a maintenance log of logType completes one of the rules.
+func AnyRuleMatches(rules []ServiceRule, logType string) bool {
+	for _, r := range rules {
+		if r.Matches(logType) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestartsMaintenanceCounter reports whether a log of logType restarts the item's
+// MaintenanceInterval counter. With a MaintenanceLogType only that type does; without
+// one, every log that completes none of the item's rules does.
+func (i Item) RestartsMaintenanceCounter(rules []ServiceRule, logType string) bool {
+	if i.MaintenanceLogType == "" {
+		return !AnyRuleMatches(rules, logType)
+	}
+	return i.MaintenanceInterval > 0 && strings.EqualFold(strings.TrimSpace(logType), i.MaintenanceLogType)
+}
+
+// ValidateOdometerReading rejects negative readings and readings that would make the
+// odometer run backwards against the stored ones.
+func ValidateOdometerReading(reading OdometerReading, existing []OdometerReading) error {
+	if reading.Km < 0 {
+		return fmt.Errorf("%w: km must not be negative", ErrInvalidInput)
+	}
+	for _, e := range existing {
+		if (!e.ReadAt.After(reading.ReadAt) && e.Km > reading.Km) || (e.ReadAt.After(reading.ReadAt) && e.Km < reading.Km) {
+			return fmt.Errorf("%w: odometer read %d km on %s", ErrInvalidInput, e.Km, e.ReadAt.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// OdometerAt returns the latest reading taken at or before at.
+func OdometerAt(readings []OdometerReading, at time.Time) (int, bool) {
+	var latest *OdometerReading
+	for i, r := range readings {
+		if !r.ReadAt.After(at) && (latest == nil || r.ReadAt.After(latest.ReadAt)) {
+			latest = &readings[i]
+		}
+	}
+	if latest == nil {
+		return 0, false
+	}
+	return latest.Km, true
+}
+
+// MeterAt sums the meter's entries recorded at or before at.
+func MeterAt(entries []UsageEntry, meterID string, at time.Time) float64 {
+	total := 0.0
+	for _, e := range entries {
+		if e.MeterID == meterID && !e.RecordedAt.After(at) {
+			total += e.Amount
+		}
+	}
+	return total
+}
+
+// serviceCheck is one threshold of a rule, in the threshold's own unit.
+type serviceCheck struct {
+	by        ServiceThreshold
+	remaining float64
+	interval  float64
+	grace     float64
+}
+
+func (c serviceCheck) share() float64 {
+	return c.remaining / c.interval
+}
+
+func (c serviceCheck) state() ServiceState {
+	switch {
+	case c.share() > ServiceDueSoonFraction:
+		return ServiceOK
+	case c.remaining > 0:
+		return ServiceDueSoon
+	case c.grace > 0 && -c.remaining <= c.grace:
+		return ServiceDue
+	default:
+		return ServiceOverdue
+	}
+}
+
+// ComputeServiceStatus finds the last service of the rule among the item's logs (or
+// the rule's own baseline) and measures every interval from it.
+func ComputeServiceStatus(rule ServiceRule, history ServiceHistory, now time.Time) ServiceStatus {
+	item := history.Item
+	status := ServiceStatus{Rule: rule, ItemID: item.ID, Item: &item, State: ServiceUnknown, LastServiceAt: rule.CreatedAt,
+		LastServiceKm: rule.LastServiceKm, LastServiceUsage: rule.LastServiceUsage}
+	status.Rule.Item = nil // Carried by status.Item
+	if rule.LastServiceAt != nil {
+		status.LastServiceAt = *rule.LastServiceAt
+	}
+	var lastLog *MaintenanceLog
+	for i, l := range history.Logs {
+		if rule.Matches(l.Type) && (lastLog == nil || l.PerformedAt.After(lastLog.PerformedAt)) {
+			lastLog = &history.Logs[i]
+		}
+	}
+	if lastLog != nil {
+		status.LastServiceAt = lastLog.PerformedAt
+		status.LastServiceKm = lastLog.OdometerKm
+		status.LastServiceUsage = nil
+	}
+
+	// Baselines that were not given are read off the odometer and meter at the last service
+	if status.LastServiceKm == nil {
+		if km, ok := OdometerAt(history.Readings, status.LastServiceAt); ok {
+			status.LastServiceKm = &km
+		}
+	}
+	if km, ok := OdometerAt(history.Readings, now); ok {
+		status.CurrentKm = &km
+	}
+	var meter *UsageMeter
+	for i, m := range history.Meters {
+		if rule.MeterName != "" && strings.EqualFold(m.Name, rule.MeterName) {
+			meter = &history.Meters[i]
+		}
+	}
+	if meter != nil {
+		current := meter.Total
+		status.CurrentUsage = &current
+		if status.LastServiceUsage == nil {
+			last := MeterAt(history.Entries, meter.ID, status.LastServiceAt)
+			status.LastServiceUsage = &last
+		}
+	}
+
+	var checks []serviceCheck
+	if rule.IntervalKm != nil && status.LastServiceKm != nil && status.CurrentKm != nil {
+		next := *status.LastServiceKm + *rule.IntervalKm
+		remaining := next - *status.CurrentKm
+		status.NextDueKm, status.RemainingKm = &next, &remaining
+		checks = append(checks, serviceCheck{ServiceByKm, float64(remaining), float64(*rule.IntervalKm), floatOrZero(rule.GraceUsage)})
+	}
+	if rule.IntervalUsage != nil && meter != nil {
+		next := *status.LastServiceUsage + *rule.IntervalUsage
+		remaining := next - *status.CurrentUsage
+		status.NextDueUsage, status.RemainingUsage = &next, &remaining
+		checks = append(checks, serviceCheck{ServiceByUsage, remaining, *rule.IntervalUsage, floatOrZero(rule.GraceUsage)})
+	}
+	if rule.IntervalMonths != nil {
+		next := status.LastServiceAt.AddDate(0, *rule.IntervalMonths, 0)
+		remaining := int(math.Ceil(next.Sub(now).Hours() / 24))
+		status.NextDueAt, status.RemainingDays = &next, &remaining
+		interval := next.Sub(status.LastServiceAt).Hours() / 24
+		grace := 0.0
+		if rule.GraceDays != nil {
+			grace = float64(*rule.GraceDays)
+		}
+		checks = append(checks, serviceCheck{ServiceByTime, float64(remaining), interval, grace})
+	}
+
+	// The most urgent threshold wins; among equals, the one with less of its interval left
+	best := -1
+	for i, c := range checks {
+		if best < 0 || serviceStateRank[c.state()] < serviceStateRank[checks[best].state()] ||
+			(c.state() == checks[best].state() && c.share() < checks[best].share()) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		status.State, status.DueBy = checks[best].state(), checks[best].by
+	}
+	return status
+}
+
+func floatOrZero(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
+// SortServiceStatuses puts overdue services first, then due, due soon, ok and unknown ones.
+func SortServiceStatuses(statuses []ServiceStatus) {
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return serviceStateRank[statuses[i].State] < serviceStateRank[statuses[j].State]
+	})
+}
@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func intPtr(n int) *int { return &n }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestServiceRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    ServiceRule
+		wantErr bool
+	}{
+		{"Km only", ServiceRule{Name: "Chain", LogType: "chain", IntervalKm: intPtr(500)}, false},
+		{"Km and months", ServiceRule{Name: "Oil", LogType: "oil", IntervalKm: intPtr(5000), IntervalMonths: intPtr(12)}, false},
+		{"No interval", ServiceRule{Name: "Oil", LogType: "oil"}, true},
+		{"Zero interval", ServiceRule{Name: "Oil", LogType: "oil", IntervalMonths: intPtr(0)}, true},
+		{"Missing log type", ServiceRule{Name: "Oil", IntervalKm: intPtr(5000)}, true},
+		{"Missing name", ServiceRule{LogType: "oil", IntervalKm: intPtr(5000)}, true},
+		{"Meter", ServiceRule{Name: "Wax", LogType: "wax", MeterName: "Ski days", IntervalUsage: floatPtr(5), GraceUsage: floatPtr(1)}, false},
+		{"Meter without name", ServiceRule{Name: "Wax", LogType: "wax", IntervalUsage: floatPtr(5)}, true},
+		{"Km and meter", ServiceRule{Name: "Wax", LogType: "wax", IntervalKm: intPtr(50), MeterName: "Ski days", IntervalUsage: floatPtr(5)}, true},
+		{"Grace days without months", ServiceRule{Name: "Chain", LogType: "chain", IntervalKm: intPtr(500), GraceDays: intPtr(7)}, true},
+		{"Negative grace", ServiceRule{Name: "Grind", LogType: "grind", IntervalMonths: intPtr(12), GraceDays: intPtr(-1)}, true},
+		{"Category rule with km baseline", ServiceRule{Category: "Motorcycle", Name: "Oil", LogType: "oil", IntervalKm: intPtr(5000), LastServiceKm: intPtr(100)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("Validate() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestValidateOdometerReading(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := []OdometerReading{
+		{Km: 1000, ReadAt: jan},
+		{Km: 3000, ReadAt: jan.AddDate(0, 2, 0)},
+	}
+	tests := []struct {
+		name    string
+		reading OdometerReading
+		wantErr bool
+	}{
+		{"Latest", OdometerReading{Km: 3500, ReadAt: jan.AddDate(0, 3, 0)}, false},
+		{"Between", OdometerReading{Km: 2000, ReadAt: jan.AddDate(0, 1, 0)}, false},
+		{"Runs backwards", OdometerReading{Km: 2500, ReadAt: jan.AddDate(0, 3, 0)}, true},
+		{"Higher than a later reading", OdometerReading{Km: 3100, ReadAt: jan.AddDate(0, 1, 0)}, true},
+		{"Negative", OdometerReading{Km: -1, ReadAt: jan}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOdometerReading(tt.reading, existing)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOdometerReading() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestComputeServiceStatus(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	readings := []OdometerReading{
+		{Km: 10000, ReadAt: jan},
+		{Km: 12000, ReadAt: jan.AddDate(0, 3, 0)},
+		{Km: 14800, ReadAt: jan.AddDate(0, 5, 0)},
+	}
+	oil := ServiceRule{Name: "Oil", LogType: "oil", IntervalKm: intPtr(5000), IntervalMonths: intPtr(12), LastServiceAt: &jan, CreatedAt: jan}
+
+	tests := []struct {
+		name      string
+		rule      ServiceRule
+		logs      []MaintenanceLog
+		readings  []OdometerReading
+		now       time.Time
+		wantState ServiceState
+		wantDueBy ServiceThreshold
+	}{
+		{
+			name:      "Km hits first",
+			rule:      oil,
+			logs:      []MaintenanceLog{{Type: "Oil", PerformedAt: jan}},
+			readings:  readings,
+			now:       jan.AddDate(0, 6, 0),
+			wantState: ServiceDueSoon, // 200 of 5000 km left, half a year to go
+			wantDueBy: ServiceByKm,
+		},
+		{
+			name:      "Months hit first",
+			rule:      oil,
+			logs:      []MaintenanceLog{{Type: "oil", PerformedAt: jan, OdometerKm: intPtr(10000)}},
+			readings:  readings[:1],
+			now:       jan.AddDate(1, 0, 1),
+			wantState: ServiceOverdue,
+			wantDueBy: ServiceByTime,
+		},
+		{
+			name:      "Recent service restarts the km interval",
+			rule:      oil,
+			logs:      []MaintenanceLog{{Type: "oil", PerformedAt: jan}, {Type: "oil", PerformedAt: jan.AddDate(0, 3, 0)}},
+			readings:  readings,
+			now:       jan.AddDate(0, 5, 0),
+			wantState: ServiceOK,
+			wantDueBy: ServiceByKm,
+		},
+		{
+			name:      "Other log types do not count",
+			rule:      ServiceRule{Name: "Chain", LogType: "chain", IntervalKm: intPtr(500), LastServiceKm: intPtr(14000), CreatedAt: jan},
+			logs:      []MaintenanceLog{{Type: "oil", PerformedAt: jan.AddDate(0, 5, 0)}},
+			readings:  readings,
+			now:       jan.AddDate(0, 5, 0),
+			wantState: ServiceOverdue,
+			wantDueBy: ServiceByKm,
+		},
+		{
+			name:      "Km rule without readings",
+			rule:      ServiceRule{Name: "Chain", LogType: "chain", IntervalKm: intPtr(500), CreatedAt: jan},
+			now:       jan,
+			wantState: ServiceUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeServiceStatus(tt.rule, ServiceHistory{Logs: tt.logs, Readings: tt.readings}, tt.now)
+			if got.State != tt.wantState {
+				t.Errorf("State = %v, want %v", got.State, tt.wantState)
+			}
+			if got.DueBy != tt.wantDueBy {
+				t.Errorf("DueBy = %v, want %v", got.DueBy, tt.wantDueBy)
+			}
+		})
+	}
+}
+
+func TestComputeServiceStatus_MeterAndGrace(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	skis := Item{ID: "skis"}
+	meters := []UsageMeter{{ID: "days", ItemID: "skis", Name: "Ski days", Unit: MeterUnitDays, Total: 16}}
+	entries := []UsageEntry{
+		{MeterID: "days", Amount: 4, RecordedAt: jan.AddDate(0, 0, 5)},
+		{MeterID: "days", Amount: 6, RecordedAt: jan.AddDate(0, 0, 20)},
+		{MeterID: "days", Amount: 6, RecordedAt: jan.AddDate(0, 1, 10)},
+	}
+	wax := ServiceRule{Category: "Skis", Name: "Wax", LogType: "wax", MeterName: "ski days", IntervalUsage: floatPtr(5), GraceUsage: floatPtr(2), LastServiceAt: &jan}
+	grind := ServiceRule{Category: "Skis", Name: "Base grind", LogType: "grind", IntervalMonths: intPtr(12), GraceDays: intPtr(30), LastServiceAt: &jan}
+
+	tests := []struct {
+		name          string
+		rule          ServiceRule
+		logs          []MaintenanceLog
+		now           time.Time
+		wantState     ServiceState
+		wantDueBy     ServiceThreshold
+		wantRemaining float64
+	}{
+		{
+			name:          "Waxed after the first trip",
+			rule:          wax,
+			logs:          []MaintenanceLog{{Type: "wax", PerformedAt: jan.AddDate(0, 0, 10)}},
+			now:           jan.AddDate(0, 2, 0),
+			wantState:     ServiceOverdue, // 12 days since waxing at 4, past the 2 days of grace
+			wantDueBy:     ServiceByUsage,
+			wantRemaining: -7,
+		},
+		{
+			name:          "Within grace",
+			rule:          wax,
+			logs:          []MaintenanceLog{{Type: "wax", PerformedAt: jan.AddDate(0, 0, 25)}},
+			now:           jan.AddDate(0, 2, 0),
+			wantState:     ServiceDue, // 6 days since waxing at 10, 1 into the grace
+			wantDueBy:     ServiceByUsage,
+			wantRemaining: -1,
+		},
+		{
+			name:          "Tuning does not count as waxing",
+			rule:          wax,
+			logs:          []MaintenanceLog{{Type: "edge tune", PerformedAt: jan.AddDate(0, 1, 15)}},
+			now:           jan.AddDate(0, 2, 0),
+			wantState:     ServiceOverdue, // 16 days since the rule started
+			wantDueBy:     ServiceByUsage,
+			wantRemaining: -11,
+		},
+		{
+			name:      "Calendar grace",
+			rule:      grind,
+			now:       jan.AddDate(1, 0, 20),
+			wantState: ServiceDue,
+			wantDueBy: ServiceByTime,
+		},
+		{
+			name:      "Calendar grace is over",
+			rule:      grind,
+			now:       jan.AddDate(1, 1, 5),
+			wantState: ServiceOverdue,
+			wantDueBy: ServiceByTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := ServiceHistory{Item: skis, Logs: tt.logs, Meters: meters, Entries: entries}
+			got := ComputeServiceStatus(tt.rule, history, tt.now)
+			if got.State != tt.wantState {
+				t.Errorf("State = %v, want %v", got.State, tt.wantState)
+			}
+			if got.DueBy != tt.wantDueBy {
+				t.Errorf("DueBy = %v, want %v", got.DueBy, tt.wantDueBy)
+			}
+			if tt.wantDueBy == ServiceByUsage && (got.RemainingUsage == nil || *got.RemainingUsage != tt.wantRemaining) {
+				t.Errorf("RemainingUsage = %v, want %v", got.RemainingUsage, tt.wantRemaining)
+			}
+			if got.ItemID != "skis" {
+				t.Errorf("ItemID = %q, want skis", got.ItemID)
+			}
+		})
+	}
+
+	t.Run("Item without the meter", func(t *testing.T) {
+		got := ComputeServiceStatus(wax, ServiceHistory{Item: skis}, jan)
+		if got.State != ServiceUnknown {
+			t.Errorf("State = %v, want %v", got.State, ServiceUnknown)
+		}
+	})
+}
+
+func TestRulesForItem(t *testing.T) {
+	skis := Item{ID: "skis", Properties: []byte(`{"category":"Skis"}`)}
+	itemID, otherID := "skis", "boots"
+	itemRules := []ServiceRule{
+		{ID: "own-wax", ItemID: &itemID, LogType: "wax"},
+		{ID: "boots-dry", ItemID: &otherID, LogType: "dry"},
+	}
+	categoryRules := []ServiceRule{
+		{ID: "cat-wax", Category: "Skis", LogType: "Wax"},
+		{ID: "cat-tune", Category: "Skis", LogType: "tune"},
+		{ID: "board-wax", Category: "Snowboards", LogType: "wax"},
+	}
+
+	got := RulesForItem(skis, itemRules, categoryRules)
+
+	var ids []string
+	for _, r := range got {
+		ids = append(ids, r.ID)
+	}
+	want := []string{"own-wax", "cat-tune"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] {
+		t.Errorf("RulesForItem() = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// --- Consumable Stock ---
+
+// DefaultShoppingWindowDays is how far ahead the shopping list looks for planned trips.
+const DefaultShoppingWindowDays = 30
+
+// ValidateStock checks the stock fields. Only consumables keep stock; a nil
+// StockQuantity means stock is not tracked for the item.
+func (i Item) ValidateStock() error {
+	if i.StockQuantity == nil {
+		if i.ReorderThreshold != 0 {
+			return fmt.Errorf("%w: reorderThreshold needs stockQuantity", ErrInvalidInput)
+		}
+		return nil
+	}
+	if i.WeightType != WeightTypeConsumable {
+		return fmt.Errorf("%w: only consumables keep stock", ErrInvalidInput)
+	}
+	if *i.StockQuantity < 0 || i.ReorderThreshold < 0 {
+		return fmt.Errorf("%w: stockQuantity and reorderThreshold cannot be negative", ErrInvalidInput)
+	}
+	return nil
+}
+
+// ConsumableNeed is the quantity of a stocked consumable packed for one upcoming trip.
+type ConsumableNeed struct {
+	ItemID    string    `json:"-"`
+	TripID    string    `json:"tripId"`
+	TripName  string    `json:"tripName"`
+	StartDate time.Time `json:"startDate"`
+	Quantity  int       `json:"quantity"`
+}
+
+// ShoppingListLine is one consumable to buy.
+type ShoppingListLine struct {
+	Item             Item             `json:"item"`
+	InStock          int              `json:"inStock"`
+	Needed           int              `json:"needed"`    // Packed for upcoming trips
+	Shortfall        int              `json:"shortfall"` // Missing for the trips themselves
+	ReorderThreshold int              `json:"reorderThreshold"`
+	ToBuy            int              `json:"toBuy"` // Covers the trips and refills the reserve
+	Trips            []ConsumableNeed `json:"trips"`
+}
+
+// BuildShoppingList lists stocked consumables whose stock cannot cover the
+// upcoming trips plus the reorder threshold. Items with no upcoming trips
+// appear once their stock falls below the threshold.
+func BuildShoppingList(items []Item, needs []ConsumableNeed) []ShoppingListLine {
+	byItem := map[string][]ConsumableNeed{}
+	for _, need := range needs {
+		byItem[need.ItemID] = append(byItem[need.ItemID], need)
+	}
+
+	lines := []ShoppingListLine{}
+	for _, item := range items {
+		if item.StockQuantity == nil {
+			continue
+		}
+		line := ShoppingListLine{
+			Item:             item,
+			InStock:          *item.StockQuantity,
+			ReorderThreshold: item.ReorderThreshold,
+			Trips:            byItem[item.ID],
+		}
+		for _, need := range line.Trips {
+			line.Needed += need.Quantity
+		}
+		line.Shortfall = max(line.Needed-line.InStock, 0)
+		line.ToBuy = max(line.Needed+line.ReorderThreshold-line.InStock, 0)
+		if line.ToBuy == 0 {
+			continue
+		}
+		if line.Trips == nil {
+			line.Trips = []ConsumableNeed{}
+		}
+		lines = append(lines, line)
+	}
+
+	// Shortfalls block trips, so they come first
+	sort.SliceStable(lines, func(i, j int) bool {
+		if (lines[i].Shortfall > 0) != (lines[j].Shortfall > 0) {
+			return lines[i].Shortfall > 0
+		}
+		return lines[i].Item.Name < lines[j].Item.Name
+	})
+	return lines
+}
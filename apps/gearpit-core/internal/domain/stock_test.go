@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func TestItem_ValidateStock(t *testing.T) {
+	n := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		item    Item
+		wantErr bool
+	}{
+		{"untracked", Item{WeightType: WeightTypeBase}, false},
+		{"stocked consumable", Item{WeightType: WeightTypeConsumable, StockQuantity: n(4), ReorderThreshold: 2}, false},
+		{"stock on base gear", Item{WeightType: WeightTypeBase, StockQuantity: n(1)}, true},
+		{"threshold without stock", Item{WeightType: WeightTypeConsumable, ReorderThreshold: 2}, true},
+		{"negative stock", Item{WeightType: WeightTypeConsumable, StockQuantity: n(-1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.item.ValidateStock(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateStock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildShoppingList(t *testing.T) {
+	n := func(v int) *int { return &v }
+	items := []Item{
+		{ID: "gas", Name: "Gas Canister (Small)", StockQuantity: n(1), ReorderThreshold: 1},
+		{ID: "food", Name: "Food (Day 1)", StockQuantity: n(5)},
+		{ID: "bars", Name: "Energy Bars", StockQuantity: n(0), ReorderThreshold: 6},
+	}
+	needs := []ConsumableNeed{
+		{ItemID: "gas", TripID: "t1", Quantity: 2},
+		{ItemID: "gas", TripID: "t2", Quantity: 1},
+		{ItemID: "food", TripID: "t1", Quantity: 3},
+	}
+
+	lines := BuildShoppingList(items, needs)
+
+	if len(lines) != 2 {
+		t.Fatalf("BuildShoppingList() = %+v, want 2 lines", lines)
+	}
+	gas := lines[0]
+	if gas.Item.ID != "gas" || gas.Needed != 3 || gas.Shortfall != 2 || gas.ToBuy != 3 || len(gas.Trips) != 2 {
+		t.Errorf("gas line = %+v", gas)
+	}
+	bars := lines[1]
+	if bars.Item.ID != "bars" || bars.Shortfall != 0 || bars.ToBuy != 6 {
+		t.Errorf("bars line = %+v", bars)
+	}
+}
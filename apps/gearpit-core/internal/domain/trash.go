@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Trash ---
+
+// DefaultTrashRetention is how long soft-deleted rows are kept before the purge job removes them.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// TrashEntity names a soft-deletable table in trash URLs.
+type TrashEntity string
+
+const (
+	TrashItems           TrashEntity = "items"
+	TrashKits            TrashEntity = "kits"
+	TrashLoadouts        TrashEntity = "loadouts"
+	TrashTrips           TrashEntity = "trips"
+	TrashMaintenanceLogs TrashEntity = "maintenanceLogs"
+	TrashProfiles        TrashEntity = "profiles"
+)
+
+// TrashEntities lists all entities, children before parents, so purging in
+// this order never relies on cascades from rows that are still restorable.
+var TrashEntities = []TrashEntity{
+	TrashMaintenanceLogs, TrashTrips, TrashLoadouts, TrashKits, TrashItems, TrashProfiles,
+}
+
+// ParseTrashEntity validates an entity name.
+func ParseTrashEntity(s string) (TrashEntity, error) {
+	for _, e := range TrashEntities {
+		if string(e) == s {
+			return e, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown trash entity %q", ErrInvalidInput, s)
+}
+
+// TrashEntry is a soft-deleted row. Name is the row's display label.
+type TrashEntry struct {
+	Entity    TrashEntity `json:"entity"`
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	DeletedAt time.Time   `json:"deletedAt"`
+	PurgeAt   time.Time   `json:"purgeAt"` // When the purge job will remove it
+}
+
+// PurgeResult counts rows removed per entity.
+type PurgeResult map[TrashEntity]int64
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// WeightUnit is a unit accepted for weight input and display.
+// All weights are persisted as grams (WeightGram); units only apply at the edges.
+type WeightUnit string
+
+const (
+	UnitGram     WeightUnit = "g"
+	UnitKilogram WeightUnit = "kg"
+	UnitOunce    WeightUnit = "oz"
+	UnitPound    WeightUnit = "lb"
+)
+
+var gramsPerUnit = map[WeightUnit]float64{
+	UnitGram:     1,
+	UnitKilogram: 1000,
+	UnitOunce:    28.349523125,
+	UnitPound:    453.59237,
+}
+
+// UnitSystem is a user's display preference.
+type UnitSystem string
+
+const (
+	UnitSystemMetric   UnitSystem = "metric"
+	UnitSystemImperial UnitSystem = "imperial"
+)
+
+// ParseWeightUnit normalizes the unit spellings used by LighterPack and spreadsheets.
+// An empty string is treated as grams.
+func ParseWeightUnit(s string) (WeightUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "g", "gram", "grams":
+		return UnitGram, nil
+	case "kg", "kilogram", "kilograms":
+		return UnitKilogram, nil
+	case "oz", "ounce", "ounces":
+		return UnitOunce, nil
+	case "lb", "lbs", "pound", "pounds":
+		return UnitPound, nil
+	}
+	return "", fmt.Errorf("%w: unknown weight unit %q", ErrInvalidInput, s)
+}
+
+// ParseUnitSystem validates a unit system name (default: metric).
+func ParseUnitSystem(s string) (UnitSystem, error) {
+	switch UnitSystem(strings.ToLower(strings.TrimSpace(s))) {
+	case "", UnitSystemMetric:
+		return UnitSystemMetric, nil
+	case UnitSystemImperial:
+		return UnitSystemImperial, nil
+	}
+	return "", fmt.Errorf("%w: unknown unit system %q", ErrInvalidInput, s)
+}
+
+// DisplayUnit returns the weight unit used to render values for a unit system.
+func (u UnitSystem) DisplayUnit() WeightUnit {
+	if u == UnitSystemImperial {
+		return UnitOunce
+	}
+	return UnitGram
+}
+
+// ToGrams converts a weight in the given unit to whole grams (rounded).
+func ToGrams(value float64, unit WeightUnit) (int, error) {
+	factor, ok := gramsPerUnit[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown weight unit %q", ErrInvalidInput, unit)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("%w: weight must not be negative", ErrInvalidInput)
+	}
+	return int(math.Round(value * factor)), nil
+}
+
+// FromGrams converts grams to the given unit, rounded to two decimals.
+// Unknown units fall back to grams.
+func FromGrams(grams int, unit WeightUnit) float64 {
+	factor, ok := gramsPerUnit[unit]
+	if !ok {
+		factor = 1
+	}
+	return math.Round(float64(grams)/factor*100) / 100
+}
+
+// --- Display Conversion ---
+
+// WeightDisplay carries the gram fields of a response converted to a display unit.
+// Keys of Values are the JSON names of the gram fields (e.g. "weightGram").
+type WeightDisplay struct {
+	Unit   WeightUnit         `json:"unit"`
+	Values map[string]float64 `json:"values"`
+}
+
+func newWeightDisplay(unit WeightUnit, grams map[string]int) *WeightDisplay {
+	d := &WeightDisplay{Unit: unit, Values: make(map[string]float64, len(grams))}
+	for key, g := range grams {
+		d.Values[key] = FromGrams(g, unit)
+	}
+	return d
+}
+
+// ApplyDisplayUnit fills Display. Without an explicit unit, the item's own Unit is used.
+func (i *Item) ApplyDisplayUnit(unit WeightUnit) {
+	if unit == "" {
+		parsed, err := ParseWeightUnit(i.Unit)
+		if err != nil || i.Unit == "" {
+			return
+		}
+		unit = parsed
+	}
+	grams := map[string]int{"weightGram": i.WeightGram}
+	if i.TotalWeightGram != nil {
+		grams["totalWeightGram"] = *i.TotalWeightGram
+	}
+	i.Display = newWeightDisplay(unit, grams)
+}
+
+// ApplyDisplayUnit fills Display for the computed weights and all contained items.
+func (l *Loadout) ApplyDisplayUnit(unit WeightUnit) {
+	for i := range l.Items {
+		l.Items[i].ApplyDisplayUnit(unit)
+	}
+	for k := range l.Kits {
+		for i := range l.Kits[k].Items {
+			l.Kits[k].Items[i].ApplyDisplayUnit(unit)
+		}
+	}
+	if unit == "" {
+		return
+	}
+	grams := map[string]int{
+		"totalWeightGram":      l.TotalWeightGram,
+		"baseWeightGram":       l.BaseWeightGram,
+		"consumableWeightGram": l.ConsumableWeightGram,
+		"wornWeightGram":       l.WornWeightGram,
+		"longWeightGram":       l.LongWeightGram,
+	}
+	if l.TargetWeightGram != nil {
+		grams["targetWeightGram"] = *l.TargetWeightGram
+	}
+	l.Display = newWeightDisplay(unit, grams)
+}
+
+// ApplyDisplayUnit fills Display for the pack weight and all trip items.
+// Without an explicit unit, the preference of the trip's profile is used.
+func (t *Trip) ApplyDisplayUnit(unit WeightUnit) {
+	if unit == "" && t.UserProfile != nil && t.UserProfile.UnitSystem != "" {
+		unit = t.UserProfile.UnitSystem.DisplayUnit()
+	}
+	for i := range t.TripItems {
+		t.TripItems[i].Item.ApplyDisplayUnit(unit)
+	}
+	if unit == "" {
+		return
+	}
+	t.Display = newWeightDisplay(unit, map[string]int{"packWeightGram": t.PackWeightGram})
+}
+
+// ApplyDisplayUnit fills Display for the totals and each category.
+func (s *DashboardStats) ApplyDisplayUnit(unit WeightUnit) {
+	if unit == "" {
+		return
+	}
+	s.Display = newWeightDisplay(unit, map[string]int{
+		"totalWeight": s.TotalWeight,
+		"longWeight":  s.LongWeight,
+	})
+	for i := range s.CategoryStats {
+		s.CategoryStats[i].Display = newWeightDisplay(unit, map[string]int{"totalWeight": s.CategoryStats[i].TotalWeight})
+	}
+}
+
+type displayUnitKey struct{}
+
+// WithDisplayUnit stores the requested display unit in the context.
+func WithDisplayUnit(ctx context.Context, unit WeightUnit) context.Context {
+	return context.WithValue(ctx, displayUnitKey{}, unit)
+}
+
+// DisplayUnitFromContext returns the requested display unit, or "" when none was requested.
+func DisplayUnitFromContext(ctx context.Context) WeightUnit {
+	unit, _ := ctx.Value(displayUnitKey{}).(WeightUnit)
+	return unit
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestToGrams(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		unit     WeightUnit
+		expected int
+		wantErr  bool
+	}{
+		{"Grams", 250, UnitGram, 250, false},
+		{"Kilograms", 1.2, UnitKilogram, 1200, false},
+		{"Ounces", 10, UnitOunce, 283, false}, // 283.495 -> 283
+		{"Pounds", 2, UnitPound, 907, false},  // 907.18 -> 907
+		{"Negative", -1, UnitGram, 0, true},
+		{"Unknown Unit", 1, WeightUnit("stone"), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToGrams(tt.value, tt.unit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToGrams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ToGrams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFromGrams(t *testing.T) {
+	tests := []struct {
+		name     string
+		grams    int
+		unit     WeightUnit
+		expected float64
+	}{
+		{"Grams", 250, UnitGram, 250},
+		{"Kilograms", 1250, UnitKilogram, 1.25},
+		{"Ounces", 500, UnitOunce, 17.64},
+		{"Pounds", 3400, UnitPound, 7.5},
+		{"Unknown falls back to grams", 42, WeightUnit("stone"), 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromGrams(tt.grams, tt.unit); got != tt.expected {
+				t.Errorf("FromGrams() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyDisplayUnit(t *testing.T) {
+	t.Run("Item uses its own unit by default", func(t *testing.T) {
+		item := Item{WeightGram: 1000, Unit: "kg"}
+		item.ApplyDisplayUnit("")
+		if item.Display == nil || item.Display.Unit != UnitKilogram || item.Display.Values["weightGram"] != 1 {
+			t.Errorf("Display = %+v, want 1 kg", item.Display)
+		}
+	})
+
+	t.Run("Item without unit has no display", func(t *testing.T) {
+		item := Item{WeightGram: 1000}
+		item.ApplyDisplayUnit("")
+		if item.Display != nil {
+			t.Errorf("Display = %+v, want nil", item.Display)
+		}
+	})
+
+	t.Run("Loadout converts computed weights and items", func(t *testing.T) {
+		target := 4536
+		loadout := Loadout{
+			TotalWeightGram:  2268,
+			TargetWeightGram: &target,
+			Items:            []Item{{WeightGram: 454}},
+		}
+		loadout.ApplyDisplayUnit(UnitPound)
+		if loadout.Display.Values["totalWeightGram"] != 5 || loadout.Display.Values["targetWeightGram"] != 10 {
+			t.Errorf("Display = %+v, want total 5 lb, target 10 lb", loadout.Display)
+		}
+		if loadout.Items[0].Display.Values["weightGram"] != 1 {
+			t.Errorf("Item display = %+v, want 1 lb", loadout.Items[0].Display)
+		}
+	})
+
+	t.Run("Trip falls back to profile preference", func(t *testing.T) {
+		trip := Trip{PackWeightGram: 2835, UserProfile: &UserProfile{UnitSystem: UnitSystemImperial}}
+		trip.ApplyDisplayUnit("")
+		if trip.Display == nil || trip.Display.Unit != UnitOunce || trip.Display.Values["packWeightGram"] != 100 {
+			t.Errorf("Display = %+v, want 100 oz", trip.Display)
+		}
+	})
+}
+
+func TestDisplayUnitContext(t *testing.T) {
+	ctx := context.Background()
+	if got := DisplayUnitFromContext(ctx); got != "" {
+		t.Errorf("DisplayUnitFromContext() = %q, want empty", got)
+	}
+	if got := DisplayUnitFromContext(WithDisplayUnit(ctx, UnitOunce)); got != UnitOunce {
+		t.Errorf("DisplayUnitFromContext() = %q, want oz", got)
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// --- Usage Meters ---
+
+// MeterUnit is what a usage meter counts.
+type MeterUnit string
+
+const (
+	MeterUnitDays   MeterUnit = "days"
+	MeterUnitKm     MeterUnit = "km"
+	MeterUnitHours  MeterUnit = "hours"
+	MeterUnitFalls  MeterUnit = "falls"
+	MeterUnitCycles MeterUnit = "cycles"
+)
+
+// ParseMeterUnit accepts a unit case-insensitively.
+func ParseMeterUnit(raw string) (MeterUnit, error) {
+	switch unit := MeterUnit(strings.ToLower(strings.TrimSpace(raw))); unit {
+	case MeterUnitDays, MeterUnitKm, MeterUnitHours, MeterUnitFalls, MeterUnitCycles:
+		return unit, nil
+	}
+	return "", fmt.Errorf("%w: unknown meter unit %q (want days, km, hours, falls or cycles)", ErrInvalidInput, raw)
+}
+
+// SinceService is the usage counted since the item was last maintained.
+func (m UsageMeter) SinceService() float64 {
+	return m.Total - m.ServicedAtTotal
+}
+
+// Validate trims the name and checks the name and unit.
+func (m *UsageMeter) Validate() error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return fmt.Errorf("%w: meter name is required", ErrInvalidInput)
+	}
+	unit, err := ParseMeterUnit(string(m.Unit))
+	if err != nil {
+		return err
+	}
+	m.Unit = unit
+	return nil
+}
+
+// ValidateMeterAmount accepts positive, finite increments. Meters only count up.
+func ValidateMeterAmount(amount float64) error {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+		return fmt.Errorf("%w: meter amount must be positive", ErrInvalidInput)
+	}
+	return nil
+}
+
+// TripMeterAmounts returns the amount a completed trip adds to each meter of the
+// items it carried. Amounts recorded on the trip win; meters counting days that
+// have none get the trip's days, so ski days need no entry.
+func TripMeterAmounts(meters []UsageMeter, recorded []TripMeterUsage, days int) map[string]float64 {
+	amounts := map[string]float64{}
+	for _, m := range meters {
+		if m.Unit == MeterUnitDays && days > 0 {
+			amounts[m.ID] = float64(days)
+		}
+	}
+	onTrip := make(map[string]bool, len(meters))
+	for _, m := range meters {
+		onTrip[m.ID] = true
+	}
+	for _, u := range recorded {
+		// Usage of a meter whose item left the trip is dropped with it
+		if onTrip[u.MeterID] && u.Amount > 0 {
+			amounts[u.MeterID] = u.Amount
+		}
+	}
+	return amounts
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMeterUnit(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    MeterUnit
+		wantErr bool
+	}{
+		{"days", MeterUnitDays, false},
+		{" Hours ", MeterUnitHours, false},
+		{"FALLS", MeterUnitFalls, false},
+		{"miles", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMeterUnit(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMeterUnit(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ParseMeterUnit(%q) error = %v, want ErrInvalidInput", tt.raw, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseMeterUnit(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestUsageMeter_Validate(t *testing.T) {
+	m := UsageMeter{Name: "  Falls ", Unit: "Falls"}
+	if err := m.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if m.Name != "Falls" || m.Unit != MeterUnitFalls {
+		t.Errorf("Validate() normalized to %q %q, want Falls falls", m.Name, m.Unit)
+	}
+	if err := (&UsageMeter{Name: " ", Unit: MeterUnitDays}).Validate(); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Validate() without name error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestValidateMeterAmount(t *testing.T) {
+	for _, amount := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if err := ValidateMeterAmount(amount); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ValidateMeterAmount(%v) error = %v, want ErrInvalidInput", amount, err)
+		}
+	}
+	if err := ValidateMeterAmount(0.5); err != nil {
+		t.Errorf("ValidateMeterAmount(0.5) error = %v", err)
+	}
+}
+
+func TestUsageMeter_SinceService(t *testing.T) {
+	m := UsageMeter{Total: 42.5, ServicedAtTotal: 40}
+	if got := m.SinceService(); got != 2.5 {
+		t.Errorf("SinceService() = %v, want 2.5", got)
+	}
+}
+
+func TestTripMeterAmounts(t *testing.T) {
+	meters := []UsageMeter{
+		{ID: "ski-days", Unit: MeterUnitDays},
+		{ID: "boot-days", Unit: MeterUnitDays},
+		{ID: "falls", Unit: MeterUnitFalls},
+		{ID: "km", Unit: MeterUnitKm},
+	}
+	recorded := []TripMeterUsage{
+		{MeterID: "boot-days", Amount: 1},
+		{MeterID: "falls", Amount: 3},
+		{MeterID: "removed-item", Amount: 5},
+	}
+
+	got := TripMeterAmounts(meters, recorded, 4)
+
+	want := map[string]float64{"ski-days": 4, "boot-days": 1, "falls": 3}
+	if len(got) != len(want) {
+		t.Fatalf("TripMeterAmounts() = %v, want %v", got, want)
+	}
+	for id, amount := range want {
+		if got[id] != amount {
+			t.Errorf("TripMeterAmounts()[%q] = %v, want %v", id, got[id], amount)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// --- Depreciation ---
+
+type DepreciationMethod string
+
+const (
+	DepreciationNone             DepreciationMethod = "none"
+	DepreciationStraightLine     DepreciationMethod = "straightLine"
+	DepreciationDecliningBalance DepreciationMethod = "decliningBalance"
+)
+
+// DefaultDepreciationRule applies to categories without a DepreciationRule.
+var DefaultDepreciationRule = DepreciationRule{
+	Method:         DepreciationStraightLine,
+	LifeMonths:     60,
+	SalvagePercent: 10,
+}
+
+// Validate checks the parameters the method needs.
+func (r DepreciationRule) Validate() error {
+	if strings.TrimSpace(r.Category) == "" {
+		return fmt.Errorf("%w: rule category is required", ErrInvalidInput)
+	}
+	switch r.Method {
+	case DepreciationNone:
+	case DepreciationStraightLine:
+		if r.LifeMonths <= 0 {
+			return fmt.Errorf("%w: straightLine needs lifeMonths > 0", ErrInvalidInput)
+		}
+	case DepreciationDecliningBalance:
+		if r.AnnualRatePercent <= 0 || r.AnnualRatePercent >= 100 {
+			return fmt.Errorf("%w: decliningBalance needs annualRatePercent between 0 and 100", ErrInvalidInput)
+		}
+	default:
+		return fmt.Errorf("%w: unknown depreciation method %q", ErrInvalidInput, r.Method)
+	}
+	if r.SalvagePercent < 0 || r.SalvagePercent > 100 {
+		return fmt.Errorf("%w: salvagePercent must be between 0 and 100", ErrInvalidInput)
+	}
+	return nil
+}
+
+// CurrentValue depreciates price from purchased to now. The value never drops
+// below SalvagePercent of the price.
+func (r DepreciationRule) CurrentValue(price int, purchased, now time.Time) int {
+	ageMonths := monthsBetween(purchased, now)
+	floor := float64(price) * r.SalvagePercent / 100
+
+	value := float64(price)
+	switch r.Method {
+	case DepreciationStraightLine:
+		used := math.Min(ageMonths/float64(r.LifeMonths), 1)
+		value = float64(price) - (float64(price)-floor)*used
+	case DepreciationDecliningBalance:
+		value = float64(price) * math.Pow(1-r.AnnualRatePercent/100, ageMonths/12)
+	}
+	return int(math.Round(math.Max(value, floor)))
+}
+
+// monthsBetween returns the fractional age in months; future dates count as new.
+func monthsBetween(from, to time.Time) float64 {
+	if to.Before(from) {
+		return 0
+	}
+	return to.Sub(from).Hours() / 24 / (365.25 / 12)
+}
+
+// --- Valuation Report ---
+
+type ValuationGroupBy string
+
+const (
+	ValuationByCategory ValuationGroupBy = "category"
+	ValuationByStorage  ValuationGroupBy = "storage" // Location path, falling back to the free-text storage
+)
+
+// UnownedItemStatuses are left out of valuations; everything else is still in the house.
+var UnownedItemStatuses = []ItemStatus{ItemStatusSold, ItemStatusLost}
+
+func ParseValuationGroupBy(s string) (ValuationGroupBy, error) {
+	switch ValuationGroupBy(s) {
+	case "", ValuationByCategory:
+		return ValuationByCategory, nil
+	case ValuationByStorage:
+		return ValuationByStorage, nil
+	}
+	return "", fmt.Errorf("%w: unknown groupBy %q", ErrInvalidInput, s)
+}
+
+// ValuationInput is the purchase data of one owned item.
+type ValuationInput struct {
+	ItemID        string
+	Name          string
+	Category      string
+	Storage       string
+	Currency      string
+	PurchasePrice *int
+	PurchaseDate  *time.Time
+	CreatedAt     time.Time // Stands in for a missing PurchaseDate
+}
+
+// ValuationLine is one item of the report. ReplacementValue is what the item cost new.
+type ValuationLine struct {
+	ItemID           string             `json:"itemId"`
+	Name             string             `json:"name"`
+	Category         string             `json:"category"`
+	Storage          string             `json:"storage"`
+	PurchaseDate     *time.Time         `json:"purchaseDate,omitempty"`
+	AgeMonths        int                `json:"ageMonths"`
+	Method           DepreciationMethod `json:"method"`
+	ReplacementValue int                `json:"replacementValue"`
+	CurrentValue     int                `json:"currentValue"`
+}
+
+// ValuationGroup sums one category or storage place in one currency.
+type ValuationGroup struct {
+	Key              string          `json:"key"`
+	Currency         string          `json:"currency"`
+	ItemCount        int             `json:"itemCount"`
+	ReplacementValue int             `json:"replacementValue"`
+	CurrentValue     int             `json:"currentValue"`
+	Items            []ValuationLine `json:"items"`
+}
+
+type ValuationTotal struct {
+	Currency         string `json:"currency"`
+	ItemCount        int    `json:"itemCount"`
+	ReplacementValue int    `json:"replacementValue"`
+	CurrentValue     int    `json:"currentValue"`
+}
+
+// ValuationReport lists owned items for insurance purposes. Unpriced items have
+// no purchase price and are listed separately so they can be filled in.
+type ValuationReport struct {
+	GeneratedAt time.Time        `json:"generatedAt"`
+	GroupBy     ValuationGroupBy `json:"groupBy"`
+	Groups      []ValuationGroup `json:"groups"`
+	Totals      []ValuationTotal `json:"totals"`
+	Unpriced    []ValuationLine  `json:"unpriced"`
+}
+
+// BuildValuationReport depreciates every priced item with the rule of its category
+// (DefaultDepreciationRule otherwise) and groups the results.
+func BuildValuationReport(inputs []ValuationInput, rules []DepreciationRule, groupBy ValuationGroupBy, now time.Time) ValuationReport {
+	byCategory := map[string]DepreciationRule{}
+	for _, rule := range rules {
+		byCategory[rule.Category] = rule
+	}
+
+	report := ValuationReport{
+		GeneratedAt: now,
+		GroupBy:     groupBy,
+		Groups:      []ValuationGroup{},
+		Totals:      []ValuationTotal{},
+		Unpriced:    []ValuationLine{},
+	}
+	groups := map[[2]string]*ValuationGroup{}
+	totals := map[string]*ValuationTotal{}
+
+	for _, in := range inputs {
+		line := ValuationLine{
+			ItemID:       in.ItemID,
+			Name:         in.Name,
+			Category:     valuationKey(in.Category, "Uncategorized"),
+			Storage:      valuationKey(in.Storage, "Unassigned"),
+			PurchaseDate: in.PurchaseDate,
+		}
+		if in.PurchasePrice == nil {
+			report.Unpriced = append(report.Unpriced, line)
+			continue
+		}
+
+		rule, ok := byCategory[in.Category]
+		if !ok {
+			rule = DefaultDepreciationRule
+		}
+		purchased := in.CreatedAt
+		if in.PurchaseDate != nil {
+			purchased = *in.PurchaseDate
+		}
+		line.AgeMonths = int(monthsBetween(purchased, now))
+		line.Method = rule.Method
+		line.ReplacementValue = *in.PurchasePrice
+		line.CurrentValue = rule.CurrentValue(*in.PurchasePrice, purchased, now)
+
+		currency := in.Currency
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		key := line.Category
+		if groupBy == ValuationByStorage {
+			key = line.Storage
+		}
+		group, ok := groups[[2]string{key, currency}]
+		if !ok {
+			group = &ValuationGroup{Key: key, Currency: currency}
+			groups[[2]string{key, currency}] = group
+		}
+		group.ItemCount++
+		group.ReplacementValue += line.ReplacementValue
+		group.CurrentValue += line.CurrentValue
+		group.Items = append(group.Items, line)
+
+		total, ok := totals[currency]
+		if !ok {
+			total = &ValuationTotal{Currency: currency}
+			totals[currency] = total
+		}
+		total.ItemCount++
+		total.ReplacementValue += line.ReplacementValue
+		total.CurrentValue += line.CurrentValue
+	}
+
+	for _, group := range groups {
+		sort.Slice(group.Items, func(i, j int) bool { return group.Items[i].ReplacementValue > group.Items[j].ReplacementValue })
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Currency != report.Groups[j].Currency {
+			return report.Groups[i].Currency < report.Groups[j].Currency
+		}
+		return report.Groups[i].ReplacementValue > report.Groups[j].ReplacementValue
+	})
+	for _, total := range totals {
+		report.Totals = append(report.Totals, *total)
+	}
+	sort.Slice(report.Totals, func(i, j int) bool { return report.Totals[i].Currency < report.Totals[j].Currency })
+	sort.Slice(report.Unpriced, func(i, j int) bool { return report.Unpriced[i].Name < report.Unpriced[j].Name })
+	return report
+}
+
+func valuationKey(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
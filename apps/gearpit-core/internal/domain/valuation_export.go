@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/csv"
+	"html/template"
+	"io"
+	"strconv"
+)
+
+var valuationCSVHeader = []string{
+	"group", "currency", "itemId", "name", "category", "storage", "purchaseDate",
+	"ageMonths", "method", "replacementValue", "currentValue",
+}
+
+// WriteValuationCSV writes one row per item, unpriced items last with empty values.
+func WriteValuationCSV(w io.Writer, report ValuationReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(valuationCSVHeader); err != nil {
+		return err
+	}
+	for _, group := range report.Groups {
+		for _, line := range group.Items {
+			record := []string{
+				group.Key,
+				group.Currency,
+				line.ItemID,
+				line.Name,
+				line.Category,
+				line.Storage,
+				formatDate(line.PurchaseDate),
+				strconv.Itoa(line.AgeMonths),
+				string(line.Method),
+				FormatMoney(line.ReplacementValue, group.Currency),
+				FormatMoney(line.CurrentValue, group.Currency),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	for _, line := range report.Unpriced {
+		record := []string{"Unpriced", "", line.ItemID, line.Name, line.Category, line.Storage, formatDate(line.PurchaseDate), "", "", "", ""}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+var valuationHTML = template.Must(template.New("valuation").Funcs(template.FuncMap{
+	"money": FormatMoney,
+	"date":  formatDate,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>GearPit valuation report</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+th, td { border: 1px solid #999; padding: 4px 6px; text-align: left; }
+td.num, th.num { text-align: right; }
+h2 { page-break-after: avoid; }
+table { page-break-inside: auto; }
+tr { page-break-inside: avoid; }
+</style>
+</head>
+<body>
+<h1>Valuation report</h1>
+<p>Generated {{.GeneratedAt.Format "2006-01-02 15:04"}}, grouped by {{.GroupBy}}.</p>
+
+<h2>Totals</h2>
+<table>
+<tr><th>Currency</th><th class="num">Items</th><th class="num">Replacement value</th><th class="num">Current value</th></tr>
+{{range .Totals}}<tr><td>{{.Currency}}</td><td class="num">{{.ItemCount}}</td><td class="num">{{money .ReplacementValue .Currency}}</td><td class="num">{{money .CurrentValue .Currency}}</td></tr>
+{{end}}</table>
+
+{{range .Groups}}{{$currency := .Currency}}
+<h2>{{.Key}} ({{.Currency}})</h2>
+<table>
+<tr><th>Item</th><th>Category</th><th>Storage</th><th>Purchased</th><th class="num">Age (months)</th><th class="num">Replacement value</th><th class="num">Current value</th></tr>
+{{range .Items}}<tr><td>{{.Name}}</td><td>{{.Category}}</td><td>{{.Storage}}</td><td>{{date .PurchaseDate}}</td><td class="num">{{.AgeMonths}}</td><td class="num">{{money .ReplacementValue $currency}}</td><td class="num">{{money .CurrentValue $currency}}</td></tr>
+{{end}}<tr><th colspan="5">Subtotal ({{.ItemCount}} items)</th><th class="num">{{money .ReplacementValue .Currency}}</th><th class="num">{{money .CurrentValue .Currency}}</th></tr>
+</table>
+{{end}}
+{{if .Unpriced}}
+<h2>Items without a purchase price</h2>
+<table>
+<tr><th>Item</th><th>Category</th><th>Storage</th></tr>
+{{range .Unpriced}}<tr><td>{{.Name}}</td><td>{{.Category}}</td><td>{{.Storage}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// WriteValuationHTML renders a printable report.
+func WriteValuationHTML(w io.Writer, report ValuationReport) error {
+	return valuationHTML.Execute(w, report)
+}
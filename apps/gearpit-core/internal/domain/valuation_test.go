@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDepreciationRule_CurrentValue(t *testing.T) {
+	purchased := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	twoYears := purchased.AddDate(2, 0, 0)
+
+	tests := []struct {
+		name string
+		rule DepreciationRule
+		now  time.Time
+		want int
+	}{
+		{"none keeps the price", DepreciationRule{Method: DepreciationNone}, twoYears, 100000},
+		{"straight line halfway", DepreciationRule{Method: DepreciationStraightLine, LifeMonths: 48}, twoYears, 50000},
+		{"straight line stops at salvage", DepreciationRule{Method: DepreciationStraightLine, LifeMonths: 12, SalvagePercent: 20}, twoYears, 20000},
+		{"declining balance", DepreciationRule{Method: DepreciationDecliningBalance, AnnualRatePercent: 50}, twoYears, 25000},
+		{"future purchase date counts as new", DepreciationRule{Method: DepreciationStraightLine, LifeMonths: 12}, purchased.AddDate(0, -1, 0), 100000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.CurrentValue(100000, purchased, tt.now)
+			// Calendar years are not exactly 24 average months; allow rounding slack
+			if diff := got - tt.want; diff < -100 || diff > 100 {
+				t.Errorf("CurrentValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepreciationRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    DepreciationRule
+		wantErr bool
+	}{
+		{"valid straight line", DepreciationRule{Category: "Skis", Method: DepreciationStraightLine, LifeMonths: 36}, false},
+		{"missing life", DepreciationRule{Category: "Skis", Method: DepreciationStraightLine}, true},
+		{"rate out of range", DepreciationRule{Category: "PC GPU", Method: DepreciationDecliningBalance, AnnualRatePercent: 120}, true},
+		{"unknown method", DepreciationRule{Category: "Rope", Method: "magic"}, true},
+		{"missing category", DepreciationRule{Method: DepreciationNone}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildValuationReport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	bought := now.AddDate(-1, 0, 0)
+	p := func(v int) *int { return &v }
+
+	inputs := []ValuationInput{
+		{ItemID: "1", Name: "Skis", Category: "Skis", Storage: "Garage", Currency: "JPY", PurchasePrice: p(120000), PurchaseDate: &bought},
+		{ItemID: "2", Name: "Boots", Category: "Skis", Storage: "Closet", Currency: "JPY", PurchasePrice: p(60000), PurchaseDate: &bought},
+		{ItemID: "3", Name: "GPU", Category: "PC GPU", Storage: "Garage", Currency: "USD", PurchasePrice: p(50000), CreatedAt: bought},
+		{ItemID: "4", Name: "Old tent", Category: "Tent"},
+	}
+	rules := []DepreciationRule{{Category: "Skis", Method: DepreciationNone}}
+
+	report := BuildValuationReport(inputs, rules, ValuationByCategory, now)
+
+	if len(report.Groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(report.Groups))
+	}
+	skis := report.Groups[0]
+	if skis.Key != "Skis" || skis.ItemCount != 2 || skis.CurrentValue != 180000 {
+		t.Errorf("Skis group = %+v", skis)
+	}
+	gpu := report.Groups[1]
+	if gpu.Currency != "USD" || gpu.Items[0].Method != DefaultDepreciationRule.Method || gpu.CurrentValue >= 50000 {
+		t.Errorf("GPU group should use the default rule, got %+v", gpu)
+	}
+	if len(report.Totals) != 2 || report.Totals[0].ReplacementValue != 180000 {
+		t.Errorf("Totals = %+v", report.Totals)
+	}
+	if len(report.Unpriced) != 1 || report.Unpriced[0].Storage != "Unassigned" {
+		t.Errorf("Unpriced = %+v", report.Unpriced)
+	}
+
+	byStorage := BuildValuationReport(inputs, rules, ValuationByStorage, now)
+	if len(byStorage.Groups) != 3 {
+		t.Errorf("expected 3 storage groups (Garage JPY, Closet JPY, Garage USD), got %d", len(byStorage.Groups))
+	}
+}
+
+func TestWriteValuationHTML(t *testing.T) {
+	price := 1299
+	report := BuildValuationReport([]ValuationInput{
+		{ItemID: "1", Name: "<script>Stove</script>", Currency: "USD", PurchasePrice: &price},
+	}, nil, ValuationByCategory, time.Now())
+
+	var buf bytes.Buffer
+	if err := WriteValuationHTML(&buf, report); err != nil {
+		t.Fatalf("WriteValuationHTML() error = %v", err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") {
+		t.Error("item names must be escaped")
+	}
+	if !strings.Contains(out, "12.99") {
+		t.Error("expected price formatted in major units")
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// --- Warranty ---
+
+// DefaultWarrantyWindowDays is the look-ahead of the expiring warranties list.
+const DefaultWarrantyWindowDays = 30
+
+// MaxWindowDays caps look-ahead lists (warranties, expiring items) to something a person would act on.
+const MaxWindowDays = 3650
+
+// WarrantyAlert is an item whose warranty ends within the requested window.
+type WarrantyAlert struct {
+	Item     Item `json:"item"`
+	DaysLeft int  `json:"daysLeft"`
+}
+
+// NormalizeSerialNumber trims whitespace and upper-cases a serial number;
+// lookups compare serials in this form.
+func NormalizeSerialNumber(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// ValidateWindowDays checks the days parameter of look-ahead lists.
+func ValidateWindowDays(days int) error {
+	if days < 0 || days > MaxWindowDays {
+		return fmt.Errorf("%w: days must be between 0 and %d", ErrInvalidInput, MaxWindowDays)
+	}
+	return nil
+}
+
+// WarrantyDaysLeft returns the whole days until the warranty ends (negative once
+// expired), or nil when no expiry is recorded.
+func (i Item) WarrantyDaysLeft(now time.Time) *int {
+	if i.WarrantyExpiresAt == nil {
+		return nil
+	}
+	days := int(math.Ceil(i.WarrantyExpiresAt.Sub(now).Hours() / 24))
+	return &days
+}
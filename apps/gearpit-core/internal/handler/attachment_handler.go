@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type AttachmentHandler struct {
+	service domain.AttachmentService
+}
+
+func NewAttachmentHandler(s domain.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: s}
+}
+
+// ListAttachments handles GET /api/v1/attachments?ownerType=items&ownerId={id}
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	owner, err := domain.ParseAttachmentOwner(r.URL.Query().Get("ownerType"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		http.Error(w, "ownerId is required", http.StatusBadRequest)
+		return
+	}
+
+	attachments, err := h.service.List(r.Context(), owner, ownerID)
+	if err != nil {
+		writeDomainError(w, err, "Failed to list attachments")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(attachments); err != nil {
+		slog.Error("Failed to encode attachments", "error", err)
+	}
+}
+
+// UploadAttachment handles POST /api/v1/attachments as multipart/form-data
+// with the fields ownerType (items | maintenanceLogs), ownerId and file.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	// Leave room for the multipart framing and the other fields
+	r.Body = http.MaxBytesReader(w, r.Body, domain.MaxAttachmentSize+1<<20)
+	if err := r.ParseMultipartForm(domain.MaxAttachmentSize); err != nil {
+		http.Error(w, "Invalid upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	owner, err := domain.ParseAttachmentOwner(r.FormValue("ownerType"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ownerID := r.FormValue("ownerId")
+	if ownerID == "" {
+		http.Error(w, "ownerId is required", http.StatusBadRequest)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer func() { _ = file.Close() }()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read upload", http.StatusBadRequest)
+		return
+	}
+
+	attachment, err := h.service.Upload(r.Context(), domain.UploadAttachmentParams{
+		Owner:    owner,
+		OwnerID:  ownerID,
+		FileName: header.Filename,
+		Data:     data,
+	})
+	if err != nil {
+		writeDomainError(w, err, "Failed to upload attachment")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(attachment); err != nil {
+		slog.Error("Failed to encode attachment", "error", err)
+	}
+}
+
+// HandleAttachment serves GET (download), GET .../thumbnail and DELETE /api/v1/attachments/{id}
+func (h *AttachmentHandler) HandleAttachment(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/attachments/")
+	thumbnail := strings.HasSuffix(id, "/thumbnail")
+	id = strings.TrimSuffix(id, "/thumbnail")
+	if id == "" || strings.Contains(id, "/") {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		attachment, content, err := h.service.Open(r.Context(), id, thumbnail)
+		if err != nil {
+			writeDomainError(w, err, "Failed to open attachment")
+			return
+		}
+		defer func() { _ = content.Close() }()
+
+		contentType := attachment.ContentType
+		if thumbnail {
+			contentType = "image/jpeg"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName}))
+		if _, err := io.Copy(w, content); err != nil {
+			slog.Error("Failed to write attachment", "id", id, "error", err)
+		}
+
+	case http.MethodDelete:
+		if thumbnail {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := h.service.Delete(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete attachment")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
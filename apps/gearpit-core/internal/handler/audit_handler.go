@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+// ActorHeader names who is making a request (a person's name or profile ID).
+// There are no accounts, so the value is recorded in the history as given.
+const ActorHeader = "X-Actor"
+
+// ActorMiddleware stores the request's actor in the context for the audit log.
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := strings.TrimSpace(r.Header.Get(ActorHeader)); actor != "" {
+			r = r.WithContext(domain.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type AuditHandler struct {
+	service domain.AuditService
+}
+
+func NewAuditHandler(s domain.AuditService) *AuditHandler {
+	return &AuditHandler{service: s}
+}
+
+// HandleHistory serves GET /api/v1/history/{entity}/{id} and
+// POST /api/v1/history/{entity}/{id}/{entryId}/revert
+func (h *AuditHandler) HandleHistory(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/history/")
+	revert := strings.HasSuffix(path, "/revert")
+	path = strings.TrimSuffix(path, "/revert")
+
+	parts := strings.Split(path, "/")
+	if (!revert && len(parts) != 2) || (revert && len(parts) != 3) || parts[1] == "" {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+	entity, err := domain.ParseAuditEntity(parts[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var entries []domain.AuditEntry
+	switch {
+	case !revert && r.Method == http.MethodGet:
+		entries, err = h.service.GetHistory(r.Context(), entity, parts[1])
+	case revert && r.Method == http.MethodPost:
+		entries, err = h.service.RevertChange(r.Context(), entity, parts[1], parts[2])
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		writeDomainError(w, err, "Failed to process history")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		slog.Error("Failed to encode history", "error", err)
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type BackupHandler struct {
+	service domain.BackupService
+}
+
+func NewBackupHandler(s domain.BackupService) *BackupHandler {
+	return &BackupHandler{service: s}
+}
+
+func (h *BackupHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	archive, err := h.service.CreateBackup(r.Context())
+	if err != nil {
+		slog.Error("Failed to create backup", "error", err)
+		http.Error(w, "Failed to create backup", http.StatusInternalServerError)
+		return
+	}
+
+	fileName := "gearpit-backup-" + archive.CreatedAt.Format("20060102-150405") + ".json"
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	if err := json.NewEncoder(w).Encode(archive); err != nil {
+		slog.Error("Failed to encode backup", "error", err)
+	}
+}
+
+// RestoreBackup handles POST /api/v1/backup/restore?policy=skip|overwrite|fail
+func (h *BackupHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	policy, err := domain.ParseConflictPolicy(r.URL.Query().Get("policy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var archive domain.BackupArchive
+	if err := json.NewDecoder(r.Body).Decode(&archive); err != nil {
+		http.Error(w, "Invalid archive", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.RestoreBackup(r.Context(), &archive, policy)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, domain.ErrConflict):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("Failed to restore backup", "error", err)
+			http.Error(w, "Failed to restore backup", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Error("Failed to encode restore result", "error", err)
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type CatalogHandler struct {
+	service domain.CatalogService
+}
+
+func NewCatalogHandler(s domain.CatalogService) *CatalogHandler {
+	return &CatalogHandler{service: s}
+}
+
+type ImportCatalogRequest struct {
+	Format string `json:"format"` // "csv" or "json"
+	Data   string `json:"data"`   // Raw CSV text or a JSON array of products
+	DryRun bool   `json:"dryRun"`
+}
+
+// BarcodeLookupResponse answers a scan. Params can be posted to /api/v1/gears as is.
+type BarcodeLookupResponse struct {
+	Barcode string                 `json:"barcode"`
+	Product *domain.CatalogProduct `json:"product"` // null when the barcode is not in the catalog
+	Params  CreateItemRequest      `json:"params"`
+	Items   []domain.Item          `json:"items"` // Items already carrying the barcode
+}
+
+// ImportCatalog handles POST /api/v1/catalog/import
+func (h *CatalogHandler) ImportCatalog(w http.ResponseWriter, r *http.Request) {
+	var req ImportCatalogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	format, err := domain.ParseCatalogFormat(req.Format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.ImportCatalog(r.Context(), format, strings.NewReader(req.Data), req.DryRun)
+	if err != nil {
+		writeDomainError(w, err, "Failed to import catalog")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(result.Errors) > 0 && !result.DryRun {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Error("Failed to encode catalog import result", "error", err)
+	}
+}
+
+// LookupBarcode handles GET /api/v1/catalog/lookup?barcode=4901234567894
+func (h *CatalogHandler) LookupBarcode(w http.ResponseWriter, r *http.Request) {
+	lookup, err := h.service.LookupBarcode(r.Context(), r.URL.Query().Get("barcode"))
+	if err != nil {
+		writeDomainError(w, err, "Failed to look up barcode")
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range lookup.Items {
+		lookup.Items[i].ApplyDisplayUnit(unit)
+	}
+
+	p := lookup.Params
+	resp := BarcodeLookupResponse{
+		Barcode: lookup.Barcode,
+		Product: lookup.Product,
+		Params: CreateItemRequest{
+			Name:          p.Name,
+			Description:   p.Description,
+			Manufacturer:  p.Manufacturer,
+			WeightGram:    p.WeightGram,
+			Unit:          string(p.Unit),
+			WeightType:    string(p.WeightType),
+			Category:      p.Category,
+			Brand:         p.Brand,
+			Tags:          []string{},
+			PurchasePrice: p.PurchasePrice,
+			Currency:      p.Currency,
+			Barcode:       &p.Barcode,
+		},
+		Items: lookup.Items,
+	}
+	if resp.Items == nil {
+		resp.Items = []domain.Item{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.Error("Failed to encode barcode lookup", "error", err)
+	}
+}
@@ -16,11 +16,14 @@ func NewDashboardHandler(s domain.DashboardService) *DashboardHandler {
 }
 
 func (h *DashboardHandler) GetStats(w http.ResponseWriter, r *http.Request) {
-	stats, err := h.service.GetDashboardStats(r.Context())
+	// Retired and sold items are left out unless ?includeInactive=true
+	includeInactive := r.URL.Query().Get("includeInactive") == "true"
+	stats, err := h.service.GetDashboardStats(r.Context(), includeInactive)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	stats.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Content-Type", "application/json")
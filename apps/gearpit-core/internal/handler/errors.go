@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+// writeDomainError maps domain sentinel errors to status codes; anything else is logged as a 500.
+func writeDomainError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, domain.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		slog.Error(message, "error", err)
+		http.Error(w, message, http.StatusInternalServerError)
+	}
+}
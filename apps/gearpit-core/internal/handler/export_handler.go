@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type ExportHandler struct {
+	service domain.ExportService
+}
+
+func NewExportHandler(s domain.ExportService) *ExportHandler {
+	return &ExportHandler{service: s}
+}
+
+// Export serves /api/v1/export/{entity}[/{id}]?format=csv|json|lighterpack
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/export/"), "/"), "/")
+	params := domain.ExportParams{Entity: domain.ExportEntity(parts[0])}
+	if len(parts) > 1 {
+		params.ID = parts[1]
+	}
+
+	format, err := domain.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	params.Format = format
+
+	file, err := h.service.Export(r.Context(), params)
+	if err != nil {
+		writeDomainError(w, err, "Failed to export")
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+file.FileName+`"`)
+	if _, err := w.Write(file.Data); err != nil {
+		slog.Error("Failed to write export", "error", err)
+	}
+}
@@ -2,9 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -24,16 +29,89 @@ func NewGearHandler(s domain.GearService) *GearHandler {
 
 // リクエスト用の構造体を定義
 type CreateItemRequest struct {
-	Name                string   `json:"name" validate:"required"`
-	Description         string   `json:"description"`
-	Manufacturer        string   `json:"manufacturer"`
-	WeightGram          int      `json:"weightGram" validate:"min=0"`
-	WeightType          string   `json:"weightType" validate:"oneof=base consumable worn long accessory"`
-	Category            string   `json:"category"`
-	Brand               string   `json:"brand"`
-	Tags                []string `json:"tags"`
-	UsageCount          int      `json:"usageCount" validate:"min=0"`
-	MaintenanceInterval int      `json:"maintenanceInterval" validate:"min=0"`
+	Name                string                 `json:"name" validate:"required"`
+	Description         string                 `json:"description"`
+	Manufacturer        string                 `json:"manufacturer"`
+	WeightGram          int                    `json:"weightGram" validate:"min=0"`
+	Weight              *float64               `json:"weight" validate:"omitempty,min=0"` // Optional: weight in Unit, overrides weightGram
+	Unit                string                 `json:"unit"`                              // "g", "kg", "oz", "lb"
+	WeightType          string                 `json:"weightType" validate:"oneof=base consumable worn long accessory"`
+	Category            string                 `json:"category"`
+	Brand               string                 `json:"brand"`
+	Tags                []string               `json:"tags"`
+	Attributes          map[string]interface{} `json:"attributes"` // Category-specific properties (see /api/v1/property-schemas)
+	UsageCount          int                    `json:"usageCount" validate:"min=0"`
+	MaintenanceInterval int                    `json:"maintenanceInterval" validate:"min=0"`
+	MaintenanceLogType  *string                `json:"maintenanceLogType"`                       // Log type that restarts maintenanceInterval, e.g. "cleaning"; omitted keeps it on update
+	PurchasePrice       *int                   `json:"purchasePrice" validate:"omitempty,min=0"` // Minor units of currency (yen, cents)
+	PurchaseDate        string                 `json:"purchaseDate"`                             // RFC 3339 or YYYY-MM-DD
+	Currency            string                 `json:"currency"`                                 // ISO 4217, default JPY
+	Vendor              string                 `json:"vendor"`
+	Storage             string                 `json:"storage"`            // Where the item is kept
+	SerialNumber        *string                `json:"serialNumber"`       // On update, omitted keeps it and "" clears it
+	WarrantyExpiresAt   *string                `json:"warrantyExpiresAt"`  // RFC 3339 or YYYY-MM-DD; on update, omitted keeps it and "" clears it
+	ExpiresAt           *string                `json:"expiresAt"`          // RFC 3339 or YYYY-MM-DD; on update, omitted keeps it and "" clears it
+	LotNumber           *string                `json:"lotNumber"`          // On update, omitted keeps it and "" clears it
+	Barcode             *string                `json:"barcode"`            // UPC/EAN; see /api/v1/catalog/lookup. On update, omitted keeps it and "" clears it
+	StockQuantity       *int                   `json:"stockQuantity"`      // Consumables only; omit to leave stock untracked
+	ReorderThreshold    *int                   `json:"reorderThreshold"`   // Stock to keep in reserve
+	MaintenanceMeterID  *string                `json:"maintenanceMeterId"` // Updates only: meter maintenanceInterval counts on, "" for usageCount
+}
+
+// purchase parses the optional purchase date and currency code.
+func (req CreateItemRequest) purchase() (*time.Time, string, error) {
+	currency, err := domain.NormalizeCurrency(req.Currency)
+	if err != nil {
+		return nil, "", err
+	}
+	date, err := parseOptionalDate("purchaseDate", req.PurchaseDate)
+	if err != nil {
+		return nil, "", err
+	}
+	return date, currency, nil
+}
+
+// parseOptionalDate parses an RFC 3339 or YYYY-MM-DD body field; empty yields nil.
+func parseOptionalDate(field, raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	date, err := parseQueryTime(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", field, raw)
+	}
+	return &date, nil
+}
+
+// stringValue returns *s, or "" when the field was omitted.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// clears reports whether an update sent an optional field as "", which removes its value.
+func clears(s *string) bool {
+	return s != nil && strings.TrimSpace(*s) == ""
+}
+
+// normalizedWeight returns the weight in grams and the unit it was entered in.
+// Without "weight", weightGram is used as-is and the unit is left unchanged.
+func (req CreateItemRequest) normalizedWeight() (int, domain.WeightUnit, error) {
+	if req.Weight == nil {
+		if req.Unit == "" {
+			return req.WeightGram, "", nil
+		}
+		unit, err := domain.ParseWeightUnit(req.Unit)
+		return req.WeightGram, unit, err
+	}
+	unit, err := domain.ParseWeightUnit(req.Unit)
+	if err != nil {
+		return 0, "", err
+	}
+	grams, err := domain.ToGrams(*req.Weight, unit)
+	return grams, unit, err
 }
 
 func (h *GearHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
@@ -48,25 +126,71 @@ func (h *GearHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	weightGram, unit, err := req.normalizedWeight()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	purchaseDate, currency, err := req.purchase()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	warrantyExpiresAt, err := parseOptionalDate("warrantyExpiresAt", stringValue(req.WarrantyExpiresAt))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	expiresAt, err := parseOptionalDate("expiresAt", stringValue(req.ExpiresAt))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var reorderThreshold int
+	if req.ReorderThreshold != nil {
+		reorderThreshold = *req.ReorderThreshold
+	}
+
 	params := domain.CreateGearParams{
 		Name:                req.Name,
 		Description:         req.Description,
 		Manufacturer:        req.Manufacturer,
-		WeightGram:          req.WeightGram,
+		WeightGram:          weightGram,
 		WeightType:          domain.WeightType(req.WeightType),
+		Unit:                unit,
 		Category:            req.Category,
 		Brand:               req.Brand,
 		Tags:                req.Tags,
+		Attributes:          req.Attributes,
 		UsageCount:          req.UsageCount,
 		MaintenanceInterval: req.MaintenanceInterval,
+		MaintenanceLogType:  stringValue(req.MaintenanceLogType),
+		PurchasePrice:       req.PurchasePrice,
+		PurchaseDate:        purchaseDate,
+		Currency:            currency,
+		Vendor:              req.Vendor,
+		Storage:             req.Storage,
+		SerialNumber:        stringValue(req.SerialNumber),
+		WarrantyExpiresAt:   warrantyExpiresAt,
+		ExpiresAt:           expiresAt,
+		LotNumber:           stringValue(req.LotNumber),
+		Barcode:             stringValue(req.Barcode),
+		StockQuantity:       req.StockQuantity,
+		ReorderThreshold:    reorderThreshold,
 	}
 
 	item, err := h.service.CreateItem(r.Context(), params)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		slog.Error("Failed to create item", "error", err)
 		http.Error(w, "Failed to create item", http.StatusInternalServerError)
 		return
 	}
+	item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(item); err != nil {
@@ -75,14 +199,213 @@ func (h *GearHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SearchItems handles GET /api/v1/gears.
+//
+// Filters: q, weightType (repeatable or comma separated), category, brand, tag (repeatable, all must match),
+// status (repeatable; default hides retired/sold), includeInactive=true,
+// minWeightGram, maxWeightGram, minUsageCount, maintenanceDue=true, createdAfter, createdBefore (RFC 3339 or YYYY-MM-DD),
+// location (location ID including sub-locations, or "none" for items without a location).
+// Sorting: sort=name|weight|createdAt|usageCount, order=asc|desc.
+// Paging: limit and cursor. Without them the response stays a plain array; with them it is a GearPage.
+// X-Total-Count carries the number of matches either way.
 func (h *GearHandler) SearchItems(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("q")
-	items, err := h.service.SearchItems(r.Context(), query)
+	filter, err := parseGearFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.service.ListItemsFiltered(r.Context(), filter)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		slog.Error("Failed to search items", "error", err)
 		http.Error(w, "Failed to search items", http.StatusInternalServerError)
 		return
 	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range page.Items {
+		page.Items[i].ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	var body interface{} = page.Items
+	if filter.Limit > 0 {
+		body = page
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Failed to encode items", "error", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// SearchRanked handles GET /api/v1/gears/search?q=sawyer+squeeze&limit=20
+func (h *GearHandler) SearchRanked(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+
+	hits, err := h.service.SearchRanked(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to search items", "error", err)
+		http.Error(w, "Failed to search items", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range hits {
+		hits[i].Item.ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hits); err != nil {
+		slog.Error("Failed to encode search results", "error", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+func parseGearFilter(query url.Values) (domain.GearFilter, error) {
+	filter := domain.GearFilter{
+		Query:           query.Get("q"),
+		Category:        query.Get("category"),
+		Brand:           query.Get("brand"),
+		LocationID:      query.Get("location"),
+		Tags:            splitQueryList(query["tag"]),
+		MaintenanceDue:  query.Get("maintenanceDue") == "true",
+		IncludeInactive: query.Get("includeInactive") == "true",
+		SortBy:          domain.GearSortField(query.Get("sort")),
+		Cursor:          query.Get("cursor"),
+	}
+	for _, wt := range splitQueryList(query["weightType"]) {
+		filter.WeightTypes = append(filter.WeightTypes, domain.WeightType(wt))
+	}
+	for _, status := range splitQueryList(query["status"]) {
+		filter.Statuses = append(filter.Statuses, domain.ItemStatus(status))
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.SortDesc = true
+	default:
+		return filter, errors.New("order must be asc or desc")
+	}
+	if filter.SortBy == "" && query.Get("order") != "" {
+		filter.SortBy = domain.GearSortCreatedAt
+	}
+
+	ints := []struct {
+		name string
+		dest **int
+	}{
+		{"minWeightGram", &filter.MinWeightGram},
+		{"maxWeightGram", &filter.MaxWeightGram},
+		{"minUsageCount", &filter.MinUsageCount},
+	}
+	for _, p := range ints {
+		if raw := query.Get(p.name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be an integer", p.name)
+			}
+			*p.dest = &v
+		}
+	}
+	if raw := query.Get("limit"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			return filter, errors.New("limit must be a positive integer")
+		}
+		filter.Limit = v
+	}
+
+	dates := []struct {
+		name string
+		dest **time.Time
+	}{
+		{"createdAfter", &filter.CreatedAfter},
+		{"createdBefore", &filter.CreatedBefore},
+	}
+	for _, p := range dates {
+		if raw := query.Get(p.name); raw != "" {
+			t, err := parseQueryTime(raw)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be RFC 3339 or YYYY-MM-DD", p.name)
+			}
+			*p.dest = &t
+		}
+	}
+	return filter, nil
+}
+
+// splitQueryList accepts both repeated parameters and comma separated values.
+func splitQueryList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func parseQueryTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
+
+// propertyQueryReserved are query parameters that are not property filters.
+var propertyQueryReserved = map[string]bool{"category": true, "unit": true, "profileId": true}
+
+// FindByProperties handles GET /api/v1/gears/by-property?category=Skis&waistWidth=gte:100&dryTreated=true
+// Every other query parameter is a property filter: "value" or "op:value" with op eq|gt|gte|lt|lte.
+func (h *GearHandler) FindByProperties(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var filters []domain.PropertyFilter
+	for key, values := range query {
+		if propertyQueryReserved[key] {
+			continue
+		}
+		for _, raw := range values {
+			filter, err := domain.ParsePropertyFilter(key, raw)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	items, err := h.service.FindByProperties(r.Context(), query.Get("category"), filters)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to find items by properties", "error", err)
+		http.Error(w, "Failed to find items", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range items {
+		items[i].ApplyDisplayUnit(unit)
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(items); err != nil {
@@ -104,26 +427,119 @@ func (h *GearHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	weightGram, unit, err := req.normalizedWeight()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	purchaseDate, currency, err := req.purchase()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	warrantyExpiresAt, err := parseOptionalDate("warrantyExpiresAt", stringValue(req.WarrantyExpiresAt))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	expiresAt, err := parseOptionalDate("expiresAt", stringValue(req.ExpiresAt))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	params := domain.UpdateGearParams{
-		Name:                req.Name,
-		Description:         req.Description,
-		Manufacturer:        req.Manufacturer,
-		WeightGram:          req.WeightGram,
-		WeightType:          domain.WeightType(req.WeightType),
-		Category:            req.Category,
-		Brand:               req.Brand,
-		Tags:                req.Tags,
-		UsageCount:          req.UsageCount,
-		MaintenanceInterval: req.MaintenanceInterval,
+		Name:                   req.Name,
+		Description:            req.Description,
+		Manufacturer:           req.Manufacturer,
+		WeightGram:             weightGram,
+		WeightType:             domain.WeightType(req.WeightType),
+		Unit:                   unit,
+		Category:               req.Category,
+		Brand:                  req.Brand,
+		Tags:                   req.Tags,
+		Attributes:             req.Attributes,
+		UsageCount:             req.UsageCount,
+		MaintenanceInterval:    req.MaintenanceInterval,
+		MaintenanceLogType:     req.MaintenanceLogType,
+		PurchasePrice:          req.PurchasePrice,
+		PurchaseDate:           purchaseDate,
+		Currency:               currency,
+		Vendor:                 req.Vendor,
+		Storage:                req.Storage,
+		SerialNumber:           req.SerialNumber,
+		WarrantyExpiresAt:      warrantyExpiresAt,
+		ClearWarrantyExpiresAt: clears(req.WarrantyExpiresAt),
+		ExpiresAt:              expiresAt,
+		ClearExpiresAt:         clears(req.ExpiresAt),
+		LotNumber:              req.LotNumber,
+		Barcode:                req.Barcode,
+		StockQuantity:          req.StockQuantity,
+		ReorderThreshold:       req.ReorderThreshold,
+		MaintenanceMeterID:     req.MaintenanceMeterID,
 	}
 
 	item, err := h.service.UpdateItem(r.Context(), id, params)
 
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		slog.Error("Failed to update item", "error", err)
 		http.Error(w, "Failed to update item", http.StatusInternalServerError)
 		return
 	}
+	item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(item); err != nil {
+		slog.Error("Failed to encode item", "error", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+type ChangeStatusRequest struct {
+	Status    string     `json:"status" validate:"required"`
+	Reason    string     `json:"reason"`
+	SalePrice *int       `json:"salePrice" validate:"omitempty,min=0"`
+	Date      *time.Time `json:"date"` // Optional: when the change happened (default now)
+}
+
+// ChangeStatus handles POST /api/v1/gears/{id}/status
+func (h *GearHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/status")
+	var req ChangeStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		http.Error(w, "Validation failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	status, err := domain.ParseItemStatus(req.Status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.service.ChangeItemStatus(r.Context(), id, domain.ChangeItemStatusParams{
+		Status:    status,
+		Reason:    req.Reason,
+		SalePrice: req.SalePrice,
+		At:        req.Date,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to change item status", "error", err)
+		http.Error(w, "Failed to change item status", http.StatusInternalServerError)
+		return
+	}
+	item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(item); err != nil {
@@ -132,6 +548,133 @@ func (h *GearHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// FindBySerialNumber handles GET /api/v1/gears/by-serial?serial=SN123
+func (h *GearHandler) FindBySerialNumber(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.FindBySerialNumber(r.Context(), r.URL.Query().Get("serial"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to find items by serial number", "error", err)
+		http.Error(w, "Failed to find items", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range items {
+		items[i].ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		slog.Error("Failed to encode items", "error", err)
+	}
+}
+
+// FindByBarcode handles GET /api/v1/gears/by-barcode?barcode=4901234567894
+func (h *GearHandler) FindByBarcode(w http.ResponseWriter, r *http.Request) {
+	items, err := h.service.FindByBarcode(r.Context(), r.URL.Query().Get("barcode"))
+	if err != nil {
+		writeDomainError(w, err, "Failed to find items")
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range items {
+		items[i].ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		slog.Error("Failed to encode items", "error", err)
+	}
+}
+
+// ListExpiringWarranties handles GET /api/v1/gears/warranties?days=30
+func (h *GearHandler) ListExpiringWarranties(w http.ResponseWriter, r *http.Request) {
+	days := domain.DefaultWarrantyWindowDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "days must be a number", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	alerts, err := h.service.ListExpiringWarranties(r.Context(), days)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to list expiring warranties", "error", err)
+		http.Error(w, "Failed to list expiring warranties", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range alerts {
+		alerts[i].Item.ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(alerts); err != nil {
+		slog.Error("Failed to encode warranty alerts", "error", err)
+	}
+}
+
+// ListExpiringItems handles GET /api/v1/gears/expiring?days=30
+func (h *GearHandler) ListExpiringItems(w http.ResponseWriter, r *http.Request) {
+	days := domain.DefaultExpiryWindowDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "days must be a number", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	alerts, err := h.service.ListExpiringItems(r.Context(), days)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to list expiring items", "error", err)
+		http.Error(w, "Failed to list expiring items", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range alerts {
+		alerts[i].Item.ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(alerts); err != nil {
+		slog.Error("Failed to encode expiry alerts", "error", err)
+	}
+}
+
+// GetItemCost handles GET /api/v1/gears/{id}/cost
+func (h *GearHandler) GetItemCost(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/cost")
+	cost, err := h.service.GetItemCost(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get item cost", "error", err)
+		http.Error(w, "Failed to get item cost", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cost); err != nil {
+		slog.Error("Failed to encode item cost", "error", err)
+	}
+}
+
 func (h *GearHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/api/v1/gears/")
 	if err := h.service.DeleteItem(r.Context(), id); err != nil {
@@ -141,3 +684,287 @@ func (h *GearHandler) DeleteItem(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+type ImportItemsRequest struct {
+	Format      string               `json:"format" validate:"oneof=lighterpack csv"`
+	Data        string               `json:"data" validate:"required"` // Raw CSV text
+	Mapping     domain.ColumnMapping `json:"mapping"`                  // Required for format "csv"
+	DefaultUnit string               `json:"defaultUnit"`
+	Currency    string               `json:"currency"` // Currency of the price column when the row has none
+	DryRun      bool                 `json:"dryRun"`
+}
+
+func (h *GearHandler) ImportItems(w http.ResponseWriter, r *http.Request) {
+	var req ImportItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		http.Error(w, "Validation failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	unit, err := domain.ParseWeightUnit(req.DefaultUnit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	currency, err := domain.NormalizeCurrency(req.Currency)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := domain.ImportGearParams{
+		Format:          domain.ImportFormat(req.Format),
+		Data:            strings.NewReader(req.Data),
+		Mapping:         req.Mapping,
+		DefaultUnit:     unit,
+		DefaultCurrency: currency,
+		DryRun:          req.DryRun,
+	}
+
+	result, err := h.service.ImportItems(r.Context(), params)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to import items", "error", err)
+		http.Error(w, "Failed to import items", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(result.Errors) > 0 && !result.DryRun {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Error("Failed to encode import result", "error", err)
+	}
+}
+
+// FindDuplicates serves GET /api/v1/gears/duplicates?minScore=0.7
+func (h *GearHandler) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	minScore := domain.DefaultDuplicateMinScore
+	if raw := r.URL.Query().Get("minScore"); raw != "" {
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			http.Error(w, "minScore must be a number", http.StatusBadRequest)
+			return
+		}
+		minScore = n
+	}
+
+	pairs, err := h.service.FindDuplicates(r.Context(), minScore)
+	if err != nil {
+		writeDomainError(w, err, "Failed to find duplicates")
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range pairs {
+		pairs[i].Item.ApplyDisplayUnit(unit)
+		pairs[i].Duplicate.ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pairs); err != nil {
+		slog.Error("Failed to encode duplicates", "error", err)
+	}
+}
+
+type MergeItemsRequest struct {
+	DuplicateIDs []string `json:"duplicateIds" validate:"required,min=1"`
+}
+
+// MergeItems serves POST /api/v1/gears/{id}/merge; {id} is the item that survives.
+func (h *GearHandler) MergeItems(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/merge")
+	var req MergeItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		http.Error(w, "Validation failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	item, err := h.service.MergeItems(r.Context(), id, req.DuplicateIDs)
+	if err != nil {
+		writeDomainError(w, err, "Failed to merge items")
+		return
+	}
+	item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(item); err != nil {
+		slog.Error("Failed to encode item", "error", err)
+	}
+}
+
+type ComponentRequest struct {
+	ItemID   string `json:"itemId"`
+	Quantity int    `json:"quantity"` // Default 1
+}
+
+type SetComponentsRequest struct {
+	Components []ComponentRequest `json:"components"` // Replaces the whole list; empty makes the item a plain part
+}
+
+// HandleComponents serves GET and PUT /api/v1/gears/{id}/components with the
+// component tree and its rolled-up weight.
+func (h *GearHandler) HandleComponents(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/components")
+
+	var tree *domain.ComponentNode
+	var err error
+	if r.Method == http.MethodPut {
+		var req SetComponentsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		components := make([]domain.ItemComponent, 0, len(req.Components))
+		for _, c := range req.Components {
+			quantity := c.Quantity
+			if quantity == 0 {
+				quantity = 1
+			}
+			components = append(components, domain.ItemComponent{ComponentID: c.ItemID, Quantity: quantity})
+		}
+		tree, err = h.service.SetComponents(r.Context(), id, components)
+	} else {
+		tree, err = h.service.GetComponents(r.Context(), id)
+	}
+	if err != nil {
+		writeDomainError(w, err, "Failed to handle components")
+		return
+	}
+	tree.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tree); err != nil {
+		slog.Error("Failed to encode components", "error", err)
+	}
+}
+
+type MeterRequest struct {
+	Name string `json:"name"`
+	Unit string `json:"unit"` // days, km, hours, falls or cycles
+}
+
+type UsageEntryRequest struct {
+	Amount     float64 `json:"amount"`
+	RecordedAt string  `json:"recordedAt"` // RFC 3339 or YYYY-MM-DD, default now
+	Note       string  `json:"note"`
+}
+
+// HandleItemMeters serves GET and POST /api/v1/gears/{id}/meters.
+func (h *GearHandler) HandleItemMeters(w http.ResponseWriter, r *http.Request) {
+	itemID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/meters")
+
+	if r.Method == http.MethodGet {
+		meters, err := h.service.ListMeters(r.Context(), itemID)
+		if err != nil {
+			writeDomainError(w, err, "Failed to list meters")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(meters); err != nil {
+			slog.Error("Failed to encode meters", "error", err)
+		}
+		return
+	}
+
+	var req MeterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	meter, err := h.service.CreateMeter(r.Context(), itemID, req.Name, domain.MeterUnit(req.Unit))
+	if err != nil {
+		writeDomainError(w, err, "Failed to create meter")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(meter); err != nil {
+		slog.Error("Failed to encode meter", "error", err)
+	}
+}
+
+// HandleMeter serves PUT and DELETE /api/v1/meters/{id}.
+func (h *GearHandler) HandleMeter(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/meters/")
+
+	if r.Method == http.MethodDelete {
+		if err := h.service.DeleteMeter(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete meter")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var req MeterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	meter, err := h.service.UpdateMeter(r.Context(), id, req.Name, domain.MeterUnit(req.Unit))
+	if err != nil {
+		writeDomainError(w, err, "Failed to update meter")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(meter); err != nil {
+		slog.Error("Failed to encode meter", "error", err)
+	}
+}
+
+// HandleMeterUsage serves GET and POST /api/v1/meters/{id}/usage: the meter's entries
+// and manual increments.
+func (h *GearHandler) HandleMeterUsage(w http.ResponseWriter, r *http.Request) {
+	meterID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/meters/"), "/usage")
+
+	if r.Method == http.MethodGet {
+		entries, err := h.service.ListUsageEntries(r.Context(), meterID)
+		if err != nil {
+			writeDomainError(w, err, "Failed to list usage entries")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			slog.Error("Failed to encode usage entries", "error", err)
+		}
+		return
+	}
+
+	var req UsageEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	recordedAt, err := parseOptionalDate("recordedAt", req.RecordedAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var at time.Time
+	if recordedAt != nil {
+		at = *recordedAt
+	}
+	entry, err := h.service.RecordUsage(r.Context(), meterID, req.Amount, at, req.Note)
+	if err != nil {
+		writeDomainError(w, err, "Failed to record usage")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		slog.Error("Failed to encode usage entry", "error", err)
+	}
+}
@@ -72,3 +72,12 @@ func (h *KitHandler) GetKit(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(kit)
 }
+
+func (h *KitHandler) DeleteKit(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/kits/")
+	if err := h.service.DeleteKit(r.Context(), id); err != nil {
+		writeDomainError(w, err, "Failed to delete kit")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
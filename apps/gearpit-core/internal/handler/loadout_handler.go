@@ -39,10 +39,11 @@ func (h *LoadoutHandler) CreateLoadout(w http.ResponseWriter, r *http.Request) {
 
 	loadout, err := h.service.CreateLoadout(r.Context(), req.Name, req.ActivityType, req.KitIDs, req.ItemIDs, req.TargetWeightGram)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeDomainError(w, err, "Failed to create loadout")
 		return
 	}
 
+	loadout.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(toLoadoutResponse(loadout))
@@ -55,8 +56,10 @@ func (h *LoadoutHandler) ListLoadouts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unit := domain.DisplayUnitFromContext(r.Context())
 	responses := make([]LoadoutResponse, len(loadouts))
 	for i := range loadouts {
+		loadouts[i].ApplyDisplayUnit(unit)
 		responses[i] = toLoadoutResponse(&loadouts[i])
 	}
 
@@ -72,6 +75,7 @@ func (h *LoadoutHandler) GetLoadout(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal server error or Not found", http.StatusInternalServerError)
 		return
 	}
+	loadout.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(toLoadoutResponse(loadout))
@@ -95,9 +99,10 @@ func (h *LoadoutHandler) UpdateLoadout(w http.ResponseWriter, r *http.Request) {
 
 	loadout, err := h.service.UpdateLoadout(r.Context(), id, req.Name, req.ActivityType, req.KitIDs, req.ItemIDs, req.TargetWeightGram)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeDomainError(w, err, "Failed to update loadout")
 		return
 	}
+	loadout.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(toLoadoutResponse(loadout))
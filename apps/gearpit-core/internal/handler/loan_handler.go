@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type LoanHandler struct {
+	service domain.LoanService
+}
+
+func NewLoanHandler(s domain.LoanService) *LoanHandler {
+	return &LoanHandler{service: s}
+}
+
+type LoanRequest struct {
+	ItemID            string  `json:"itemId"`            // Ignored on update
+	BorrowerProfileID *string `json:"borrowerProfileId"` // Either a profile...
+	BorrowerContact   string  `json:"borrowerContact"`   // ...or a free-text contact
+	LoanedAt          string  `json:"loanedAt"`          // RFC 3339 or YYYY-MM-DD, default now
+	DueAt             string  `json:"dueAt"`             // RFC 3339 or YYYY-MM-DD; empty clears it on update
+	Note              string  `json:"note"`
+}
+
+type ReturnLoanRequest struct {
+	ReturnedAt string `json:"returnedAt"` // RFC 3339 or YYYY-MM-DD, default now
+}
+
+func (req LoanRequest) params() (domain.SaveLoanParams, error) {
+	loanedAt, err := parseOptionalDate("loanedAt", req.LoanedAt)
+	if err != nil {
+		return domain.SaveLoanParams{}, err
+	}
+	dueAt, err := parseOptionalDate("dueAt", req.DueAt)
+	if err != nil {
+		return domain.SaveLoanParams{}, err
+	}
+	return domain.SaveLoanParams{
+		ItemID:            req.ItemID,
+		BorrowerProfileID: req.BorrowerProfileID,
+		BorrowerContact:   req.BorrowerContact,
+		LoanedAt:          loanedAt,
+		DueAt:             dueAt,
+		Note:              req.Note,
+	}, nil
+}
+
+// ListLoans handles GET /api/v1/loans?itemId=&borrowerProfileId=&status=open|returned
+func (h *LoanHandler) ListLoans(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	status, err := domain.ParseLoanStatus(query.Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	loans, err := h.service.ListLoans(r.Context(), domain.LoanFilter{
+		ItemID:            query.Get("itemId"),
+		BorrowerProfileID: query.Get("borrowerProfileId"),
+		Status:            status,
+	})
+	if err != nil {
+		slog.Error("Failed to list loans", "error", err)
+		http.Error(w, "Failed to list loans", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range loans {
+		applyLoanDisplayUnit(&loans[i], unit)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(loans); err != nil {
+		slog.Error("Failed to encode loans", "error", err)
+	}
+}
+
+func (h *LoanHandler) CreateLoan(w http.ResponseWriter, r *http.Request) {
+	var req LoanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	params, err := req.params()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	loan, err := h.service.CreateLoan(r.Context(), params)
+	if err != nil {
+		writeDomainError(w, err, "Failed to create loan")
+		return
+	}
+	h.writeLoan(w, r, http.StatusCreated, loan)
+}
+
+// ListOverdue handles GET /api/v1/loans/overdue
+func (h *LoanHandler) ListOverdue(w http.ResponseWriter, r *http.Request) {
+	overdue, err := h.service.ListOverdue(r.Context())
+	if err != nil {
+		slog.Error("Failed to list overdue loans", "error", err)
+		http.Error(w, "Failed to list overdue loans", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range overdue {
+		applyLoanDisplayUnit(&overdue[i].Loan, unit)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(overdue); err != nil {
+		slog.Error("Failed to encode overdue loans", "error", err)
+	}
+}
+
+// HandleLoan serves GET/PUT/DELETE /api/v1/loans/{id} and POST /api/v1/loans/{id}/return.
+func (h *LoanHandler) HandleLoan(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/loans/")
+	returning := strings.HasSuffix(id, "/return")
+	id = strings.TrimSuffix(id, "/return")
+	if id == "" {
+		http.Error(w, "Loan ID is required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case returning && r.Method == http.MethodPost:
+		var req ReturnLoanRequest
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, "Invalid payload", http.StatusBadRequest)
+				return
+			}
+		}
+		returnedAt, err := parseOptionalDate("returnedAt", req.ReturnedAt)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		loan, err := h.service.ReturnLoan(r.Context(), id, returnedAt)
+		if err != nil {
+			writeDomainError(w, err, "Failed to return loan")
+			return
+		}
+		h.writeLoan(w, r, http.StatusOK, loan)
+
+	case returning:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+
+	case r.Method == http.MethodGet:
+		loan, err := h.service.GetLoan(r.Context(), id)
+		if err != nil {
+			writeDomainError(w, err, "Failed to get loan")
+			return
+		}
+		h.writeLoan(w, r, http.StatusOK, loan)
+
+	case r.Method == http.MethodPut:
+		var req LoanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		params, err := req.params()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		loan, err := h.service.UpdateLoan(r.Context(), id, params)
+		if err != nil {
+			writeDomainError(w, err, "Failed to update loan")
+			return
+		}
+		h.writeLoan(w, r, http.StatusOK, loan)
+
+	case r.Method == http.MethodDelete:
+		if err := h.service.DeleteLoan(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete loan")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *LoanHandler) writeLoan(w http.ResponseWriter, r *http.Request, status int, loan *domain.Loan) {
+	applyLoanDisplayUnit(loan, domain.DisplayUnitFromContext(r.Context()))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(loan); err != nil {
+		slog.Error("Failed to encode loan", "error", err)
+	}
+}
+
+func applyLoanDisplayUnit(loan *domain.Loan, unit domain.WeightUnit) {
+	if loan.Item != nil {
+		loan.Item.ApplyDisplayUnit(unit)
+	}
+}
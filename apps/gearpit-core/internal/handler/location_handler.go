@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type LocationHandler struct {
+	service domain.LocationService
+}
+
+func NewLocationHandler(s domain.LocationService) *LocationHandler {
+	return &LocationHandler{service: s}
+}
+
+type LocationRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	ParentID    *string `json:"parentId"` // null for a top-level place
+}
+
+type MoveItemRequest struct {
+	LocationID *string `json:"locationId"` // null takes the item out of any location
+	Note       string  `json:"note"`
+}
+
+func (req LocationRequest) params() domain.SaveLocationParams {
+	return domain.SaveLocationParams{Name: req.Name, Description: req.Description, ParentID: req.ParentID}
+}
+
+func (h *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.ListLocations(r.Context())
+	if err != nil {
+		slog.Error("Failed to list locations", "error", err)
+		http.Error(w, "Failed to list locations", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(locations); err != nil {
+		slog.Error("Failed to encode locations", "error", err)
+	}
+}
+
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	location, err := h.service.CreateLocation(r.Context(), req.params())
+	if err != nil {
+		writeDomainError(w, err, "Failed to create location")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(location); err != nil {
+		slog.Error("Failed to encode location", "error", err)
+	}
+}
+
+// HandleLocation serves GET/PUT/DELETE /api/v1/locations/{id}.
+// PUT replaces name, description and parent; a location with sub-locations or items cannot be deleted.
+func (h *LocationHandler) HandleLocation(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/locations/")
+	if id == "" {
+		http.Error(w, "Location ID is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		location, err := h.service.GetLocation(r.Context(), id)
+		if err != nil {
+			writeDomainError(w, err, "Failed to get location")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(location); err != nil {
+			slog.Error("Failed to encode location", "error", err)
+		}
+
+	case http.MethodPut:
+		var req LocationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		location, err := h.service.UpdateLocation(r.Context(), id, req.params())
+		if err != nil {
+			writeDomainError(w, err, "Failed to update location")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(location); err != nil {
+			slog.Error("Failed to encode location", "error", err)
+		}
+
+	case http.MethodDelete:
+		if err := h.service.DeleteLocation(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete location")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// WhereIs handles GET /api/v1/locations/where?q=stove
+func (h *LocationHandler) WhereIs(w http.ResponseWriter, r *http.Request) {
+	results, err := h.service.WhereIs(r.Context(), r.URL.Query().Get("q"))
+	if err != nil {
+		writeDomainError(w, err, "Failed to look up items")
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range results {
+		results[i].Item.ApplyDisplayUnit(unit)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		slog.Error("Failed to encode item whereabouts", "error", err)
+	}
+}
+
+// Locate handles GET /api/v1/gears/{id}/location
+func (h *LocationHandler) Locate(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/location")
+	result, err := h.service.Locate(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "Failed to locate item")
+		return
+	}
+	result.Item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Error("Failed to encode item whereabouts", "error", err)
+	}
+}
+
+// MoveItem handles POST /api/v1/gears/{id}/move
+func (h *LocationHandler) MoveItem(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/move")
+	var req MoveItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	move, err := h.service.MoveItem(r.Context(), id, domain.MoveItemParams{LocationID: req.LocationID, Note: req.Note})
+	if err != nil {
+		writeDomainError(w, err, "Failed to move item")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(move); err != nil {
+		slog.Error("Failed to encode item move", "error", err)
+	}
+}
+
+// ListMoves handles GET /api/v1/gears/{id}/moves
+func (h *LocationHandler) ListMoves(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/gears/"), "/moves")
+	moves, err := h.service.ListMoves(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err, "Failed to list item moves")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(moves); err != nil {
+		slog.Error("Failed to encode item moves", "error", err)
+	}
+}
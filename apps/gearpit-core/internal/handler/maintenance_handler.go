@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
@@ -70,7 +71,7 @@ func (h *MaintenanceHandler) UpdateLog(w http.ResponseWriter, r *http.Request) {
 
 	log, err := h.service.UpdateLog(r.Context(), id, req.Type, req.Description, req.Cost, date)
 	if err != nil {
-		http.Error(w, "Failed to update log", http.StatusInternalServerError)
+		writeDomainError(w, err, "Failed to update log")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -85,3 +86,203 @@ func (h *MaintenanceHandler) DeleteLog(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+type OdometerRequest struct {
+	Km     int    `json:"km"`
+	ReadAt string `json:"readAt"` // RFC 3339 or YYYY-MM-DD, default now
+	Note   string `json:"note"`
+}
+
+type ServiceRuleRequest struct {
+	ItemID           string   `json:"itemId"`   // itemId or category; both are ignored on update
+	Category         string   `json:"category"` // Applies to every item of the category
+	Name             string   `json:"name"`
+	LogType          string   `json:"logType"` // Maintenance log type that completes the service
+	IntervalKm       *int     `json:"intervalKm"`
+	MeterName        string   `json:"meterName"`     // Usage meter of the item, by name
+	IntervalUsage    *float64 `json:"intervalUsage"` // In the meter's unit
+	IntervalMonths   *int     `json:"intervalMonths"`
+	GraceUsage       *float64 `json:"graceUsage"` // In km or the meter's unit
+	GraceDays        *int     `json:"graceDays"`
+	LastServiceKm    *int     `json:"lastServiceKm"`    // Default: odometer at lastServiceAt
+	LastServiceUsage *float64 `json:"lastServiceUsage"` // Default: meter at lastServiceAt
+	LastServiceAt    string   `json:"lastServiceAt"`    // RFC 3339 or YYYY-MM-DD, default now
+}
+
+func (req ServiceRuleRequest) params() (domain.SaveServiceRuleParams, error) {
+	lastServiceAt, err := parseOptionalDate("lastServiceAt", req.LastServiceAt)
+	if err != nil {
+		return domain.SaveServiceRuleParams{}, err
+	}
+	return domain.SaveServiceRuleParams{
+		ItemID:           req.ItemID,
+		Category:         req.Category,
+		Name:             req.Name,
+		LogType:          req.LogType,
+		IntervalKm:       req.IntervalKm,
+		MeterName:        req.MeterName,
+		IntervalUsage:    req.IntervalUsage,
+		IntervalMonths:   req.IntervalMonths,
+		GraceUsage:       req.GraceUsage,
+		GraceDays:        req.GraceDays,
+		LastServiceKm:    req.LastServiceKm,
+		LastServiceUsage: req.LastServiceUsage,
+		LastServiceAt:    lastServiceAt,
+	}, nil
+}
+
+// HandleOdometer serves GET and POST /api/v1/maintenance/item/{itemId}/odometer
+func (h *MaintenanceHandler) HandleOdometer(w http.ResponseWriter, r *http.Request) {
+	itemID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/maintenance/item/"), "/odometer")
+
+	if r.Method == http.MethodGet {
+		readings, err := h.service.ListOdometerReadings(r.Context(), itemID)
+		if err != nil {
+			writeDomainError(w, err, "Failed to list odometer readings")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(readings); err != nil {
+			slog.Error("Failed to encode odometer readings", "error", err)
+		}
+		return
+	}
+
+	var req OdometerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	readAt, err := parseOptionalDate("readAt", req.ReadAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var at time.Time
+	if readAt != nil {
+		at = *readAt
+	}
+	reading, err := h.service.AddOdometerReading(r.Context(), itemID, req.Km, at, req.Note)
+	if err != nil {
+		writeDomainError(w, err, "Failed to add odometer reading")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(reading); err != nil {
+		slog.Error("Failed to encode odometer reading", "error", err)
+	}
+}
+
+// GetServiceStatus handles GET /api/v1/maintenance/item/{itemId}/status
+func (h *MaintenanceHandler) GetServiceStatus(w http.ResponseWriter, r *http.Request) {
+	itemID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/maintenance/item/"), "/status")
+	statuses, err := h.service.GetServiceStatus(r.Context(), itemID)
+	if err != nil {
+		writeDomainError(w, err, "Failed to get service status")
+		return
+	}
+	writeServiceStatuses(w, r, statuses)
+}
+
+// ListDueServices handles GET /api/v1/maintenance/due
+func (h *MaintenanceHandler) ListDueServices(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.service.ListDueServices(r.Context())
+	if err != nil {
+		slog.Error("Failed to list due services", "error", err)
+		http.Error(w, "Failed to list due services", http.StatusInternalServerError)
+		return
+	}
+	writeServiceStatuses(w, r, statuses)
+}
+
+// ListServiceRules handles GET /api/v1/maintenance/rules?category= with the category rules
+func (h *MaintenanceHandler) ListServiceRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListServiceRules(r.Context(), r.URL.Query().Get("category"))
+	if err != nil {
+		writeDomainError(w, err, "Failed to list service rules")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rules); err != nil {
+		slog.Error("Failed to encode service rules", "error", err)
+	}
+}
+
+func (h *MaintenanceHandler) CreateServiceRule(w http.ResponseWriter, r *http.Request) {
+	var req ServiceRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	params, err := req.params()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule, err := h.service.CreateServiceRule(r.Context(), params)
+	if err != nil {
+		writeDomainError(w, err, "Failed to create service rule")
+		return
+	}
+	writeServiceRule(w, r, http.StatusCreated, rule)
+}
+
+// HandleServiceRule serves PUT and DELETE /api/v1/maintenance/rules/{id}
+func (h *MaintenanceHandler) HandleServiceRule(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/maintenance/rules/")
+	if id == "" {
+		http.Error(w, "Rule ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := h.service.DeleteServiceRule(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete service rule")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var req ServiceRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	params, err := req.params()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule, err := h.service.UpdateServiceRule(r.Context(), id, params)
+	if err != nil {
+		writeDomainError(w, err, "Failed to update service rule")
+		return
+	}
+	writeServiceRule(w, r, http.StatusOK, rule)
+}
+
+func writeServiceRule(w http.ResponseWriter, r *http.Request, status int, rule *domain.ServiceRule) {
+	if rule.Item != nil {
+		rule.Item.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		slog.Error("Failed to encode service rule", "error", err)
+	}
+}
+
+func writeServiceStatuses(w http.ResponseWriter, r *http.Request, statuses []domain.ServiceStatus) {
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range statuses {
+		if statuses[i].Item != nil {
+			statuses[i].Item.ApplyDisplayUnit(unit)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		slog.Error("Failed to encode service status", "error", err)
+	}
+}
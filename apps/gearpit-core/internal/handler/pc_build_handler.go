@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type PCBuildHandler struct {
+	service domain.PCBuildService
+}
+
+func NewPCBuildHandler(s domain.PCBuildService) *PCBuildHandler {
+	return &PCBuildHandler{service: s}
+}
+
+type BuildPartRequest struct {
+	ItemID string `json:"itemId"`
+	Slot   string `json:"slot"` // cpu, motherboard, ram, gpu, psu, case
+}
+
+type PCBuildRequest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Parts       []BuildPartRequest `json:"parts"` // Replaces all parts on update
+}
+
+func (req PCBuildRequest) params() domain.SavePCBuildParams {
+	parts := make([]domain.BuildPart, 0, len(req.Parts))
+	for _, p := range req.Parts {
+		parts = append(parts, domain.BuildPart{ItemID: p.ItemID, Slot: domain.BuildSlot(p.Slot)})
+	}
+	return domain.SavePCBuildParams{Name: req.Name, Description: req.Description, Parts: parts}
+}
+
+// ListBuilds handles GET /api/v1/pc-builds
+func (h *PCBuildHandler) ListBuilds(w http.ResponseWriter, r *http.Request) {
+	builds, err := h.service.ListBuilds(r.Context())
+	if err != nil {
+		slog.Error("Failed to list builds", "error", err)
+		http.Error(w, "Failed to list builds", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range builds {
+		applyBuildDisplayUnit(&builds[i], unit)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(builds); err != nil {
+		slog.Error("Failed to encode builds", "error", err)
+	}
+}
+
+func (h *PCBuildHandler) CreateBuild(w http.ResponseWriter, r *http.Request) {
+	var req PCBuildRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	build, err := h.service.CreateBuild(r.Context(), req.params())
+	if err != nil {
+		writeDomainError(w, err, "Failed to create build")
+		return
+	}
+	h.writeBuild(w, r, http.StatusCreated, build)
+}
+
+// HandleBuild serves GET/PUT/DELETE /api/v1/pc-builds/{id} and GET /api/v1/pc-builds/{id}/report.
+func (h *PCBuildHandler) HandleBuild(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/pc-builds/")
+	reporting := strings.HasSuffix(id, "/report")
+	id = strings.TrimSuffix(id, "/report")
+	if id == "" {
+		http.Error(w, "Build ID is required", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case reporting && r.Method == http.MethodGet:
+		report, err := h.service.GetReport(r.Context(), id)
+		if err != nil {
+			writeDomainError(w, err, "Failed to check build")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			slog.Error("Failed to encode build report", "error", err)
+		}
+
+	case reporting:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+
+	case r.Method == http.MethodGet:
+		build, err := h.service.GetBuild(r.Context(), id)
+		if err != nil {
+			writeDomainError(w, err, "Failed to get build")
+			return
+		}
+		h.writeBuild(w, r, http.StatusOK, build)
+
+	case r.Method == http.MethodPut:
+		var req PCBuildRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		build, err := h.service.UpdateBuild(r.Context(), id, req.params())
+		if err != nil {
+			writeDomainError(w, err, "Failed to update build")
+			return
+		}
+		h.writeBuild(w, r, http.StatusOK, build)
+
+	case r.Method == http.MethodDelete:
+		if err := h.service.DeleteBuild(r.Context(), id); err != nil {
+			writeDomainError(w, err, "Failed to delete build")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PCBuildHandler) writeBuild(w http.ResponseWriter, r *http.Request, status int, build *domain.PCBuild) {
+	applyBuildDisplayUnit(build, domain.DisplayUnitFromContext(r.Context()))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(build); err != nil {
+		slog.Error("Failed to encode build", "error", err)
+	}
+}
+
+func applyBuildDisplayUnit(build *domain.PCBuild, unit domain.WeightUnit) {
+	for i := range build.Parts {
+		if build.Parts[i].Item != nil {
+			build.Parts[i].Item.ApplyDisplayUnit(unit)
+		}
+	}
+}
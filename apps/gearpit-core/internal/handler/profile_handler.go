@@ -17,11 +17,12 @@ func NewProfileHandler(s domain.ProfileService) *ProfileHandler {
 }
 
 type ProfileRequest struct {
-	Name     string  `json:"name"`
-	HeightCm float64 `json:"heightCm"`
-	WeightKg float64 `json:"weightKg"`
-	Age      int     `json:"age"`
-	Gender   string  `json:"gender"`
+	Name       string  `json:"name"`
+	HeightCm   float64 `json:"heightCm"`
+	WeightKg   float64 `json:"weightKg"`
+	Age        int     `json:"age"`
+	Gender     string  `json:"gender"`
+	UnitSystem string  `json:"unitSystem"` // "metric" (default) or "imperial"
 }
 
 func (h *ProfileHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
@@ -41,7 +42,13 @@ func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	profile, err := h.service.CreateProfile(r.Context(), req.Name, req.HeightCm, req.WeightKg, req.Age, req.Gender)
+	unitSystem, err := domain.ParseUnitSystem(req.UnitSystem)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.service.CreateProfile(r.Context(), req.Name, req.HeightCm, req.WeightKg, req.Age, req.Gender, unitSystem)
 	if err != nil {
 		http.Error(w, "Failed to create profile", http.StatusInternalServerError)
 		return
@@ -69,7 +76,17 @@ func (h *ProfileHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Invalid payload", http.StatusBadRequest)
 			return
 		}
-		profile, err := h.service.UpdateProfile(r.Context(), id, req.Name, req.HeightCm, req.WeightKg, req.Age, req.Gender)
+		// An omitted unitSystem keeps the stored preference
+		var unitSystem domain.UnitSystem
+		if req.UnitSystem != "" {
+			parsed, err := domain.ParseUnitSystem(req.UnitSystem)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			unitSystem = parsed
+		}
+		profile, err := h.service.UpdateProfile(r.Context(), id, req.Name, req.HeightCm, req.WeightKg, req.Age, req.Gender, unitSystem)
 		if err != nil {
 			http.Error(w, "Failed to update profile", http.StatusInternalServerError)
 			return
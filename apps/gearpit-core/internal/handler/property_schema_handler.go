@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type PropertySchemaHandler struct {
+	service  domain.PropertySchemaService
+	validate *validator.Validate
+}
+
+func NewPropertySchemaHandler(s domain.PropertySchemaService) *PropertySchemaHandler {
+	return &PropertySchemaHandler{
+		service:  s,
+		validate: validator.New(),
+	}
+}
+
+type PropertySchemaRequest struct {
+	Category    string                 `json:"category"` // Only used on create; updates take it from the path
+	Description string                 `json:"description"`
+	Fields      []domain.PropertyField `json:"fields" validate:"required"`
+}
+
+func (h *PropertySchemaHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas, err := h.service.ListSchemas(r.Context())
+	if err != nil {
+		slog.Error("Failed to list property schemas", "error", err)
+		http.Error(w, "Failed to list property schemas", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(schemas); err != nil {
+		slog.Error("Failed to encode property schemas", "error", err)
+	}
+}
+
+func (h *PropertySchemaHandler) CreateSchema(w http.ResponseWriter, r *http.Request) {
+	var req PropertySchemaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+	if err := h.validate.Struct(req); err != nil {
+		http.Error(w, "Validation failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schema, err := h.service.CreateSchema(r.Context(), req.Category, req.Description, req.Fields)
+	if err != nil {
+		writeDomainError(w, err, "Failed to create property schema")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(schema); err != nil {
+		slog.Error("Failed to encode property schema", "error", err)
+	}
+}
+
+// HandleSchema serves GET/PUT/DELETE /api/v1/property-schemas/{category}
+func (h *PropertySchemaHandler) HandleSchema(w http.ResponseWriter, r *http.Request) {
+	category := strings.TrimPrefix(r.URL.Path, "/api/v1/property-schemas/")
+	if category == "" {
+		http.Error(w, "Category is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		schema, err := h.service.GetSchema(r.Context(), category)
+		if err != nil {
+			writeDomainError(w, err, "Failed to get property schema")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(schema); err != nil {
+			slog.Error("Failed to encode property schema", "error", err)
+		}
+
+	case http.MethodPut:
+		var req PropertySchemaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		if err := h.validate.Struct(req); err != nil {
+			http.Error(w, "Validation failed: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		schema, err := h.service.UpdateSchema(r.Context(), category, req.Description, req.Fields)
+		if err != nil {
+			writeDomainError(w, err, "Failed to update property schema")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(schema); err != nil {
+			slog.Error("Failed to encode property schema", "error", err)
+		}
+
+	case http.MethodDelete:
+		if err := h.service.DeleteSchema(r.Context(), category); err != nil {
+			writeDomainError(w, err, "Failed to delete property schema")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
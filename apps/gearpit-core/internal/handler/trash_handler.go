@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type TrashHandler struct {
+	service domain.TrashService
+}
+
+func NewTrashHandler(s domain.TrashService) *TrashHandler {
+	return &TrashHandler{service: s}
+}
+
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.ListTrash(r.Context())
+	if err != nil {
+		slog.Error("Failed to list trash", "error", err)
+		http.Error(w, "Failed to list trash", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		slog.Error("Failed to encode trash", "error", err)
+	}
+}
+
+// HandleEntry serves POST /api/v1/trash/{entity}/{id}/restore and DELETE /api/v1/trash/{entity}/{id}
+func (h *TrashHandler) HandleEntry(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/trash/")
+	restore := strings.HasSuffix(path, "/restore")
+	path = strings.TrimSuffix(path, "/restore")
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[1] == "" {
+		http.Error(w, "Invalid path", http.StatusBadRequest)
+		return
+	}
+	entity, err := domain.ParseTrashEntity(parts[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case restore && r.Method == http.MethodPost:
+		err = h.service.Restore(r.Context(), entity, parts[1])
+	case !restore && r.Method == http.MethodDelete:
+		err = h.service.Purge(r.Context(), entity, parts[1])
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidInput):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("Failed to update trash", "entity", entity, "error", err)
+			http.Error(w, "Failed to update trash", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +41,11 @@ type TripItemUpsertRequest struct {
 	Quantity int    `json:"quantity"`
 }
 
+// TripItemsResponse carries warnings about added items; they never block the change.
+type TripItemsResponse struct {
+	Warnings []domain.TripWarning `json:"warnings"`
+}
+
 // parseDate handles both RFC3339 (frontend default) and YYYY-MM-DD formats
 func parseDate(dateStr string) (time.Time, error) {
 	if t, err := time.Parse(time.RFC3339, dateStr); err == nil {
@@ -101,6 +109,7 @@ func (h *TripHandler) GetTrip(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Trip not found", http.StatusNotFound)
 		return
 	}
+	trip.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(trip)
 }
@@ -134,6 +143,7 @@ func (h *TripHandler) UpdateTrip(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	trip.ApplyDisplayUnit(domain.DisplayUnitFromContext(r.Context()))
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(trip)
 }
@@ -164,11 +174,12 @@ func (h *TripHandler) HandleTripItems(w http.ResponseWriter, r *http.Request) {
 		if req.Quantity < 1 {
 			req.Quantity = 1
 		}
-		if err := h.service.AddOrUpdateItem(r.Context(), tripID, req.ItemID, req.Quantity); err != nil {
-			http.Error(w, "Failed to update item quantity", http.StatusInternalServerError)
+		warnings, err := h.service.AddOrUpdateItem(r.Context(), tripID, req.ItemID, req.Quantity)
+		if err != nil {
+			writeDomainError(w, err, "Failed to update item quantity")
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		writeTripItemsResponse(w, warnings)
 
 	case http.MethodPost:
 		// 一括追加 (Quantity=1)
@@ -177,11 +188,17 @@ func (h *TripHandler) HandleTripItems(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Invalid payload", http.StatusBadRequest)
 			return
 		}
+		var warnings []domain.TripWarning
 		for _, itemID := range req.ItemIDs {
 			// 一括追加時は個数1で登録
-			_ = h.service.AddOrUpdateItem(r.Context(), tripID, itemID, 1)
+			itemWarnings, err := h.service.AddOrUpdateItem(r.Context(), tripID, itemID, 1)
+			if err != nil {
+				writeDomainError(w, err, "Failed to add items")
+				return
+			}
+			warnings = append(warnings, itemWarnings...)
 		}
-		w.WriteHeader(http.StatusOK)
+		writeTripItemsResponse(w, warnings)
 
 	case http.MethodDelete:
 		// 一括削除
@@ -203,6 +220,48 @@ func (h *TripHandler) HandleTripItems(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func writeTripItemsResponse(w http.ResponseWriter, warnings []domain.TripWarning) {
+	if warnings == nil {
+		warnings = []domain.TripWarning{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(TripItemsResponse{Warnings: warnings}); err != nil {
+		slog.Error("Failed to encode trip item warnings", "error", err)
+	}
+}
+
+type TripMeterUsageRequest struct {
+	MeterID string  `json:"meterId"`
+	Amount  float64 `json:"amount"` // 0 removes the usage
+}
+
+// HandleTripMeters serves GET and PUT /api/v1/trips/{id}/meters: the usage the trip
+// adds to item meters when it is completed. Meters counting days default to the trip's days.
+func (h *TripHandler) HandleTripMeters(w http.ResponseWriter, r *http.Request) {
+	tripID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/trips/"), "/meters")
+
+	var usages []domain.TripMeterUsage
+	var err error
+	if r.Method == http.MethodPut {
+		var req TripMeterUsageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		usages, err = h.service.SetMeterUsage(r.Context(), tripID, req.MeterID, req.Amount)
+	} else {
+		usages, err = h.service.ListMeterUsages(r.Context(), tripID)
+	}
+	if err != nil {
+		writeDomainError(w, err, "Failed to handle trip meters")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(usages); err != nil {
+		slog.Error("Failed to encode trip meters", "error", err)
+	}
+}
+
 func (h *TripHandler) CompleteTrip(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/api/v1/trips/")
 	id = strings.TrimSuffix(id, "/complete")
@@ -213,3 +272,36 @@ func (h *TripHandler) CompleteTrip(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+// ShoppingList handles GET /api/v1/trips/shopping-list?days=30
+func (h *TripHandler) ShoppingList(w http.ResponseWriter, r *http.Request) {
+	days := domain.DefaultShoppingWindowDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			http.Error(w, "days must be a number", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	lines, err := h.service.ShoppingList(r.Context(), days)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to build shopping list", "error", err)
+		http.Error(w, "Failed to build shopping list", http.StatusInternalServerError)
+		return
+	}
+	unit := domain.DisplayUnitFromContext(r.Context())
+	for i := range lines {
+		lines[i].Item.ApplyDisplayUnit(unit)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(lines); err != nil {
+		slog.Error("Failed to encode shopping list", "error", err)
+	}
+}
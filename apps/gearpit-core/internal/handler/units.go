@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+// DisplayUnitMiddleware resolves the weight unit requested for a response and stores
+// it in the request context. "?unit=oz" wins over "?profileId=...", which uses the
+// profile's unit system; an unknown profile is ignored. Without either, responses keep
+// their gram fields only. Wrap only the routes that return weights.
+func DisplayUnitMiddleware(profiles domain.ProfileService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			var unit domain.WeightUnit
+
+			if raw := query.Get("unit"); raw != "" {
+				parsed, err := domain.ParseWeightUnit(raw)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				unit = parsed
+			} else if profileID := query.Get("profileId"); profileID != "" {
+				// The unit is a display preference, so a missing profile must not fail the request
+				if profile, err := profiles.GetProfile(r.Context(), profileID); err == nil {
+					unit = profile.UnitSystem.DisplayUnit()
+				}
+			}
+
+			if unit != "" {
+				r = r.WithContext(domain.WithDisplayUnit(r.Context(), unit))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type ValuationHandler struct {
+	service domain.ValuationService
+}
+
+func NewValuationHandler(s domain.ValuationService) *ValuationHandler {
+	return &ValuationHandler{service: s}
+}
+
+type DepreciationRuleRequest struct {
+	Method            string  `json:"method"` // none | straightLine | decliningBalance
+	LifeMonths        int     `json:"lifeMonths"`
+	AnnualRatePercent float64 `json:"annualRatePercent"`
+	SalvagePercent    float64 `json:"salvagePercent"`
+}
+
+// GetReport handles GET /api/v1/reports/valuation?groupBy=category|storage&format=csv|html|json
+// Without format the report is returned as JSON; with it the report is a file (html is printable and served inline).
+func (h *ValuationHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	groupBy, err := domain.ParseValuationGroupBy(r.URL.Query().Get("groupBy"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rawFormat := r.URL.Query().Get("format")
+	if rawFormat == "" {
+		report, err := h.service.GetReport(r.Context(), groupBy)
+		if err != nil {
+			slog.Error("Failed to build valuation report", "error", err)
+			http.Error(w, "Failed to build valuation report", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			slog.Error("Failed to encode valuation report", "error", err)
+		}
+		return
+	}
+
+	format, err := domain.ParseExportFormat(rawFormat)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	file, err := h.service.ExportReport(r.Context(), groupBy, format)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to export valuation report", "error", err)
+		http.Error(w, "Failed to export valuation report", http.StatusInternalServerError)
+		return
+	}
+
+	disposition := "attachment"
+	if format == domain.ExportFormatHTML {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", disposition+`; filename="`+file.FileName+`"`)
+	if _, err := w.Write(file.Data); err != nil {
+		slog.Error("Failed to write valuation report", "error", err)
+	}
+}
+
+func (h *ValuationHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListRules(r.Context())
+	if err != nil {
+		slog.Error("Failed to list depreciation rules", "error", err)
+		http.Error(w, "Failed to list depreciation rules", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rules); err != nil {
+		slog.Error("Failed to encode depreciation rules", "error", err)
+	}
+}
+
+// HandleRule serves GET/PUT/DELETE /api/v1/depreciation-rules/{category}. PUT creates or replaces.
+func (h *ValuationHandler) HandleRule(w http.ResponseWriter, r *http.Request) {
+	category := strings.TrimPrefix(r.URL.Path, "/api/v1/depreciation-rules/")
+	if category == "" {
+		http.Error(w, "Category is required", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		rule, err := h.service.GetRule(r.Context(), category)
+		if err != nil {
+			writeDomainError(w, err, "Failed to get depreciation rule")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rule); err != nil {
+			slog.Error("Failed to encode depreciation rule", "error", err)
+		}
+
+	case http.MethodPut:
+		var req DepreciationRuleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid payload", http.StatusBadRequest)
+			return
+		}
+		rule, err := h.service.SaveRule(r.Context(), domain.DepreciationRule{
+			Category:          category,
+			Method:            domain.DepreciationMethod(req.Method),
+			LifeMonths:        req.LifeMonths,
+			AnnualRatePercent: req.AnnualRatePercent,
+			SalvagePercent:    req.SalvagePercent,
+		})
+		if err != nil {
+			writeDomainError(w, err, "Failed to save depreciation rule")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rule); err != nil {
+			slog.Error("Failed to encode depreciation rule", "error", err)
+		}
+
+	case http.MethodDelete:
+		if err := h.service.DeleteRule(r.Context(), category); err != nil {
+			writeDomainError(w, err, "Failed to delete depreciation rule")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
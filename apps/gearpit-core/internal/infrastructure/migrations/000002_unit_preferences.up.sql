@@ -0,0 +1,2 @@
+-- Weight display preference per profile ("metric" or "imperial")
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS unit_system TEXT NOT NULL DEFAULT 'metric';
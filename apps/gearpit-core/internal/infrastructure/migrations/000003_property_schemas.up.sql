@@ -0,0 +1,28 @@
+-- Typed attribute definitions per item category (validated by the API, stored in items.properties)
+CREATE TABLE IF NOT EXISTS property_schemas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category TEXT NOT NULL,
+    description TEXT,
+    fields JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_property_schemas_category ON property_schemas (category);
+
+-- Built-in schemas
+INSERT INTO property_schemas (category, description, fields, created_at, updated_at) VALUES
+('Skis', 'Alpine and touring skis', '[
+    {"key": "lengthCm", "label": "Length", "type": "number", "unit": "cm", "required": true, "min": 50, "max": 230},
+    {"key": "waistWidthMm", "label": "Waist width", "type": "number", "unit": "mm", "required": true, "min": 50, "max": 150},
+    {"key": "radiusM", "label": "Turn radius", "type": "number", "unit": "m", "required": false, "min": 5, "max": 50}
+]', NOW(), NOW()),
+('Rope', 'Climbing ropes', '[
+    {"key": "diameterMm", "label": "Diameter", "type": "number", "unit": "mm", "required": true, "min": 5, "max": 13},
+    {"key": "lengthM", "label": "Length", "type": "number", "unit": "m", "required": true, "min": 1, "max": 200},
+    {"key": "dryTreated", "label": "Dry treated", "type": "boolean", "required": false}
+]', NOW(), NOW()),
+('PC GPU', 'Graphics cards', '[
+    {"key": "vramGb", "label": "VRAM", "type": "integer", "unit": "GB", "required": true, "min": 1},
+    {"key": "tdpW", "label": "TDP", "type": "integer", "unit": "W", "required": false, "min": 1}
+]', NOW(), NOW())
+ON CONFLICT (category) DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_items_weight_type;
+DROP INDEX IF EXISTS idx_items_usage_count_id;
+DROP INDEX IF EXISTS idx_items_weight_id;
+DROP INDEX IF EXISTS idx_items_name_id;
+DROP INDEX IF EXISTS idx_items_created_at_id;
@@ -0,0 +1,6 @@
+-- Keyset pagination indexes for GET /api/v1/gears (sort column, id)
+CREATE INDEX IF NOT EXISTS idx_items_created_at_id ON items ((COALESCE(created_at, 'epoch'::timestamptz)), id);
+CREATE INDEX IF NOT EXISTS idx_items_name_id ON items (name, id);
+CREATE INDEX IF NOT EXISTS idx_items_weight_id ON items ((COALESCE(weight_gram, 0)), id);
+CREATE INDEX IF NOT EXISTS idx_items_usage_count_id ON items ((COALESCE(usage_count, 0)), id);
+CREATE INDEX IF NOT EXISTS idx_items_weight_type ON items (weight_type);
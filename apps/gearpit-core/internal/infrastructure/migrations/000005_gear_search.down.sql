@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_items_search_text_trgm;
+ALTER TABLE items DROP COLUMN IF EXISTS search_text;
+DROP INDEX IF EXISTS idx_items_search_vector;
+ALTER TABLE items DROP COLUMN IF EXISTS search_vector;
+-- pg_trgm is left installed; other objects may depend on it
@@ -0,0 +1,23 @@
+-- Full-text and fuzzy search for items
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Weighted document: name (A), manufacturer and all string values in properties
+-- such as category, brand, tags and schema attributes (B), description (C)
+ALTER TABLE items ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(manufacturer, '')), 'B') ||
+    setweight(jsonb_to_tsvector('english', coalesce(properties, '{}'::jsonb), '["string"]'), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_items_search_vector ON items USING GIN (search_vector);
+
+-- Short text for typo-tolerant matching ("sawer squeze" -> "Sawyer Squeeze")
+ALTER TABLE items ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+    lower(
+        coalesce(name, '') || ' ' ||
+        coalesce(manufacturer, '') || ' ' ||
+        coalesce(properties ->> 'brand', '') || ' ' ||
+        coalesce(properties ->> 'category', '')
+    )
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_items_search_text_trgm ON items USING GIN (search_text gin_trgm_ops);
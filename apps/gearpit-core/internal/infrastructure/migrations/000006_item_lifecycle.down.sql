@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_items_status;
+ALTER TABLE items DROP COLUMN IF EXISTS sale_price;
+ALTER TABLE items DROP COLUMN IF EXISTS retired_at;
+ALTER TABLE items DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE items DROP COLUMN IF EXISTS status_reason;
+ALTER TABLE items DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+-- Item lifecycle: active, retired, lost, sold, broken
+ALTER TABLE items ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE items ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS retired_at TIMESTAMPTZ;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS sale_price INT;
+CREATE INDEX IF NOT EXISTS idx_items_status ON items (status);
@@ -0,0 +1,14 @@
+-- Trashed rows would become visible again; remove them first
+DELETE FROM maintenance_logs WHERE deleted_at IS NOT NULL;
+DELETE FROM trips WHERE deleted_at IS NOT NULL;
+DELETE FROM loadouts WHERE deleted_at IS NOT NULL;
+DELETE FROM kits WHERE deleted_at IS NOT NULL;
+DELETE FROM items WHERE deleted_at IS NOT NULL;
+DELETE FROM user_profiles WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE trips DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE maintenance_logs DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE loadouts DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE kits DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE items DROP COLUMN IF EXISTS deleted_at;
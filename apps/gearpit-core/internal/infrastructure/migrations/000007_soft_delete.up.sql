@@ -0,0 +1,14 @@
+-- Soft delete (trash) for all user-facing entities
+ALTER TABLE items ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE kits ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE loadouts ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE maintenance_logs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_kits_deleted_at ON kits (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_loadouts_deleted_at ON loadouts (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_maintenance_logs_deleted_at ON maintenance_logs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_user_profiles_deleted_at ON user_profiles (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_trips_deleted_at ON trips (deleted_at);
@@ -0,0 +1,4 @@
+ALTER TABLE items DROP COLUMN IF EXISTS vendor;
+ALTER TABLE items DROP COLUMN IF EXISTS currency;
+ALTER TABLE items DROP COLUMN IF EXISTS purchase_date;
+ALTER TABLE items DROP COLUMN IF EXISTS purchase_price;
@@ -0,0 +1,5 @@
+-- Purchase info; prices are integers in the currency's minor unit
+ALTER TABLE items ADD COLUMN IF NOT EXISTS purchase_price INT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS purchase_date TIMESTAMPTZ;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS currency TEXT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS vendor TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS depreciation_rules;
+ALTER TABLE items DROP COLUMN IF EXISTS storage;
@@ -0,0 +1,15 @@
+-- Free-text storage place used to group the valuation report
+ALTER TABLE items ADD COLUMN IF NOT EXISTS storage TEXT;
+
+-- How items of a category lose value (straightLine, decliningBalance or none)
+CREATE TABLE IF NOT EXISTS depreciation_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category TEXT NOT NULL,
+    method TEXT NOT NULL,
+    life_months INT,
+    annual_rate_percent FLOAT8,
+    salvage_percent FLOAT8,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_depreciation_rules_category ON depreciation_rules (category);
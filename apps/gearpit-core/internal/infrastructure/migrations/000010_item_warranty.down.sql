@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_items_warranty_expires_at;
+DROP INDEX IF EXISTS idx_items_serial_number;
+ALTER TABLE items DROP COLUMN IF EXISTS warranty_expires_at;
+ALTER TABLE items DROP COLUMN IF EXISTS serial_number;
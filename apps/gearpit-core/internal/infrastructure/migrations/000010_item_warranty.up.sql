@@ -0,0 +1,5 @@
+-- Serial numbers are stored trimmed and upper-cased so lookups can use the index
+ALTER TABLE items ADD COLUMN IF NOT EXISTS serial_number TEXT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS warranty_expires_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_items_serial_number ON items (serial_number);
+CREATE INDEX IF NOT EXISTS idx_items_warranty_expires_at ON items (warranty_expires_at) WHERE warranty_expires_at IS NOT NULL;
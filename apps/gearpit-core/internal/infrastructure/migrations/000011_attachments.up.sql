@@ -0,0 +1,13 @@
+-- Files attached to items and maintenance logs. Content lives in blob storage
+-- under attachments/{id}; rows are removed when their owner is purged from the trash.
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_type TEXT NOT NULL,
+    owner_id UUID NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT,
+    has_thumbnail BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_owner ON attachments (owner_type, owner_id);
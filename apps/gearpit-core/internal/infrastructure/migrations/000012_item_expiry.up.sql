@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS lot_number TEXT;
+CREATE INDEX IF NOT EXISTS idx_items_expires_at ON items (expires_at) WHERE expires_at IS NOT NULL;
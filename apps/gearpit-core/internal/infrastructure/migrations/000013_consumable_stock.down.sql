@@ -0,0 +1,2 @@
+ALTER TABLE items DROP COLUMN IF EXISTS reorder_threshold;
+ALTER TABLE items DROP COLUMN IF EXISTS stock_quantity;
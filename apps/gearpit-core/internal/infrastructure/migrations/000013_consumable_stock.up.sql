@@ -0,0 +1,3 @@
+-- NULL stock_quantity means stock is not tracked for the item
+ALTER TABLE items ADD COLUMN IF NOT EXISTS stock_quantity INT;
+ALTER TABLE items ADD COLUMN IF NOT EXISTS reorder_threshold INT NOT NULL DEFAULT 0;
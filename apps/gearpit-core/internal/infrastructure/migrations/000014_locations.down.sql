@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS item_moves;
+DROP INDEX IF EXISTS idx_items_location_id;
+ALTER TABLE items DROP COLUMN IF EXISTS location_id;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    description TEXT,
+    parent_id UUID REFERENCES locations(id),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_locations_parent_id ON locations (parent_id);
+
+ALTER TABLE items ADD COLUMN IF NOT EXISTS location_id UUID REFERENCES locations(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_items_location_id ON items (location_id);
+
+-- Paths are stored as text so the history survives renamed or deleted locations
+CREATE TABLE IF NOT EXISTS item_moves (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    from_location_id UUID,
+    to_location_id UUID,
+    from_path TEXT,
+    to_path TEXT,
+    note TEXT,
+    moved_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_item_moves_item_id ON item_moves (item_id);
+
+-- Turn the free-text storage of existing items into top-level locations
+INSERT INTO locations (name, created_at, updated_at)
+SELECT DISTINCT TRIM(storage), NOW(), NOW() FROM items
+WHERE TRIM(COALESCE(storage, '')) <> '';
+
+UPDATE items SET location_id = locations.id
+FROM locations
+WHERE locations.parent_id IS NULL AND locations.name = TRIM(items.storage) AND items.location_id IS NULL;
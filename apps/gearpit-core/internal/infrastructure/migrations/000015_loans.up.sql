@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS loans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    borrower_profile_id UUID REFERENCES user_profiles(id) ON DELETE SET NULL,
+    borrower_contact TEXT,
+    loaned_at TIMESTAMPTZ NOT NULL,
+    due_at TIMESTAMPTZ,
+    returned_at TIMESTAMPTZ,
+    note TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_loans_item_id ON loans (item_id);
+CREATE INDEX IF NOT EXISTS idx_loans_borrower_profile_id ON loans (borrower_profile_id);
+CREATE INDEX IF NOT EXISTS idx_loans_returned_at ON loans (returned_at);
+-- Overdue listing only looks at open loans
+CREATE INDEX IF NOT EXISTS idx_loans_open_due_at ON loans (due_at) WHERE returned_at IS NULL;
@@ -0,0 +1,15 @@
+-- entity_id points into items, kits, loadouts, trips or maintenance_logs depending on
+-- entity_type, so there is no foreign key; history outlives purged rows.
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    field TEXT NOT NULL,
+    old_value JSONB,
+    new_value JSONB,
+    actor TEXT NOT NULL,
+    revert_of_id UUID,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_changed_at ON audit_entries (changed_at);
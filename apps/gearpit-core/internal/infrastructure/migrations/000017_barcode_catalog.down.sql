@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS catalog_products;
+DROP INDEX IF EXISTS idx_items_barcode;
+ALTER TABLE items DROP COLUMN IF EXISTS barcode;
@@ -0,0 +1,17 @@
+ALTER TABLE items ADD COLUMN IF NOT EXISTS barcode TEXT;
+CREATE INDEX IF NOT EXISTS idx_items_barcode ON items (barcode);
+
+-- Products known by barcode, imported from retailer or community dumps.
+CREATE TABLE IF NOT EXISTS catalog_products (
+    barcode TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    manufacturer TEXT,
+    brand TEXT,
+    category TEXT,
+    description TEXT,
+    weight_gram BIGINT,
+    price BIGINT,
+    currency TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
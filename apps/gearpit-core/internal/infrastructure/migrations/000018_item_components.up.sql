@@ -0,0 +1,11 @@
+-- Bill of materials: an assembly (parent) is made of Quantity of each component item.
+CREATE TABLE IF NOT EXISTS item_components (
+    parent_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    component_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (parent_id, component_id),
+    CHECK (parent_id <> component_id)
+);
+-- Finds the assemblies an item belongs to
+CREATE INDEX IF NOT EXISTS idx_item_components_component_id ON item_components (component_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pc_build_parts;
+DROP TABLE IF EXISTS pc_builds;
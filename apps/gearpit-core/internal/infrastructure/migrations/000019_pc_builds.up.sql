@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS pc_builds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+-- Slot is one of cpu, motherboard, ram, gpu, psu, case; the service enforces how many each takes.
+CREATE TABLE IF NOT EXISTS pc_build_parts (
+    build_id UUID NOT NULL REFERENCES pc_builds(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    slot TEXT NOT NULL,
+    PRIMARY KEY (build_id, item_id)
+);
+CREATE INDEX IF NOT EXISTS idx_pc_build_parts_item_id ON pc_build_parts (item_id);
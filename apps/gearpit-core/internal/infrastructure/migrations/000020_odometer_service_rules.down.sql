@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS service_rules;
+DROP TABLE IF EXISTS odometer_readings;
+ALTER TABLE maintenance_logs DROP COLUMN IF EXISTS odometer_km;
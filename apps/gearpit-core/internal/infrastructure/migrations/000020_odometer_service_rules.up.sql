@@ -0,0 +1,27 @@
+ALTER TABLE maintenance_logs ADD COLUMN IF NOT EXISTS odometer_km INT;
+
+CREATE TABLE IF NOT EXISTS odometer_readings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    km INT NOT NULL CHECK (km >= 0),
+    read_at TIMESTAMPTZ NOT NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_odometer_readings_item_id ON odometer_readings (item_id, read_at);
+
+-- Maintenance of log_type every interval_km and/or interval_months, whichever comes first
+CREATE TABLE IF NOT EXISTS service_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    log_type TEXT NOT NULL,
+    interval_km INT CHECK (interval_km > 0),
+    interval_months INT CHECK (interval_months > 0),
+    last_service_km INT,
+    last_service_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CHECK (interval_km IS NOT NULL OR interval_months IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_service_rules_item_id ON service_rules (item_id);
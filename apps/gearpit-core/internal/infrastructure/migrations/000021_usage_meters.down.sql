@@ -0,0 +1,5 @@
+ALTER TABLE maintenance_logs DROP COLUMN IF EXISTS meter_total;
+ALTER TABLE items DROP COLUMN IF EXISTS maintenance_meter_id;
+DROP TABLE IF EXISTS trip_meter_usages;
+DROP TABLE IF EXISTS usage_entries;
+DROP TABLE IF EXISTS usage_meters;
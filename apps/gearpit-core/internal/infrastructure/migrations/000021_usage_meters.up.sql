@@ -0,0 +1,36 @@
+-- Named usage meters per item, e.g. rope falls or stove burn hours
+CREATE TABLE IF NOT EXISTS usage_meters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    item_id UUID NOT NULL REFERENCES items(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    unit TEXT NOT NULL CHECK (unit IN ('days', 'km', 'hours', 'falls', 'cycles')),
+    total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    serviced_at_total DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_usage_meters_item_name ON usage_meters (item_id, name);
+
+CREATE TABLE IF NOT EXISTS usage_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    meter_id UUID NOT NULL REFERENCES usage_meters(id) ON DELETE CASCADE,
+    trip_id UUID REFERENCES trips(id) ON DELETE SET NULL,
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    note TEXT,
+    recorded_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_usage_entries_meter_id ON usage_entries (meter_id, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_usage_entries_trip_id ON usage_entries (trip_id);
+
+-- Usage a trip adds to meters once it is completed
+CREATE TABLE IF NOT EXISTS trip_meter_usages (
+    trip_id UUID NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    meter_id UUID NOT NULL REFERENCES usage_meters(id) ON DELETE CASCADE,
+    amount DOUBLE PRECISION NOT NULL CHECK (amount > 0),
+    PRIMARY KEY (trip_id, meter_id)
+);
+
+-- The meter maintenance_interval counts on; NULL counts usage_count
+ALTER TABLE items ADD COLUMN IF NOT EXISTS maintenance_meter_id UUID;
+ALTER TABLE maintenance_logs ADD COLUMN IF NOT EXISTS meter_total DOUBLE PRECISION;
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_service_rules_category;
+ALTER TABLE service_rules DROP CONSTRAINT IF EXISTS service_rules_scope_check;
+ALTER TABLE service_rules DROP CONSTRAINT IF EXISTS service_rules_interval_check;
+
+-- Rules that only the new columns can express are dropped
+DELETE FROM service_rules WHERE item_id IS NULL OR (interval_km IS NULL AND interval_months IS NULL);
+ALTER TABLE service_rules ADD CONSTRAINT service_rules_check CHECK (interval_km IS NOT NULL OR interval_months IS NOT NULL);
+
+ALTER TABLE service_rules DROP COLUMN IF EXISTS last_service_usage;
+ALTER TABLE service_rules DROP COLUMN IF EXISTS grace_days;
+ALTER TABLE service_rules DROP COLUMN IF EXISTS grace_usage;
+ALTER TABLE service_rules DROP COLUMN IF EXISTS interval_usage;
+ALTER TABLE service_rules DROP COLUMN IF EXISTS meter_name;
+ALTER TABLE service_rules DROP COLUMN IF EXISTS category;
+ALTER TABLE service_rules ALTER COLUMN item_id SET NOT NULL;
@@ -0,0 +1,16 @@
+-- Service rules for a whole category, on usage meters, with a grace period
+ALTER TABLE service_rules ALTER COLUMN item_id DROP NOT NULL;
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS category TEXT;
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS meter_name TEXT;
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS interval_usage DOUBLE PRECISION CHECK (interval_usage > 0);
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS grace_usage DOUBLE PRECISION CHECK (grace_usage >= 0);
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS grace_days INT CHECK (grace_days >= 0);
+ALTER TABLE service_rules ADD COLUMN IF NOT EXISTS last_service_usage DOUBLE PRECISION;
+
+ALTER TABLE service_rules DROP CONSTRAINT IF EXISTS service_rules_check;
+ALTER TABLE service_rules ADD CONSTRAINT service_rules_interval_check
+    CHECK (interval_km IS NOT NULL OR interval_usage IS NOT NULL OR interval_months IS NOT NULL);
+-- Exactly one scope: an item or a category
+ALTER TABLE service_rules ADD CONSTRAINT service_rules_scope_check
+    CHECK ((item_id IS NOT NULL) <> (COALESCE(category, '') <> ''));
+CREATE INDEX IF NOT EXISTS idx_service_rules_category ON service_rules (category) WHERE item_id IS NULL;
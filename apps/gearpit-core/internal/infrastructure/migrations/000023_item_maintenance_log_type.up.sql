@@ -0,0 +1,3 @@
+-- Log type that restarts an item's maintenance_interval; empty keeps restarting it on
+-- every log that matches no service rule
+ALTER TABLE items ADD COLUMN IF NOT EXISTS maintenance_log_type TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_loans_open_item_id;
@@ -0,0 +1,10 @@
+-- Close all but the latest open loan of each item so the index below can be built
+UPDATE loans SET returned_at = newer.loaned_at
+FROM (
+    SELECT id, LEAD(loaned_at) OVER (PARTITION BY item_id ORDER BY loaned_at, id) AS loaned_at
+    FROM loans WHERE returned_at IS NULL
+) newer
+WHERE loans.id = newer.id AND newer.loaned_at IS NOT NULL;
+
+-- An item can only be on one open loan at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loans_open_item_id ON loans (item_id) WHERE returned_at IS NULL;
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// attachmentOwnerTables maps owners to their table. Table names never come from input.
+var attachmentOwnerTables = map[domain.AttachmentOwner]string{
+	domain.AttachmentOwnerItem:           "items",
+	domain.AttachmentOwnerMaintenanceLog: "maintenance_logs",
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	if err := r.db.WithContext(ctx).Create(attachment).Error; err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *attachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := r.db.WithContext(ctx).First(&attachment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: attachment %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) ListByOwner(ctx context.Context, owner domain.AttachmentOwner, ownerID string) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	if err := r.db.WithContext(ctx).
+		Where("owner_type = ? AND owner_id = ?", owner, ownerID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id string) error {
+	if err := r.db.WithContext(ctx).Delete(&domain.Attachment{}, "id = ?", id).Error; err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *attachmentRepository) OwnerExists(ctx context.Context, owner domain.AttachmentOwner, ownerID string) (bool, error) {
+	table, ok := attachmentOwnerTables[owner]
+	if !ok {
+		return false, fmt.Errorf("%w: unknown attachment owner %q", domain.ErrInvalidInput, owner)
+	}
+	var count int64
+	if err := r.db.WithContext(ctx).Table(table).
+		Where("id = ? AND deleted_at IS NULL", ownerID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check attachment owner: %w", err)
+	}
+	return count > 0, nil
+}
+
+// DeleteOrphans checks owners without the soft-delete filter: only purged owners orphan their files.
+func (r *attachmentRepository) DeleteOrphans(ctx context.Context) ([]domain.Attachment, error) {
+	var orphans []domain.Attachment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for owner, table := range attachmentOwnerTables {
+			var rows []domain.Attachment
+			err := tx.Raw(`DELETE FROM attachments a
+				WHERE a.owner_type = ? AND NOT EXISTS (SELECT 1 FROM `+table+` o WHERE o.id = a.owner_id)
+				RETURNING a.*`, owner).Scan(&rows).Error
+			if err != nil {
+				return fmt.Errorf("failed to delete orphaned %s attachments: %w", owner, err)
+			}
+			orphans = append(orphans, rows...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) domain.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) List(ctx context.Context, entity domain.AuditEntity, entityID string) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+	if err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ?", entity, entityID).
+		Order("changed_at DESC, field").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *auditRepository) GetByID(ctx context.Context, id string) (*domain.AuditEntry, error) {
+	var entry domain.AuditEntry
+	if err := r.db.WithContext(ctx).First(&entry, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: history entry %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get history entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// auditedUpdate runs update in a transaction and records every field that differs
+// between the rows read by load before and after it. Reading both sides back from
+// the database keeps time zones and JSON formatting from showing up as changes.
+func auditedUpdate(ctx context.Context, db *gorm.DB, entity domain.AuditEntity, id string,
+	load func(tx *gorm.DB) (domain.AuditFields, error), update func(tx *gorm.DB) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := load(tx)
+		if err != nil {
+			return err
+		}
+		if err := update(tx); err != nil {
+			return err
+		}
+		after, err := load(tx)
+		if err != nil {
+			return err
+		}
+
+		entries := domain.DiffAudit(before, after)
+		if len(entries) == 0 {
+			return nil
+		}
+		actor := domain.ActorFromContext(ctx)
+		revertOf := domain.RevertOfFromContext(ctx)
+		now := time.Now()
+		for i := range entries {
+			entries[i].EntityType = entity
+			entries[i].EntityID = id
+			entries[i].Actor = actor
+			entries[i].RevertOfID = revertOf
+			entries[i].ChangedAt = now
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			return fmt.Errorf("failed to record %s history: %w", entity, err)
+		}
+		return nil
+	})
+}
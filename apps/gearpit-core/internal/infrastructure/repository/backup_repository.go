@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const restoreBatchSize = 200
+
+type backupRepository struct {
+	db *gorm.DB
+}
+
+func NewBackupRepository(db *gorm.DB) domain.BackupRepository {
+	return &backupRepository{db: db}
+}
+
+func (r *backupRepository) SchemaVersion(ctx context.Context) (uint, error) {
+	var version uint
+	// schema_migrations is maintained by golang-migrate (see infrastructure.InitDB)
+	if err := r.db.WithContext(ctx).Raw("SELECT version FROM schema_migrations LIMIT 1").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+func (r *backupRepository) Dump(ctx context.Context) (*domain.BackupArchive, error) {
+	archive := &domain.BackupArchive{}
+
+	// Read everything from one snapshot so join tables match their parents
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		dumps := []struct {
+			table string
+			dest  interface{}
+		}{
+			{"user_profiles", &archive.UserProfiles},
+			{"property_schemas", &archive.PropertySchemas},
+			{"depreciation_rules", &archive.DepreciationRules},
+			{"locations", &archive.Locations},
+			{"items", &archive.Items},
+			{"kits", &archive.Kits},
+			{"loadouts", &archive.Loadouts},
+			{"maintenance_logs", &archive.MaintenanceLogs},
+			{"trips", &archive.Trips},
+			{"trip_items", &archive.TripItems},
+			{"kit_items", &archive.KitItems},
+			{"loadout_kits", &archive.LoadoutKits},
+			{"loadout_items", &archive.LoadoutItems},
+			{"attachments", &archive.Attachments},
+			{"item_moves", &archive.ItemMoves},
+			{"loans", &archive.Loans},
+			{"audit_entries", &archive.AuditEntries},
+			{"catalog_products", &archive.CatalogProducts},
+			{"item_components", &archive.ItemComponents},
+			{"pc_builds", &archive.PCBuilds},
+			{"pc_build_parts", &archive.PCBuildParts},
+			{"odometer_readings", &archive.OdometerReadings},
+			{"service_rules", &archive.ServiceRules},
+			{"usage_meters", &archive.UsageMeters},
+			{"usage_entries", &archive.UsageEntries},
+			{"trip_meter_usages", &archive.TripMeterUsages},
+		}
+		for _, d := range dumps {
+			// Unscoped: trashed rows are part of the backup
+			if err := tx.Unscoped().Find(d.dest).Error; err != nil {
+				return fmt.Errorf("failed to dump %s: %w", d.table, err)
+			}
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (r *backupRepository) Restore(ctx context.Context, archive *domain.BackupArchive, policy domain.ConflictPolicy) (*domain.RestoreResult, error) {
+	result := &domain.RestoreResult{Policy: policy}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state := &restoreState{tx: tx, policy: policy}
+
+		// Parents first so foreign keys resolve
+		restoreRows(state, "user_profiles", archive.UserProfiles)
+		// Seeded definitions exist under other IDs, so the policy applies per category
+		restoreRows(state, "property_schemas", archive.PropertySchemas, "category")
+		restoreRows(state, "depreciation_rules", archive.DepreciationRules, "category")
+		restoreRows(state, "locations", archive.Locations)
+		restoreRows(state, "items", archive.Items)
+		restoreRows(state, "kits", archive.Kits)
+		restoreRows(state, "loadouts", archive.Loadouts)
+		restoreRows(state, "maintenance_logs", archive.MaintenanceLogs)
+		restoreRows(state, "trips", archive.Trips)
+		restoreRows(state, "trip_items", archive.TripItems)
+		restoreRows(state, "kit_items", archive.KitItems)
+		restoreRows(state, "loadout_kits", archive.LoadoutKits)
+		restoreRows(state, "loadout_items", archive.LoadoutItems)
+		restoreRows(state, "attachments", archive.Attachments)
+		restoreRows(state, "item_moves", archive.ItemMoves)
+		restoreRows(state, "loans", archive.Loans)
+		restoreRows(state, "audit_entries", archive.AuditEntries)
+		restoreRows(state, "catalog_products", archive.CatalogProducts, "barcode")
+		restoreRows(state, "item_components", archive.ItemComponents)
+		restoreRows(state, "pc_builds", archive.PCBuilds)
+		restoreRows(state, "pc_build_parts", archive.PCBuildParts)
+		restoreRows(state, "odometer_readings", archive.OdometerReadings)
+		restoreRows(state, "service_rules", archive.ServiceRules)
+		restoreRows(state, "usage_meters", archive.UsageMeters)
+		restoreRows(state, "usage_entries", archive.UsageEntries)
+		restoreRows(state, "trip_meter_usages", archive.TripMeterUsages)
+
+		result.Tables = state.tables
+		return state.err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// restoreState carries the transaction through restoreRows and stops at the first error.
+type restoreState struct {
+	tx     *gorm.DB
+	policy domain.ConflictPolicy
+	tables []domain.RestoreTableResult
+	err    error
+}
+
+// restoreRows inserts rows with their archived primary keys.
+// Associations are skipped; join tables are restored as their own step.
+// conflictColumns overrides the primary key as the overwrite target.
+func restoreRows[T any](state *restoreState, table string, rows []T, conflictColumns ...string) {
+	if state.err != nil {
+		return
+	}
+	tableResult := domain.RestoreTableResult{Table: table, Archived: len(rows)}
+	if len(rows) == 0 {
+		state.tables = append(state.tables, tableResult)
+		return
+	}
+
+	query := state.tx.Omit(clause.Associations)
+	switch state.policy {
+	case domain.ConflictSkip:
+		query = query.Clauses(clause.OnConflict{DoNothing: true})
+	case domain.ConflictOverwrite:
+		onConflict := clause.OnConflict{UpdateAll: true}
+		for _, column := range conflictColumns {
+			onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+		}
+		query = query.Clauses(onConflict)
+	}
+
+	res := query.CreateInBatches(rows, restoreBatchSize)
+	if res.Error != nil {
+		if isDuplicateKey(state.tx, res.Error) {
+			state.err = fmt.Errorf("%w: %s already contains archived rows", domain.ErrConflict, table)
+			return
+		}
+		state.err = fmt.Errorf("failed to restore %s: %w", table, res.Error)
+		return
+	}
+	tableResult.Restored = res.RowsAffected
+	state.tables = append(state.tables, tableResult)
+}
+
+// isDuplicateKey reports whether err is a unique constraint violation.
+func isDuplicateKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
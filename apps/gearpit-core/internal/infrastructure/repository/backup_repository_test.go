@@ -0,0 +1,64 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRepository_RestoreIntoSeededDB(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.PropertySchema{}, &domain.DepreciationRule{}))
+	repo := repository.NewBackupRepository(db)
+	ctx := context.Background()
+
+	tests := []struct {
+		policy      domain.ConflictPolicy
+		wantErr     error
+		description string
+		method      domain.DepreciationMethod
+	}{
+		{domain.ConflictFail, domain.ErrConflict, "seeded", domain.DepreciationNone},
+		{domain.ConflictSkip, nil, "seeded", domain.DepreciationNone},
+		{domain.ConflictOverwrite, nil, "archived", domain.DepreciationStraightLine},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			category := "Restore Test " + string(tt.policy)
+			t.Cleanup(func() {
+				db.Where("category = ?", category).Delete(&domain.PropertySchema{})
+				db.Where("category = ?", category).Delete(&domain.DepreciationRule{})
+			})
+
+			// What the migrations seed: the same categories under IDs of their own
+			require.NoError(t, db.Create(&domain.PropertySchema{Category: category, Description: "seeded"}).Error)
+			require.NoError(t, db.Create(&domain.DepreciationRule{Category: category, Method: domain.DepreciationNone}).Error)
+
+			archive := &domain.BackupArchive{
+				PropertySchemas:   []domain.PropertySchema{{ID: "00000000-0000-4000-8000-000000000001", Category: category, Description: "archived"}},
+				DepreciationRules: []domain.DepreciationRule{{ID: "00000000-0000-4000-8000-000000000002", Category: category, Method: domain.DepreciationStraightLine, LifeMonths: 60}},
+			}
+			_, err := repo.Restore(ctx, archive, tt.policy)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var schemas []domain.PropertySchema
+			require.NoError(t, db.Where("category = ?", category).Find(&schemas).Error)
+			require.Len(t, schemas, 1)
+			assert.Equal(t, tt.description, schemas[0].Description)
+
+			var rule domain.DepreciationRule
+			require.NoError(t, db.Where("category = ?", category).First(&rule).Error)
+			assert.Equal(t, tt.method, rule.Method)
+		})
+	}
+}
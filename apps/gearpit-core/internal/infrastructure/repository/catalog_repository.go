@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// catalogBatchSize keeps each insert well under PostgreSQL's bind parameter limit.
+const catalogBatchSize = 500
+
+type catalogRepository struct {
+	db *gorm.DB
+}
+
+func NewCatalogRepository(db *gorm.DB) domain.CatalogRepository {
+	return &catalogRepository{db: db}
+}
+
+func (r *catalogRepository) Upsert(ctx context.Context, products []domain.CatalogProduct) error {
+	if len(products) == 0 {
+		return nil
+	}
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "barcode"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "manufacturer", "brand", "category", "description", "weight_gram", "price", "currency", "updated_at",
+		}),
+	}).CreateInBatches(products, catalogBatchSize).Error
+	if err != nil {
+		return fmt.Errorf("failed to import catalog: %w", err)
+	}
+	return nil
+}
+
+func (r *catalogRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.CatalogProduct, error) {
+	var product domain.CatalogProduct
+	if err := r.db.WithContext(ctx).First(&product, "barcode = ?", barcode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: product %s", domain.ErrNotFound, barcode)
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	return &product, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"gorm.io/gorm"
@@ -16,25 +18,69 @@ func NewDashboardRepository(db *gorm.DB) domain.DashboardRepository {
 	return &dashboardRepository{db: db}
 }
 
-func (r *dashboardRepository) GetStats(ctx context.Context) (*domain.DashboardStats, error) {
+// itemCategoryExpr is the category key shared by every per-category aggregate.
+const itemCategoryExpr = "items.properties->>'category'"
+
+// itemScope selects items, leaving out the given statuses.
+func itemScope(db *gorm.DB, excluded []domain.ItemStatus) *gorm.DB {
+	q := db.Model(&domain.Item{})
+	if len(excluded) > 0 {
+		q = q.Where("items.status NOT IN ?", excluded)
+	}
+	return q
+}
+
+// visibleStatuses returns the statuses hidden unless includeInactive is set.
+func visibleStatuses(includeInactive bool) []domain.ItemStatus {
+	if includeInactive {
+		return nil
+	}
+	return domain.InactiveItemStatuses
+}
+
+func (r *dashboardRepository) ListCostInputs(ctx context.Context, includeInactive bool) ([]domain.ItemCostInput, error) {
+	return scanItemCostInputs(itemScope(r.db.WithContext(ctx), visibleStatuses(includeInactive)))
+}
+
+// ListValuationInputs returns purchase data of every item that is still owned.
+// Storage is the location path, or the free-text storage for items without a location.
+func (r *dashboardRepository) ListValuationInputs(ctx context.Context) ([]domain.ValuationInput, error) {
+	var inputs []domain.ValuationInput
+	err := itemScope(r.db.WithContext(ctx), domain.UnownedItemStatuses).
+		Select("items.id AS item_id, items.name, COALESCE(" + itemCategoryExpr + ", '') AS category, " +
+			"COALESCE(" + itemLocationPathExpr + ", NULLIF(items.storage, ''), '') AS storage, COALESCE(items.currency, '') AS currency, " +
+			"items.purchase_price, items.purchase_date, items.created_at").
+		Scan(&inputs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load valuation inputs: %w", err)
+	}
+	return inputs, nil
+}
+
+func (r *dashboardRepository) GetStats(ctx context.Context, includeInactive bool) (*domain.DashboardStats, error) {
 	stats := &domain.DashboardStats{}
 	db := r.db.WithContext(ctx)
 
+	// Item aggregates share one scope so they agree with each other
+	items := func() *gorm.DB {
+		return itemScope(db, visibleStatuses(includeInactive))
+	}
+
 	// 1. Total Items
-	if err := db.Model(&domain.Item{}).Count(&stats.TotalItems).Error; err != nil {
+	if err := items().Count(&stats.TotalItems).Error; err != nil {
 		return nil, fmt.Errorf("failed to count items: %w", err)
 	}
 
-	// 2. Total Weight (Sum of all items)
+	// 2. Total Weight (Sum of all items; parts of an assembly count in its rolled-up weight)
 	var totalWeight int64
-	if err := db.Model(&domain.Item{}).Select("COALESCE(SUM(weight_gram), 0)").Scan(&totalWeight).Error; err != nil {
+	if err := items().Where("NOT " + itemIsComponentExpr).Select("COALESCE(SUM(weight_gram), 0)").Scan(&totalWeight).Error; err != nil {
 		return nil, fmt.Errorf("failed to sum weight: %w", err)
 	}
 	stats.TotalWeight = int(totalWeight)
 
 	// 2.1 Long Gear (Skis/Poles/Accessories) Weight (Sum where weight_type = 'long' or 'accessory')
 	var longWeight int64
-	if err := db.Model(&domain.Item{}).Where("weight_type IN ?", []domain.WeightType{domain.WeightTypeLong, domain.WeightTypeAccessory}).Select("COALESCE(SUM(weight_gram), 0)").Scan(&longWeight).Error; err != nil {
+	if err := items().Where("NOT "+itemIsComponentExpr).Where("weight_type IN ?", longWeightTypes).Select("COALESCE(SUM(weight_gram), 0)").Scan(&longWeight).Error; err != nil {
 		return nil, fmt.Errorf("failed to sum long weight: %w", err)
 	}
 	stats.LongWeight = int(longWeight)
@@ -53,9 +99,10 @@ func (r *dashboardRepository) GetStats(ctx context.Context) (*domain.DashboardSt
 
 	// 5. Category Stats (Group by JSON property)
 	// PostgreSQL specific syntax for JSONB
-	rows, err := db.Model(&domain.Item{}).
-		Select("properties->>'category' as category, COUNT(*) as count, SUM(weight_gram) as total_weight").
-		Group("properties->>'category'").
+	rows, err := items().
+		Select(itemCategoryExpr + " as category, COUNT(*) as count, " +
+			"COALESCE(SUM(weight_gram) FILTER (WHERE NOT " + itemIsComponentExpr + "), 0) as total_weight").
+		Group(itemCategoryExpr).
 		Order("total_weight DESC").
 		Rows()
 	if err != nil {
@@ -80,5 +127,62 @@ func (r *dashboardRepository) GetStats(ctx context.Context) (*domain.DashboardSt
 		stats.CategoryStats = append(stats.CategoryStats, catStat)
 	}
 
+	if err := addAssemblyWeights(db, items(), stats); err != nil {
+		return nil, err
+	}
 	return stats, nil
 }
+
+// itemIsComponentExpr matches items that are part of an assembly in use; their weight
+// is counted in the assembly's rolled-up weight instead.
+const itemIsComponentExpr = `EXISTS (SELECT 1 FROM item_components ic
+	JOIN items parent ON parent.id = ic.parent_id AND parent.deleted_at IS NULL
+	WHERE ic.component_id = items.id)`
+
+var longWeightTypes = []domain.WeightType{domain.WeightTypeLong, domain.WeightTypeAccessory}
+
+// addAssemblyWeights adds the components of each top-level assembly in scope to the
+// weight totals, which so far hold the assemblies' own weight only.
+func addAssemblyWeights(db *gorm.DB, scope *gorm.DB, stats *domain.DashboardStats) error {
+	var assemblies []struct {
+		domain.Item
+		Category string
+	}
+	if err := scope.Where("NOT " + itemIsComponentExpr).
+		Where("EXISTS (SELECT 1 FROM item_components ic WHERE ic.parent_id = items.id)").
+		Select("items.id, items.weight_gram, items.weight_type, COALESCE(" + itemCategoryExpr + ", '') AS category").
+		Scan(&assemblies).Error; err != nil {
+		return fmt.Errorf("failed to load assemblies: %w", err)
+	}
+	if len(assemblies) == 0 {
+		return nil
+	}
+
+	items := make([]*domain.Item, len(assemblies))
+	for i := range assemblies {
+		items[i] = &assemblies[i].Item
+	}
+	if err := applyRolledUpWeights(db, items); err != nil {
+		return err
+	}
+	for _, a := range assemblies {
+		parts := a.PackWeightGram() - a.WeightGram
+		stats.TotalWeight += parts
+		if slices.Contains(longWeightTypes, a.WeightType) {
+			stats.LongWeight += parts
+		}
+		category := a.Category
+		if category == "" {
+			category = "Uncategorized"
+		}
+		for i := range stats.CategoryStats {
+			if stats.CategoryStats[i].Category == category {
+				stats.CategoryStats[i].TotalWeight += parts
+			}
+		}
+	}
+	sort.SliceStable(stats.CategoryStats, func(i, j int) bool {
+		return stats.CategoryStats[i].TotalWeight > stats.CategoryStats[j].TotalWeight
+	})
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type depreciationRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewDepreciationRuleRepository(db *gorm.DB) domain.DepreciationRuleRepository {
+	return &depreciationRuleRepository{db: db}
+}
+
+func (r *depreciationRuleRepository) Save(ctx context.Context, rule *domain.DepreciationRule) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing domain.DepreciationRule
+		err := tx.First(&existing, "category = ?", rule.Category).Error
+		switch {
+		case err == nil:
+			rule.ID = existing.ID
+			rule.CreatedAt = existing.CreatedAt
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		return tx.Save(rule).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save depreciation rule: %w", err)
+	}
+	return nil
+}
+
+func (r *depreciationRuleRepository) GetByCategory(ctx context.Context, category string) (*domain.DepreciationRule, error) {
+	var rule domain.DepreciationRule
+	if err := r.db.WithContext(ctx).First(&rule, "category = ?", category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: no depreciation rule for category %q", domain.ErrNotFound, category)
+		}
+		return nil, fmt.Errorf("failed to get depreciation rule: %w", err)
+	}
+	return &rule, nil
+}
+
+func (r *depreciationRuleRepository) List(ctx context.Context) ([]domain.DepreciationRule, error) {
+	var rules []domain.DepreciationRule
+	if err := r.db.WithContext(ctx).Order("category ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list depreciation rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *depreciationRuleRepository) Delete(ctx context.Context, category string) error {
+	res := r.db.WithContext(ctx).Where("category = ?", category).Delete(&domain.DepreciationRule{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete depreciation rule: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: no depreciation rule for category %q", domain.ErrNotFound, category)
+	}
+	return nil
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"gorm.io/gorm"
@@ -26,7 +30,10 @@ func (r *gearRepository) Create(ctx context.Context, item *domain.Item) error {
 func (r *gearRepository) GetByID(ctx context.Context, id string) (*domain.Item, error) {
 	var item domain.Item
 	if err := r.db.WithContext(ctx).First(&item, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("item not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: item %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get item: %w", err)
 	}
 	return &item, nil
 }
@@ -40,11 +47,139 @@ func (r *gearRepository) List(ctx context.Context) ([]domain.Item, error) {
 	return items, nil
 }
 
+// gearSortColumns maps sort fields to SQL expressions. NULLs are coalesced so
+// keyset comparisons stay total.
+var gearSortColumns = map[domain.GearSortField]string{
+	domain.GearSortName:       "name",
+	domain.GearSortWeight:     "COALESCE(weight_gram, 0)",
+	domain.GearSortCreatedAt:  "COALESCE(created_at, 'epoch'::timestamptz)",
+	domain.GearSortUsageCount: "COALESCE(usage_count, 0)",
+}
+
+// ListFiltered pages with a keyset on (sort column, id), so deep pages cost the same as the first.
+func (r *gearRepository) ListFiltered(ctx context.Context, filter domain.GearFilter) (*domain.GearPage, error) {
+	query, err := applyGearFilter(r.db.WithContext(ctx).Model(&domain.Item{}), filter)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Session(&gorm.Session{})
+
+	page := &domain.GearPage{}
+	if err := query.Count(&page.Total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	column, ok := gearSortColumns[filter.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort field %q", domain.ErrInvalidInput, filter.SortBy)
+	}
+	direction, cmp := "ASC", ">"
+	if filter.SortDesc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if filter.Cursor != "" {
+		value, id, err := filter.DecodeCursor()
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("("+column+", id) "+cmp+" (?, ?)", value, id)
+	}
+	query = query.Order(column + " " + direction).Order("id " + direction)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit + 1)
+	}
+
+	if err := query.Find(&page.Items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list items: %w", err)
+	}
+	if filter.Limit > 0 && len(page.Items) > filter.Limit {
+		page.Items = page.Items[:filter.Limit]
+		page.NextCursor = filter.EncodeCursor(page.Items[filter.Limit-1])
+	}
+	return page, nil
+}
+
+func applyGearFilter(query *gorm.DB, filter domain.GearFilter) (*gorm.DB, error) {
+	if filter.Query != "" {
+		likeQuery := "%" + filter.Query + "%"
+		query = query.Where("name ILIKE ? OR description ILIKE ? OR manufacturer ILIKE ?", likeQuery, likeQuery, likeQuery)
+	}
+	switch {
+	case len(filter.Statuses) > 0:
+		query = query.Where("status IN ?", filter.Statuses)
+	case !filter.IncludeInactive:
+		query = query.Where("status NOT IN ?", domain.InactiveItemStatuses)
+	}
+	if len(filter.WeightTypes) > 0 {
+		query = query.Where("weight_type IN ?", filter.WeightTypes)
+	}
+	switch filter.LocationID {
+	case "":
+	case domain.NoLocation:
+		query = query.Where("location_id IS NULL")
+	default:
+		query = query.Where("location_id IN ("+locationSubtreeSQL+")", filter.LocationID)
+	}
+
+	// category/brand/tags live in properties; one containment check uses the GIN index
+	contains := map[string]interface{}{}
+	if filter.Category != "" {
+		contains["category"] = filter.Category
+	}
+	if filter.Brand != "" {
+		contains["brand"] = filter.Brand
+	}
+	if len(filter.Tags) > 0 {
+		contains["tags"] = filter.Tags
+	}
+	if len(contains) > 0 {
+		containsJSON, err := json.Marshal(contains)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property filter: %w", err)
+		}
+		query = query.Where("properties @> ?::jsonb", string(containsJSON))
+	}
+
+	if filter.MinWeightGram != nil {
+		query = query.Where("COALESCE(weight_gram, 0) >= ?", *filter.MinWeightGram)
+	}
+	if filter.MaxWeightGram != nil {
+		query = query.Where("COALESCE(weight_gram, 0) <= ?", *filter.MaxWeightGram)
+	}
+	if filter.MinUsageCount != nil {
+		query = query.Where("COALESCE(usage_count, 0) >= ?", *filter.MinUsageCount)
+	}
+	if filter.MaintenanceDue {
+		query = query.Where(`maintenance_interval > 0 AND CASE WHEN maintenance_meter_id IS NULL
+			THEN usage_count >= maintenance_interval
+			ELSE EXISTS (SELECT 1 FROM usage_meters m WHERE m.id = items.maintenance_meter_id
+				AND m.total - m.serviced_at_total >= items.maintenance_interval) END`)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return query, nil
+}
+
+// Update saves the item and records the changed fields in its history.
 func (r *gearRepository) Update(ctx context.Context, item *domain.Item) error {
-	if err := r.db.WithContext(ctx).Save(item).Error; err != nil {
-		return fmt.Errorf("failed to update item: %w", err)
+	load := func(tx *gorm.DB) (domain.AuditFields, error) {
+		var stored domain.Item
+		if err := tx.First(&stored, "id = ?", item.ID).Error; err != nil {
+			return nil, fmt.Errorf("failed to update item: %w", err)
+		}
+		return stored.AuditFields(), nil
 	}
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditItems, item.ID, load, func(tx *gorm.DB) error {
+		if err := tx.Save(item).Error; err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *gearRepository) Delete(ctx context.Context, id string) error {
@@ -65,10 +200,533 @@ func (r *gearRepository) Search(ctx context.Context, query string) ([]domain.Ite
 	return items, nil
 }
 
+func (r *gearRepository) FindBySerialNumber(ctx context.Context, serial string) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := r.db.WithContext(ctx).Where("serial_number = ?", serial).Order("created_at ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to find items by serial number: %w", err)
+	}
+	return items, nil
+}
+
+func (r *gearRepository) FindByBarcode(ctx context.Context, barcode string) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := r.db.WithContext(ctx).Where("barcode = ?", barcode).Order("created_at ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to find items by barcode: %w", err)
+	}
+	return items, nil
+}
+
+func (r *gearRepository) ListWarrantiesExpiring(ctx context.Context, from, to time.Time) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := r.db.WithContext(ctx).
+		Where("warranty_expires_at BETWEEN ? AND ?", from, to).
+		Where("status NOT IN ?", domain.UnownedItemStatuses).
+		Order("warranty_expires_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expiring warranties: %w", err)
+	}
+	return items, nil
+}
+
+func (r *gearRepository) ListExpiring(ctx context.Context, before time.Time) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := r.db.WithContext(ctx).
+		Where("expires_at < ?", before).
+		Where("status NOT IN ?", domain.UnownedItemStatuses).
+		Order("expires_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expiring items: %w", err)
+	}
+	return items, nil
+}
+
+// propertyRangeOps maps range operators to SQL. Keys never reach the SQL text.
+var propertyRangeOps = map[domain.PropertyOperator]string{
+	domain.PropertyOpGt:  ">",
+	domain.PropertyOpGte: ">=",
+	domain.PropertyOpLt:  "<",
+	domain.PropertyOpLte: "<=",
+}
+
+// FindByProperties combines category and eq filters into one containment (@>) check,
+// which the GIN index on properties serves. Range filters compare numeric values only.
+func (r *gearRepository) FindByProperties(ctx context.Context, category string, filters []domain.PropertyFilter) ([]domain.Item, error) {
+	contains := map[string]interface{}{}
+	if category != "" {
+		contains["category"] = category
+	}
+
+	query := r.db.WithContext(ctx)
+	for _, f := range filters {
+		if f.Op == domain.PropertyOpEq {
+			contains[f.Key] = f.Value
+			continue
+		}
+		op, ok := propertyRangeOps[f.Op]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown operator %q", domain.ErrInvalidInput, f.Op)
+		}
+		query = query.Where(
+			"CASE WHEN jsonb_typeof(properties -> ?) = 'number' THEN (properties ->> ?)::numeric END "+op+" ?",
+			f.Key, f.Key, f.Value)
+	}
+	if len(contains) > 0 {
+		containsJSON, err := json.Marshal(contains)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode property filter: %w", err)
+		}
+		query = query.Where("properties @> ?::jsonb", string(containsJSON))
+	}
+
+	var items []domain.Item
+	if err := query.Order("name ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to find items by properties: %w", err)
+	}
+	return items, nil
+}
+
+// itemCostColumns selects the raw inputs of domain.ComputeItemCost. Days used
+// come from completed trips only; trashed logs and trips do not count.
+const itemCostColumns = `items.id AS item_id, items.name, COALESCE(items.currency, '') AS currency, items.purchase_price,
+	COALESCE(items.usage_count, 0) AS uses,
+	COALESCE((SELECT SUM(ml.cost) FROM maintenance_logs ml
+		WHERE ml.item_id = items.id AND ml.deleted_at IS NULL), 0) AS maintenance_cost,
+	COALESCE((SELECT SUM(t.duration_days) FROM trip_items ti JOIN trips t ON t.id = ti.trip_id
+		WHERE ti.item_id = items.id AND t.status = 'completed' AND t.deleted_at IS NULL), 0) AS days_used`
+
+// scanItemCostInputs runs itemCostColumns over the items selected by query.
+func scanItemCostInputs(query *gorm.DB) ([]domain.ItemCostInput, error) {
+	var inputs []domain.ItemCostInput
+	if err := query.Model(&domain.Item{}).Select(itemCostColumns).Scan(&inputs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load item costs: %w", err)
+	}
+	return inputs, nil
+}
+
+func (r *gearRepository) GetCostInput(ctx context.Context, id string) (*domain.ItemCostInput, error) {
+	inputs, err := scanItemCostInputs(r.db.WithContext(ctx).Where("items.id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: item %s", domain.ErrNotFound, id)
+	}
+	return &inputs[0], nil
+}
+
+// fullTextSearchSQL ranks items by tsvector match plus trigram word similarity,
+// so misspelled queries still find items through search_text.
+const fullTextSearchSQL = `
+WITH q AS (
+	SELECT websearch_to_tsquery('english', @query) AS tsq, lower(@query) AS text
+)
+SELECT items.*,
+	ts_rank_cd(items.search_vector, q.tsq) + word_similarity(q.text, items.search_text) AS rank,
+	ts_headline('english', items.name, q.tsq, @nameOptions) AS name_highlight,
+	ts_headline('english', coalesce(items.description, ''), q.tsq, @descriptionOptions) AS description_highlight
+FROM items, q
+WHERE items.deleted_at IS NULL
+	AND (items.search_vector @@ q.tsq OR q.text <% items.search_text)
+ORDER BY rank DESC, items.name ASC
+LIMIT @limit`
+
+type searchRow struct {
+	domain.Item          `gorm:"embedded"`
+	Rank                 float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+func (r *gearRepository) FullTextSearch(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	highlight := fmt.Sprintf(`StartSel="%s", StopSel="%s"`, domain.HighlightStart, domain.HighlightStop)
+	var rows []searchRow
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Scoped to this transaction; the <% operator reads the threshold
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)",
+			fmt.Sprint(domain.SearchFuzzyThreshold)).Error; err != nil {
+			return err
+		}
+		return tx.Raw(fullTextSearchSQL,
+			sql.Named("query", query),
+			sql.Named("nameOptions", highlight+", HighlightAll=true"),
+			sql.Named("descriptionOptions", highlight+", MaxFragments=2, MaxWords=20, MinWords=5"),
+			sql.Named("limit", limit),
+		).Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+
+	hits := make([]domain.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hit := domain.SearchHit{Item: row.Item, Rank: row.Rank, Highlights: map[string]string{}}
+		if h := domain.RenderHighlight(row.NameHighlight); h != "" {
+			hit.Highlights["name"] = h
+		}
+		if h := domain.RenderHighlight(row.DescriptionHighlight); h != "" {
+			hit.Highlights["description"] = h
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
 func (r *gearRepository) AddMaintenanceLog(ctx context.Context, log *domain.MaintenanceLog) error {
 	return r.db.WithContext(ctx).Create(log).Error
 }
 
+func (r *gearRepository) MarkMeterServiced(ctx context.Context, meterID string) (float64, error) {
+	res := r.db.WithContext(ctx).Model(&domain.UsageMeter{}).Where("id = ?", meterID).
+		Update("serviced_at_total", gorm.Expr("total"))
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to mark meter serviced: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return 0, fmt.Errorf("%w: meter %s", domain.ErrNotFound, meterID)
+	}
+	meter, err := r.GetMeter(ctx, meterID)
+	if err != nil {
+		return 0, err
+	}
+	return meter.Total, nil
+}
+
+// mergeStatements move references from the duplicates (second ?) to the survivor (first ?).
+// Join rows the survivor already has are kept once; trip quantities are added up.
+var mergeStatements = []struct {
+	table string
+	sql   string
+}{
+	{"kit_items", `INSERT INTO kit_items (kit_id, item_id)
+		SELECT DISTINCT kit_id, ? FROM kit_items WHERE item_id IN ? ON CONFLICT DO NOTHING`},
+	{"loadout_items", `INSERT INTO loadout_items (loadout_id, item_id)
+		SELECT DISTINCT loadout_id, ? FROM loadout_items WHERE item_id IN ? ON CONFLICT DO NOTHING`},
+	{"trip_items", `INSERT INTO trip_items (trip_id, item_id, quantity)
+		SELECT trip_id, ?, SUM(quantity) FROM trip_items WHERE item_id IN ? GROUP BY trip_id
+		ON CONFLICT (trip_id, item_id) DO UPDATE SET quantity = trip_items.quantity + EXCLUDED.quantity`},
+	{"maintenance_logs", `UPDATE maintenance_logs SET item_id = ? WHERE item_id IN ?`},
+	{"loans", `UPDATE loans SET item_id = ? WHERE item_id IN ?`},
+	{"item_moves", `UPDATE item_moves SET item_id = ? WHERE item_id IN ?`},
+	{"odometer_readings", `UPDATE odometer_readings SET item_id = ? WHERE item_id IN ?`},
+	{"service_rules", `UPDATE service_rules SET item_id = ? WHERE item_id IN ?`},
+	{"pc_build_parts", `INSERT INTO pc_build_parts (build_id, item_id, slot)
+		SELECT DISTINCT ON (build_id) build_id, ?, slot FROM pc_build_parts WHERE item_id IN ? ON CONFLICT DO NOTHING`},
+}
+
+// meterMergeTargets maps every meter of the merged items (survivor first ?, duplicates
+// second and third ?) to the meter its name is kept in. Merge checks first that meters
+// sharing a name share the unit too.
+const meterMergeTargets = `WITH m AS (
+		SELECT id, FIRST_VALUE(id) OVER (PARTITION BY name ORDER BY item_id = ? DESC, created_at, id) AS target
+		FROM usage_meters WHERE item_id = ? OR item_id IN ?
+	) `
+
+var meterMergeStatements = []string{
+	`UPDATE usage_entries SET meter_id = m.target FROM m WHERE usage_entries.meter_id = m.id AND m.id <> m.target`,
+	`INSERT INTO trip_meter_usages (trip_id, meter_id, amount)
+		SELECT t.trip_id, m.target, SUM(t.amount) FROM trip_meter_usages t JOIN m ON t.meter_id = m.id
+		WHERE m.id <> m.target GROUP BY t.trip_id, m.target
+		ON CONFLICT (trip_id, meter_id) DO UPDATE SET amount = trip_meter_usages.amount + EXCLUDED.amount`,
+	`DELETE FROM trip_meter_usages USING m WHERE trip_meter_usages.meter_id = m.id AND m.id <> m.target`,
+	`UPDATE usage_meters SET total = usage_meters.total + folded.total,
+		serviced_at_total = usage_meters.serviced_at_total + folded.serviced_at_total
+		FROM (SELECT m.target, SUM(u.total) AS total, SUM(u.serviced_at_total) AS serviced_at_total
+			FROM usage_meters u JOIN m ON u.id = m.id WHERE m.id <> m.target GROUP BY m.target) folded
+		WHERE usage_meters.id = folded.target`,
+	`UPDATE items SET maintenance_meter_id = m.target FROM m WHERE items.maintenance_meter_id = m.id AND m.id <> m.target`,
+	`DELETE FROM usage_meters USING m WHERE usage_meters.id = m.id AND m.id <> m.target`,
+}
+
+func (r *gearRepository) Merge(ctx context.Context, survivorID string, duplicateIDs []string) error {
+	load := func(tx *gorm.DB) (domain.AuditFields, error) {
+		var stored domain.Item
+		if err := tx.First(&stored, "id = ?", survivorID).Error; err != nil {
+			return nil, fmt.Errorf("failed to merge items: %w", err)
+		}
+		return stored.AuditFields(), nil
+	}
+	return auditedUpdate(ctx, r.db, domain.AuditItems, survivorID, load, func(tx *gorm.DB) error {
+		for _, stmt := range mergeStatements {
+			if err := tx.Exec(stmt.sql, survivorID, duplicateIDs).Error; err != nil {
+				if stmt.table == "loans" && isDuplicateKey(tx, err) {
+					return fmt.Errorf("%w: more than one of the merged items is lent out", domain.ErrConflict)
+				}
+				return fmt.Errorf("failed to merge %s: %w", stmt.table, err)
+			}
+		}
+		for _, table := range []string{"kit_items", "loadout_items", "trip_items", "pc_build_parts"} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE item_id IN ?", duplicateIDs).Error; err != nil {
+				return fmt.Errorf("failed to merge %s: %w", table, err)
+			}
+		}
+		// Links between the merged items themselves are dropped, since the survivor
+		// cannot be its own component
+		for _, stmt := range []string{
+			`INSERT INTO item_components (parent_id, component_id, quantity, created_at)
+			SELECT ?, component_id, SUM(quantity), MIN(created_at) FROM item_components
+			WHERE parent_id IN ? AND component_id <> ? AND component_id NOT IN ? GROUP BY component_id
+			ON CONFLICT (parent_id, component_id) DO UPDATE SET quantity = item_components.quantity + EXCLUDED.quantity`,
+			`INSERT INTO item_components (parent_id, component_id, quantity, created_at)
+			SELECT parent_id, ?, SUM(quantity), MIN(created_at) FROM item_components
+			WHERE component_id IN ? AND parent_id <> ? AND parent_id NOT IN ? GROUP BY parent_id
+			ON CONFLICT (parent_id, component_id) DO UPDATE SET quantity = item_components.quantity + EXCLUDED.quantity`,
+		} {
+			if err := tx.Exec(stmt, survivorID, duplicateIDs, survivorID, duplicateIDs).Error; err != nil {
+				return fmt.Errorf("failed to merge item_components: %w", err)
+			}
+		}
+		if err := tx.Exec(`DELETE FROM item_components WHERE parent_id IN ? OR component_id IN ?`,
+			duplicateIDs, duplicateIDs).Error; err != nil {
+			return fmt.Errorf("failed to merge item_components: %w", err)
+		}
+		// A merged item may contain another one further down, which would make the
+		// survivor its own part
+		var cycles int64
+		if err := tx.Raw(`SELECT COUNT(*) FROM (`+componentEdgesBelow+`) below WHERE component_id = ?`,
+			[]string{survivorID}, survivorID).Scan(&cycles).Error; err != nil {
+			return fmt.Errorf("failed to merge item_components: %w", err)
+		}
+		if cycles > 0 {
+			return fmt.Errorf("%w: the merged items contain each other as components", domain.ErrConflict)
+		}
+		if err := tx.Exec(`UPDATE attachments SET owner_id = ? WHERE owner_type = ? AND owner_id IN ?`,
+			survivorID, domain.AttachmentOwnerItem, duplicateIDs).Error; err != nil {
+			return fmt.Errorf("failed to merge attachments: %w", err)
+		}
+		// Meters with the same name are folded into one, the survivor's if it has it;
+		// their totals only add up when they count in the same unit
+		var mixed []string
+		if err := tx.Raw(`SELECT name FROM usage_meters WHERE item_id = ? OR item_id IN ?
+			GROUP BY name HAVING COUNT(DISTINCT unit) > 1 ORDER BY name`, survivorID, duplicateIDs).
+			Scan(&mixed).Error; err != nil {
+			return fmt.Errorf("failed to merge usage_meters: %w", err)
+		}
+		if len(mixed) > 0 {
+			return fmt.Errorf("%w: meter %q counts in different units on the merged items", domain.ErrConflict, mixed[0])
+		}
+		for _, stmt := range meterMergeStatements {
+			if err := tx.Exec(meterMergeTargets+stmt, survivorID, survivorID, duplicateIDs).Error; err != nil {
+				return fmt.Errorf("failed to merge usage_meters: %w", err)
+			}
+		}
+		if err := tx.Exec(`UPDATE usage_meters SET item_id = ? WHERE item_id IN ?`, survivorID, duplicateIDs).Error; err != nil {
+			return fmt.Errorf("failed to merge usage_meters: %w", err)
+		}
+
+		if err := tx.Exec(`UPDATE items SET
+			usage_count = usage_count + (SELECT COALESCE(SUM(usage_count), 0) FROM items WHERE id IN ?),
+			stock_quantity = stock_quantity + (SELECT COALESCE(SUM(stock_quantity), 0) FROM items WHERE id IN ?)
+			WHERE id = ?`, duplicateIDs, duplicateIDs, survivorID).Error; err != nil {
+			return fmt.Errorf("failed to merge usage counts: %w", err)
+		}
+		// The duplicates go to the trash, so a wrong merge leaves their details recoverable
+		if err := tx.Where("id IN ?", duplicateIDs).Delete(&domain.Item{}).Error; err != nil {
+			return fmt.Errorf("failed to trash merged items: %w", err)
+		}
+		return nil
+	})
+}
+
+// componentEdgesBelow selects every item_components row reachable from the given parents.
+const componentEdgesBelow = `WITH RECURSIVE tree AS (
+		SELECT parent_id, component_id, quantity, created_at FROM item_components WHERE parent_id IN ?
+		UNION
+		SELECT ic.parent_id, ic.component_id, ic.quantity, ic.created_at
+		FROM item_components ic JOIN tree ON ic.parent_id = tree.component_id
+	)
+	SELECT * FROM tree ORDER BY created_at, component_id`
+
+func (r *gearRepository) SetComponents(ctx context.Context, parentID string, components []domain.ItemComponent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var parent domain.Item
+		if err := tx.Select("id").First(&parent, "id = ?", parentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: item %s", domain.ErrNotFound, parentID)
+			}
+			return fmt.Errorf("failed to set components: %w", err)
+		}
+
+		ids := make([]string, 0, len(components))
+		for _, c := range components {
+			ids = append(ids, c.ComponentID)
+		}
+		var edges []domain.ItemComponent
+		if len(ids) > 0 {
+			var count int64
+			if err := tx.Model(&domain.Item{}).Where("id IN ?", ids).Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to set components: %w", err)
+			}
+			if int(count) != len(ids) {
+				return fmt.Errorf("%w: some components do not exist", domain.ErrInvalidInput)
+			}
+			if err := tx.Raw(componentEdgesBelow, ids).Scan(&edges).Error; err != nil {
+				return fmt.Errorf("failed to set components: %w", err)
+			}
+		}
+		components, err := domain.ValidateComponents(parentID, components, edges)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("parent_id = ?", parentID).Delete(&domain.ItemComponent{}).Error; err != nil {
+			return fmt.Errorf("failed to set components: %w", err)
+		}
+		if len(components) == 0 {
+			return nil
+		}
+		if err := tx.Create(&components).Error; err != nil {
+			return fmt.Errorf("failed to set components: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *gearRepository) GetComponentTree(ctx context.Context, id string) (*domain.ComponentNode, error) {
+	items, edges, err := loadComponentGraph(r.db.WithContext(ctx), []string{id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get components: %w", err)
+	}
+	return domain.BuildComponentTree(id, items, edges)
+}
+
+// loadComponentGraph loads the links below the given items and every item they reference.
+func loadComponentGraph(db *gorm.DB, ids []string) (map[string]domain.Item, []domain.ItemComponent, error) {
+	var edges []domain.ItemComponent
+	if err := db.Raw(componentEdgesBelow, ids).Scan(&edges).Error; err != nil {
+		return nil, nil, err
+	}
+	all := append([]string{}, ids...)
+	for _, e := range edges {
+		all = append(all, e.ComponentID)
+	}
+	var items []domain.Item
+	if err := db.Where("id IN ?", all).Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[string]domain.Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	return byID, edges, nil
+}
+
+// applyRolledUpWeights sets TotalWeightGram on the assemblies among items, so pack
+// totals count their components.
+func applyRolledUpWeights(db *gorm.DB, items []*domain.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	graph, edges, err := loadComponentGraph(db, ids)
+	if err != nil {
+		return fmt.Errorf("failed to roll up component weights: %w", err)
+	}
+	weights := domain.RolledUpWeights(ids, graph, edges)
+	for _, item := range items {
+		if w, ok := weights[item.ID]; ok {
+			item.TotalWeightGram = &w
+		}
+	}
+	return nil
+}
+
+func (r *gearRepository) CreateMeter(ctx context.Context, meter *domain.UsageMeter) error {
+	if err := r.db.WithContext(ctx).Create(meter).Error; err != nil {
+		return fmt.Errorf("failed to create meter: %w", err)
+	}
+	return nil
+}
+
+func (r *gearRepository) GetMeter(ctx context.Context, id string) (*domain.UsageMeter, error) {
+	var meter domain.UsageMeter
+	if err := r.db.WithContext(ctx).First(&meter, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: meter %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get meter: %w", err)
+	}
+	return &meter, nil
+}
+
+func (r *gearRepository) ListMeters(ctx context.Context, itemID string) ([]domain.UsageMeter, error) {
+	var meters []domain.UsageMeter
+	if err := r.db.WithContext(ctx).Where("item_id = ?", itemID).Order("created_at ASC").Find(&meters).Error; err != nil {
+		return nil, fmt.Errorf("failed to list meters: %w", err)
+	}
+	return meters, nil
+}
+
+// UpdateMeter saves the name and unit only, so a concurrent entry's total is not overwritten.
+func (r *gearRepository) UpdateMeter(ctx context.Context, meter *domain.UsageMeter) error {
+	if err := r.db.WithContext(ctx).Model(meter).Select("name", "unit", "updated_at").Updates(meter).Error; err != nil {
+		return fmt.Errorf("failed to update meter: %w", err)
+	}
+	return nil
+}
+
+func (r *gearRepository) DeleteMeter(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Item{}).Where("maintenance_meter_id = ?", id).
+			Update("maintenance_meter_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to delete meter: %w", err)
+		}
+		for _, model := range []interface{}{&domain.UsageEntry{}, &domain.TripMeterUsage{}} {
+			if err := tx.Where("meter_id = ?", id).Delete(model).Error; err != nil {
+				return fmt.Errorf("failed to delete meter: %w", err)
+			}
+		}
+		res := tx.Delete(&domain.UsageMeter{}, "id = ?", id)
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete meter: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: meter %s", domain.ErrNotFound, id)
+		}
+		return nil
+	})
+}
+
+func (r *gearRepository) RecordUsage(ctx context.Context, entry *domain.UsageEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entries := []domain.UsageEntry{*entry}
+		if err := recordUsage(tx, entries); err != nil {
+			return err
+		}
+		*entry = entries[0]
+		return nil
+	})
+}
+
+// recordUsage inserts the entries, filling in their IDs, and adds their amounts to the meter totals.
+func recordUsage(tx *gorm.DB, entries []domain.UsageEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := tx.Create(&entries).Error; err != nil {
+		return fmt.Errorf("failed to record usage: %w", err)
+	}
+	for _, e := range entries {
+		if err := tx.Model(&domain.UsageMeter{}).Where("id = ?", e.MeterID).
+			Update("total", gorm.Expr("total + ?", e.Amount)).Error; err != nil {
+			return fmt.Errorf("failed to record usage: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *gearRepository) ListUsageEntries(ctx context.Context, meterID string) ([]domain.UsageEntry, error) {
+	var entries []domain.UsageEntry
+	if err := r.db.WithContext(ctx).Where("meter_id = ?", meterID).
+		Order("recorded_at DESC, created_at DESC").Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list usage entries: %w", err)
+	}
+	return entries, nil
+}
+
 func (r *gearRepository) DoInTransaction(ctx context.Context, fn func(txRepo domain.GearRepository) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		txRepo := &gearRepository{db: tx}
@@ -47,6 +47,7 @@ func newTestDB(t *testing.T) *gorm.DB {
 		&domain.UserProfile{},
 		&domain.Trip{},
 		&domain.TripItem{},
+		&domain.ItemComponent{},
 	)
 	require.NoError(t, err)
 
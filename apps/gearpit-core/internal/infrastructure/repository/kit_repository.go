@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -26,7 +27,10 @@ func (r *kitRepository) Create(ctx context.Context, kit *domain.Kit) error {
 func (r *kitRepository) GetByID(ctx context.Context, id string) (*domain.Kit, error) {
 	var kit domain.Kit
 	if err := r.db.WithContext(ctx).Preload("Items").First(&kit, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("kit not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: kit %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get kit: %w", err)
 	}
 	return &kit, nil
 }
@@ -44,8 +48,22 @@ func (r *kitRepository) AddItem(ctx context.Context, kitID, itemID string) error
 	var kit domain.Kit
 	kit.ID = kitID
 	// Item構造体を使って関連付け
-	if err := r.db.WithContext(ctx).Model(&kit).Association("Items").Append(&domain.Item{ID: itemID}); err != nil {
-		return fmt.Errorf("failed to add item to kit: %w", err)
+	return auditedUpdate(ctx, r.db, domain.AuditKits, kitID, r.loadAuditFields(kitID), func(tx *gorm.DB) error {
+		if err := tx.Model(&kit).Association("Items").Append(&domain.Item{ID: itemID}); err != nil {
+			return fmt.Errorf("failed to add item to kit: %w", err)
+		}
+		return nil
+	})
+}
+
+// Delete moves the kit to the trash. Its item links are kept for restore.
+func (r *kitRepository) Delete(ctx context.Context, id string) error {
+	res := r.db.WithContext(ctx).Delete(&domain.Kit{ID: id})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete kit: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: kit %s", domain.ErrNotFound, id)
 	}
 	return nil
 }
@@ -53,8 +71,35 @@ func (r *kitRepository) AddItem(ctx context.Context, kitID, itemID string) error
 func (r *kitRepository) RemoveItem(ctx context.Context, kitID, itemID string) error {
 	var kit domain.Kit
 	kit.ID = kitID
-	if err := r.db.WithContext(ctx).Model(&kit).Association("Items").Delete(&domain.Item{ID: itemID}); err != nil {
-		return fmt.Errorf("failed to remove item from kit: %w", err)
+	return auditedUpdate(ctx, r.db, domain.AuditKits, kitID, r.loadAuditFields(kitID), func(tx *gorm.DB) error {
+		if err := tx.Model(&kit).Association("Items").Delete(&domain.Item{ID: itemID}); err != nil {
+			return fmt.Errorf("failed to remove item from kit: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetItems replaces the kit's items in one recorded change.
+func (r *kitRepository) SetItems(ctx context.Context, kitID string, itemIDs []string) error {
+	return auditedUpdate(ctx, r.db, domain.AuditKits, kitID, r.loadAuditFields(kitID), func(tx *gorm.DB) error {
+		rows := make([]domain.KitItemRow, 0, len(itemIDs))
+		for _, id := range itemIDs {
+			rows = append(rows, domain.KitItemRow{KitID: kitID, ItemID: id})
+		}
+		if err := replaceLiveLinks(tx, "kit_id", kitID, "item_id", "items", itemIDs, rows); err != nil {
+			return fmt.Errorf("failed to set kit items: %w", err)
+		}
+		return nil
+	})
+}
+
+// loadAuditFields reads the kit with its item links for the history diff.
+func (r *kitRepository) loadAuditFields(kitID string) func(tx *gorm.DB) (domain.AuditFields, error) {
+	return func(tx *gorm.DB) (domain.AuditFields, error) {
+		var kit domain.Kit
+		if err := tx.Preload("Items").First(&kit, "id = ?", kitID).Error; err != nil {
+			return nil, fmt.Errorf("kit not found: %w", err)
+		}
+		return kit.AuditFields(), nil
 	}
-	return nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -17,6 +18,9 @@ func NewLoadoutRepository(db *gorm.DB) domain.LoadoutRepository {
 }
 
 func (r *loadoutRepository) Create(ctx context.Context, loadout *domain.Loadout) error {
+	if err := rejectInactiveItems(r.db.WithContext(ctx), "loadout_items", "loadout_id", "", itemIDsOf(loadout.Items)); err != nil {
+		return err
+	}
 	if err := r.db.WithContext(ctx).Create(loadout).Error; err != nil {
 		return fmt.Errorf("failed to create loadout: %w", err)
 	}
@@ -26,7 +30,13 @@ func (r *loadoutRepository) Create(ctx context.Context, loadout *domain.Loadout)
 func (r *loadoutRepository) GetByID(ctx context.Context, id string) (*domain.Loadout, error) {
 	var loadout domain.Loadout
 	if err := r.db.WithContext(ctx).Preload("Items").Preload("Kits").First(&loadout, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("loadout not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: loadout %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get loadout: %w", err)
+	}
+	if err := applyRolledUpWeights(r.db.WithContext(ctx), packedItems(&loadout)); err != nil {
+		return nil, err
 	}
 	return &loadout, nil
 }
@@ -36,18 +46,66 @@ func (r *loadoutRepository) List(ctx context.Context) ([]domain.Loadout, error)
 	if err := r.db.WithContext(ctx).Preload("Items").Preload("Kits").Find(&loadouts).Error; err != nil {
 		return nil, fmt.Errorf("failed to list loadouts: %w", err)
 	}
+	var items []*domain.Item
+	for i := range loadouts {
+		items = append(items, packedItems(&loadouts[i])...)
+	}
+	if err := applyRolledUpWeights(r.db.WithContext(ctx), items); err != nil {
+		return nil, err
+	}
 	return loadouts, nil
 }
 
+// packedItems returns the items counted in the loadout's weights.
+func packedItems(l *domain.Loadout) []*domain.Item {
+	var items []*domain.Item
+	for i := range l.Items {
+		items = append(items, &l.Items[i])
+	}
+	for k := range l.Kits {
+		for i := range l.Kits[k].Items {
+			items = append(items, &l.Kits[k].Items[i])
+		}
+	}
+	return items
+}
+
+// Update saves the loadout, replaces its kit and item links with loadout.Kits and
+// loadout.Items, and records the changed fields in its history.
 func (r *loadoutRepository) Update(ctx context.Context, loadout *domain.Loadout) error {
-	// Association更新を含む
-	if err := r.db.WithContext(ctx).Save(loadout).Error; err != nil {
-		return fmt.Errorf("failed to update loadout: %w", err)
+	load := func(tx *gorm.DB) (domain.AuditFields, error) {
+		var stored domain.Loadout
+		if err := tx.Preload("Items").Preload("Kits").First(&stored, "id = ?", loadout.ID).Error; err != nil {
+			return nil, fmt.Errorf("loadout not found: %w", err)
+		}
+		return stored.AuditFields(), nil
 	}
-	// 中間テーブルの更新が必要な場合はここでAssociation Replaceを行うが、
-	// Service側で関連付けのIDリストを受け取って再構築する方が確実。
-	// ここでは単純なSaveのみとする
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditLoadouts, loadout.ID, load, func(tx *gorm.DB) error {
+		if err := tx.Omit("Kits", "Items").Save(loadout).Error; err != nil {
+			return fmt.Errorf("failed to update loadout: %w", err)
+		}
+		kitIDs := make([]string, 0, len(loadout.Kits))
+		kitRows := make([]domain.LoadoutKitRow, 0, len(loadout.Kits))
+		for _, k := range loadout.Kits {
+			kitIDs = append(kitIDs, k.ID)
+			kitRows = append(kitRows, domain.LoadoutKitRow{LoadoutID: loadout.ID, KitID: k.ID})
+		}
+		if err := replaceLiveLinks(tx, "loadout_id", loadout.ID, "kit_id", "kits", kitIDs, kitRows); err != nil {
+			return fmt.Errorf("failed to update loadout kits: %w", err)
+		}
+		itemIDs := itemIDsOf(loadout.Items)
+		if err := rejectInactiveItems(tx, "loadout_items", "loadout_id", loadout.ID, itemIDs); err != nil {
+			return err
+		}
+		itemRows := make([]domain.LoadoutItemRow, 0, len(loadout.Items))
+		for _, i := range loadout.Items {
+			itemRows = append(itemRows, domain.LoadoutItemRow{LoadoutID: loadout.ID, ItemID: i.ID})
+		}
+		if err := replaceLiveLinks(tx, "loadout_id", loadout.ID, "item_id", "items", itemIDs, itemRows); err != nil {
+			return fmt.Errorf("failed to update loadout items: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *loadoutRepository) Delete(ctx context.Context, id string) error {
@@ -56,3 +114,33 @@ func (r *loadoutRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func itemIDsOf(items []domain.Item) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+// rejectInactiveItems fails with ErrInvalidInput when one of ids is a retired or sold
+// item that the owner does not list in table yet; items already packed may stay.
+// An empty ownerID checks every item, for owners that are about to be created.
+func rejectInactiveItems(tx *gorm.DB, table, ownerColumn, ownerID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := tx.Model(&domain.Item{}).Select("id, name, status").
+		Where("id IN ? AND status IN ?", ids, domain.InactiveItemStatuses)
+	if ownerID != "" {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM "+table+" l WHERE l."+ownerColumn+" = ? AND l.item_id = items.id)", ownerID)
+	}
+	var inactive []domain.Item
+	if err := query.Limit(1).Find(&inactive).Error; err != nil {
+		return fmt.Errorf("failed to check item status: %w", err)
+	}
+	if len(inactive) > 0 {
+		return fmt.Errorf("%w: %s is %s and cannot be packed", domain.ErrInvalidInput, inactive[0].Name, inactive[0].Status)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadoutRepository_UpdateKeepsTrashedItems(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.AuditEntry{}))
+	repo := repository.NewLoadoutRepository(db)
+	ctx := context.Background()
+
+	kept := &domain.Item{Name: "Loadout Test Stove"}
+	trashed := &domain.Item{Name: "Loadout Test Tent"}
+	require.NoError(t, db.Create(kept).Error)
+	require.NoError(t, db.Create(trashed).Error)
+	loadout := &domain.Loadout{Name: "Loadout Test", Items: []domain.Item{{ID: kept.ID}, {ID: trashed.ID}}}
+	require.NoError(t, repo.Create(ctx, loadout))
+	t.Cleanup(func() {
+		db.Unscoped().Delete(&domain.Loadout{ID: loadout.ID})
+		db.Unscoped().Delete(&domain.Item{}, "id IN ?", []string{kept.ID, trashed.ID})
+	})
+
+	require.NoError(t, db.Delete(trashed).Error)
+	stored, err := repo.GetByID(ctx, loadout.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Items, 1)
+
+	// The client only sees the live item and sends it back unchanged
+	stored.Name = "Loadout Test (renamed)"
+	require.NoError(t, repo.Update(ctx, stored))
+
+	require.NoError(t, db.Unscoped().Model(trashed).Update("deleted_at", nil).Error)
+	restored, err := repo.GetByID(ctx, loadout.ID)
+	require.NoError(t, err)
+	assert.Len(t, restored.Items, 2)
+}
+
+func TestLoadoutRepository_RejectsInactiveItems(t *testing.T) {
+	db := newTestDB(t)
+	require.NoError(t, db.AutoMigrate(&domain.AuditEntry{}))
+	repo := repository.NewLoadoutRepository(db)
+	ctx := context.Background()
+
+	stove := &domain.Item{Name: "Loadout Test Stove"}
+	sold := &domain.Item{Name: "Loadout Test Old Tent", Status: domain.ItemStatusSold}
+	require.NoError(t, db.Create(stove).Error)
+	require.NoError(t, db.Create(sold).Error)
+	t.Cleanup(func() { db.Unscoped().Delete(&domain.Item{}, "id IN ?", []string{stove.ID, sold.ID}) })
+
+	err := repo.Create(ctx, &domain.Loadout{Name: "Loadout Test", Items: []domain.Item{{ID: sold.ID}}})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	// An item retired after it was packed stays in the loadout
+	loadout := &domain.Loadout{Name: "Loadout Test", Items: []domain.Item{{ID: stove.ID}}}
+	require.NoError(t, repo.Create(ctx, loadout))
+	t.Cleanup(func() { db.Unscoped().Delete(&domain.Loadout{ID: loadout.ID}) })
+	require.NoError(t, db.Model(stove).Update("status", domain.ItemStatusRetired).Error)
+	loadout.Name = "Loadout Test (renamed)"
+	require.NoError(t, repo.Update(ctx, loadout))
+
+	loadout.Items = append(loadout.Items, domain.Item{ID: sold.ID})
+	assert.ErrorIs(t, repo.Update(ctx, loadout), domain.ErrInvalidInput)
+}
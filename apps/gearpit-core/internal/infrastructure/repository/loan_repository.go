@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type loanRepository struct {
+	db *gorm.DB
+}
+
+func NewLoanRepository(db *gorm.DB) domain.LoanRepository {
+	return &loanRepository{db: db}
+}
+
+// withParties loads the item and the borrower, including trashed ones so old loans stay readable.
+func (r *loanRepository) withParties(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload("Item", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("BorrowerProfile", func(db *gorm.DB) *gorm.DB { return db.Unscoped() })
+}
+
+func (r *loanRepository) Create(ctx context.Context, loan *domain.Loan) error {
+	if err := r.db.WithContext(ctx).Omit("Item", "BorrowerProfile").Create(loan).Error; err != nil {
+		if isDuplicateKey(r.db, err) {
+			return fmt.Errorf("%w: item %s is already lent out", domain.ErrConflict, loan.ItemID)
+		}
+		return fmt.Errorf("failed to create loan: %w", err)
+	}
+	return nil
+}
+
+func (r *loanRepository) GetByID(ctx context.Context, id string) (*domain.Loan, error) {
+	var loan domain.Loan
+	if err := r.withParties(ctx).First(&loan, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: loan %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get loan: %w", err)
+	}
+	return &loan, nil
+}
+
+func (r *loanRepository) List(ctx context.Context, filter domain.LoanFilter) ([]domain.Loan, error) {
+	query := r.withParties(ctx)
+	if filter.ItemID != "" {
+		query = query.Where("item_id = ?", filter.ItemID)
+	}
+	if filter.BorrowerProfileID != "" {
+		query = query.Where("borrower_profile_id = ?", filter.BorrowerProfileID)
+	}
+	switch filter.Status {
+	case domain.LoanStatusOpen:
+		query = query.Where("returned_at IS NULL")
+	case domain.LoanStatusReturned:
+		query = query.Where("returned_at IS NOT NULL")
+	}
+
+	var loans []domain.Loan
+	if err := query.Order("loaned_at DESC").Find(&loans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list loans: %w", err)
+	}
+	return loans, nil
+}
+
+func (r *loanRepository) Update(ctx context.Context, loan *domain.Loan) error {
+	if err := r.db.WithContext(ctx).Omit("Item", "BorrowerProfile").Save(loan).Error; err != nil {
+		if isDuplicateKey(r.db, err) {
+			return fmt.Errorf("%w: item %s is already lent out", domain.ErrConflict, loan.ItemID)
+		}
+		return fmt.Errorf("failed to update loan: %w", err)
+	}
+	return nil
+}
+
+func (r *loanRepository) Delete(ctx context.Context, id string) error {
+	res := r.db.WithContext(ctx).Delete(&domain.Loan{}, "id = ?", id)
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete loan: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: loan %s", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func (r *loanRepository) ListOverdue(ctx context.Context, now time.Time) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	if err := r.withParties(ctx).
+		Where("returned_at IS NULL AND due_at < ?", now).
+		Order("due_at ASC").
+		Find(&loans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list overdue loans: %w", err)
+	}
+	return loans, nil
+}
+
+func (r *loanRepository) ListByItems(ctx context.Context, itemIDs []string) ([]domain.Loan, error) {
+	var loans []domain.Loan
+	if len(itemIDs) == 0 {
+		return loans, nil
+	}
+	if err := r.withParties(ctx).Where("item_id IN ?", itemIDs).Order("loaned_at ASC").Find(&loans).Error; err != nil {
+		return nil, fmt.Errorf("failed to list loans of items: %w", err)
+	}
+	return loans, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+// locationSubtreeSQL selects a location and all of its descendants. UNION
+// (not UNION ALL) drops repeated rows, so a cycle cannot recurse forever.
+const locationSubtreeSQL = `WITH RECURSIVE subtree AS (
+	SELECT id FROM locations WHERE id = ?
+	UNION
+	SELECT l.id FROM locations l JOIN subtree s ON l.parent_id = s.id
+) SELECT id FROM subtree`
+
+// itemLocationPathExpr is the full location path of items.location_id (NULL without a location).
+// Must match domain.LocationPathSeparator; depth is capped in case of a cycle.
+const itemLocationPathExpr = `(WITH RECURSIVE trail AS (
+	SELECT id, parent_id, name, 0 AS depth FROM locations WHERE id = items.location_id
+	UNION ALL
+	SELECT l.id, l.parent_id, l.name, t.depth + 1 FROM locations l JOIN trail t ON l.id = t.parent_id WHERE t.depth < 32
+) SELECT string_agg(name, ' / ' ORDER BY depth DESC) FROM trail)`
+
+type locationRepository struct {
+	db *gorm.DB
+}
+
+func NewLocationRepository(db *gorm.DB) domain.LocationRepository {
+	return &locationRepository{db: db}
+}
+
+func (r *locationRepository) Create(ctx context.Context, location *domain.Location) error {
+	if err := r.db.WithContext(ctx).Create(location).Error; err != nil {
+		return fmt.Errorf("failed to create location: %w", err)
+	}
+	return nil
+}
+
+func (r *locationRepository) GetByID(ctx context.Context, id string) (*domain.Location, error) {
+	var location domain.Location
+	if err := r.db.WithContext(ctx).First(&location, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: location %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	return &location, nil
+}
+
+func (r *locationRepository) List(ctx context.Context) ([]domain.Location, error) {
+	var locations []domain.Location
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&locations).Error; err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	return locations, nil
+}
+
+func (r *locationRepository) Update(ctx context.Context, location *domain.Location) error {
+	if err := r.db.WithContext(ctx).Save(location).Error; err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+	return nil
+}
+
+// Delete keeps locations that still hold something. Items in the trash do not
+// count; the foreign key clears their location.
+func (r *locationRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var children, items int64
+		if err := tx.Model(&domain.Location{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+			return fmt.Errorf("failed to count sub-locations: %w", err)
+		}
+		if err := tx.Model(&domain.Item{}).Where("location_id = ?", id).Count(&items).Error; err != nil {
+			return fmt.Errorf("failed to count items in location: %w", err)
+		}
+		if children > 0 || items > 0 {
+			return fmt.Errorf("%w: location holds %d sub-locations and %d items", domain.ErrConflict, children, items)
+		}
+
+		res := tx.Delete(&domain.Location{}, "id = ?", id)
+		if res.Error != nil {
+			return fmt.Errorf("failed to delete location: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: location %s", domain.ErrNotFound, id)
+		}
+		return nil
+	})
+}
+
+func (r *locationRepository) MoveItem(ctx context.Context, move *domain.ItemMove) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.Item{}).Where("id = ?", move.ItemID).UpdateColumn("location_id", move.ToLocationID)
+		if res.Error != nil {
+			return fmt.Errorf("failed to move item: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: item %s", domain.ErrNotFound, move.ItemID)
+		}
+		if err := tx.Create(move).Error; err != nil {
+			return fmt.Errorf("failed to record item move: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *locationRepository) ListMoves(ctx context.Context, itemID string) ([]domain.ItemMove, error) {
+	var moves []domain.ItemMove
+	if err := r.db.WithContext(ctx).Where("item_id = ?", itemID).Order("moved_at DESC").Find(&moves).Error; err != nil {
+		return nil, fmt.Errorf("failed to list item moves: %w", err)
+	}
+	return moves, nil
+}
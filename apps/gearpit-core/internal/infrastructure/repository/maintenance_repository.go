@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -32,11 +33,32 @@ func (r *maintenanceRepository) GetByItemID(ctx context.Context, itemID string)
 	return logs, nil
 }
 
+func (r *maintenanceRepository) GetByID(ctx context.Context, id string) (*domain.MaintenanceLog, error) {
+	var log domain.MaintenanceLog
+	if err := r.db.WithContext(ctx).First(&log, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: maintenance log %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get maintenance log: %w", err)
+	}
+	return &log, nil
+}
+
+// Update saves the log and records the changed fields in its history.
 func (r *maintenanceRepository) Update(ctx context.Context, log *domain.MaintenanceLog) error {
-	if err := r.db.WithContext(ctx).Save(log).Error; err != nil {
-		return fmt.Errorf("failed to update maintenance log: %w", err)
+	load := func(tx *gorm.DB) (domain.AuditFields, error) {
+		var stored domain.MaintenanceLog
+		if err := tx.First(&stored, "id = ?", log.ID).Error; err != nil {
+			return nil, fmt.Errorf("failed to update maintenance log: %w", err)
+		}
+		return stored.AuditFields(), nil
 	}
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditMaintenanceLogs, log.ID, load, func(tx *gorm.DB) error {
+		if err := tx.Save(log).Error; err != nil {
+			return fmt.Errorf("failed to update maintenance log: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *maintenanceRepository) Delete(ctx context.Context, id string) error {
@@ -45,3 +67,80 @@ func (r *maintenanceRepository) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (r *maintenanceRepository) AddOdometerReading(ctx context.Context, reading *domain.OdometerReading) error {
+	if err := r.db.WithContext(ctx).Create(reading).Error; err != nil {
+		return fmt.Errorf("failed to add odometer reading: %w", err)
+	}
+	return nil
+}
+
+func (r *maintenanceRepository) ListOdometerReadings(ctx context.Context, itemID string) ([]domain.OdometerReading, error) {
+	var readings []domain.OdometerReading
+	if err := r.db.WithContext(ctx).Where("item_id = ?", itemID).Order("read_at ASC").Find(&readings).Error; err != nil {
+		return nil, fmt.Errorf("failed to list odometer readings: %w", err)
+	}
+	return readings, nil
+}
+
+func (r *maintenanceRepository) CreateRule(ctx context.Context, rule *domain.ServiceRule) error {
+	if err := r.db.WithContext(ctx).Omit("Item").Create(rule).Error; err != nil {
+		return fmt.Errorf("failed to create service rule: %w", err)
+	}
+	return nil
+}
+
+func (r *maintenanceRepository) GetRule(ctx context.Context, id string) (*domain.ServiceRule, error) {
+	var rule domain.ServiceRule
+	if err := r.db.WithContext(ctx).Preload("Item").First(&rule, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: service rule %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get service rule: %w", err)
+	}
+	return &rule, nil
+}
+
+func (r *maintenanceRepository) ListRules(ctx context.Context, itemID string) ([]domain.ServiceRule, error) {
+	// Rules of trashed items stay hidden until the item is restored
+	query := r.db.WithContext(ctx).Preload("Item").
+		Where("item_id IN (SELECT id FROM items WHERE deleted_at IS NULL)")
+	if itemID != "" {
+		query = query.Where("item_id = ?", itemID)
+	}
+	var rules []domain.ServiceRule
+	if err := query.Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *maintenanceRepository) ListCategoryRules(ctx context.Context, category string) ([]domain.ServiceRule, error) {
+	query := r.db.WithContext(ctx).Where("item_id IS NULL")
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	var rules []domain.ServiceRule
+	if err := query.Order("category ASC, created_at ASC").Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *maintenanceRepository) UpdateRule(ctx context.Context, rule *domain.ServiceRule) error {
+	if err := r.db.WithContext(ctx).Omit("Item").Save(rule).Error; err != nil {
+		return fmt.Errorf("failed to update service rule: %w", err)
+	}
+	return nil
+}
+
+func (r *maintenanceRepository) DeleteRule(ctx context.Context, id string) error {
+	res := r.db.WithContext(ctx).Delete(&domain.ServiceRule{ID: id})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete service rule: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: service rule %s", domain.ErrNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type pcBuildRepository struct {
+	db *gorm.DB
+}
+
+func NewPCBuildRepository(db *gorm.DB) domain.PCBuildRepository {
+	return &pcBuildRepository{db: db}
+}
+
+func (r *pcBuildRepository) withParts(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Parts").Preload("Parts.Item")
+}
+
+func (r *pcBuildRepository) Create(ctx context.Context, build *domain.PCBuild) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Parts").Create(build).Error; err != nil {
+			return err
+		}
+		return createBuildParts(tx, build)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create build: %w", err)
+	}
+	return nil
+}
+
+func (r *pcBuildRepository) GetByID(ctx context.Context, id string) (*domain.PCBuild, error) {
+	var build domain.PCBuild
+	if err := r.withParts(ctx).First(&build, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: build %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get build: %w", err)
+	}
+	return &build, nil
+}
+
+func (r *pcBuildRepository) List(ctx context.Context) ([]domain.PCBuild, error) {
+	var builds []domain.PCBuild
+	if err := r.withParts(ctx).Order("name ASC").Find(&builds).Error; err != nil {
+		return nil, fmt.Errorf("failed to list builds: %w", err)
+	}
+	return builds, nil
+}
+
+func (r *pcBuildRepository) Update(ctx context.Context, build *domain.PCBuild) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Parts").Save(build).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("build_id = ?", build.ID).Delete(&domain.BuildPart{}).Error; err != nil {
+			return err
+		}
+		return createBuildParts(tx, build)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update build: %w", err)
+	}
+	return nil
+}
+
+func (r *pcBuildRepository) Delete(ctx context.Context, id string) error {
+	res := r.db.WithContext(ctx).Delete(&domain.PCBuild{ID: id})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete build: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: build %s", domain.ErrNotFound, id)
+	}
+	return nil
+}
+
+func createBuildParts(tx *gorm.DB, build *domain.PCBuild) error {
+	if len(build.Parts) == 0 {
+		return nil
+	}
+	for i := range build.Parts {
+		build.Parts[i].BuildID = build.ID
+	}
+	return tx.Omit("Item").Create(&build.Parts).Error
+}
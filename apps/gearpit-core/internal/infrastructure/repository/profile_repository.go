@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -26,7 +27,10 @@ func (r *profileRepository) Create(ctx context.Context, profile *domain.UserProf
 func (r *profileRepository) GetByID(ctx context.Context, id string) (*domain.UserProfile, error) {
 	var profile domain.UserProfile
 	if err := r.db.WithContext(ctx).First(&profile, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("profile not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: profile %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
 	return &profile, nil
 }
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+)
+
+type propertySchemaRepository struct {
+	db *gorm.DB
+}
+
+func NewPropertySchemaRepository(db *gorm.DB) domain.PropertySchemaRepository {
+	return &propertySchemaRepository{db: db}
+}
+
+func (r *propertySchemaRepository) Create(ctx context.Context, schema *domain.PropertySchema) error {
+	if err := r.db.WithContext(ctx).Create(schema).Error; err != nil {
+		return fmt.Errorf("failed to create property schema: %w", err)
+	}
+	return nil
+}
+
+func (r *propertySchemaRepository) GetByCategory(ctx context.Context, category string) (*domain.PropertySchema, error) {
+	var schema domain.PropertySchema
+	if err := r.db.WithContext(ctx).First(&schema, "category = ?", category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: no property schema for category %q", domain.ErrNotFound, category)
+		}
+		return nil, fmt.Errorf("failed to get property schema: %w", err)
+	}
+	return &schema, nil
+}
+
+func (r *propertySchemaRepository) List(ctx context.Context) ([]domain.PropertySchema, error) {
+	var schemas []domain.PropertySchema
+	if err := r.db.WithContext(ctx).Order("category ASC").Find(&schemas).Error; err != nil {
+		return nil, fmt.Errorf("failed to list property schemas: %w", err)
+	}
+	return schemas, nil
+}
+
+func (r *propertySchemaRepository) Update(ctx context.Context, schema *domain.PropertySchema) error {
+	if err := r.db.WithContext(ctx).Save(schema).Error; err != nil {
+		return fmt.Errorf("failed to update property schema: %w", err)
+	}
+	return nil
+}
+
+func (r *propertySchemaRepository) Delete(ctx context.Context, category string) error {
+	res := r.db.WithContext(ctx).Where("category = ?", category).Delete(&domain.PropertySchema{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete property schema: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: no property schema for category %q", domain.ErrNotFound, category)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) domain.TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// trashModels maps entities to their model and the column shown as the entry name.
+var trashModels = map[domain.TrashEntity]struct {
+	model interface{}
+	label string
+}{
+	domain.TrashItems:           {&domain.Item{}, "name"},
+	domain.TrashKits:            {&domain.Kit{}, "name"},
+	domain.TrashLoadouts:        {&domain.Loadout{}, "name"},
+	domain.TrashTrips:           {&domain.Trip{}, "name"},
+	domain.TrashMaintenanceLogs: {&domain.MaintenanceLog{}, "COALESCE(NULLIF(description, ''), type)"},
+	domain.TrashProfiles:        {&domain.UserProfile{}, "name"},
+}
+
+func (r *trashRepository) List(ctx context.Context) ([]domain.TrashEntry, error) {
+	var entries []domain.TrashEntry
+	for _, entity := range domain.TrashEntities {
+		m := trashModels[entity]
+		var rows []domain.TrashEntry
+		if err := r.db.WithContext(ctx).Unscoped().Model(m.model).
+			Select("id, " + m.label + " AS name, deleted_at").
+			Where("deleted_at IS NOT NULL").
+			Order("deleted_at DESC").
+			Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to list trashed %s: %w", entity, err)
+		}
+		for i := range rows {
+			rows[i].Entity = entity
+		}
+		entries = append(entries, rows...)
+	}
+	return entries, nil
+}
+
+func (r *trashRepository) Restore(ctx context.Context, entity domain.TrashEntity, id string) error {
+	m, ok := trashModels[entity]
+	if !ok {
+		return fmt.Errorf("%w: unknown trash entity %q", domain.ErrInvalidInput, entity)
+	}
+	res := r.db.WithContext(ctx).Unscoped().Model(m.model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return fmt.Errorf("failed to restore %s: %w", entity, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s %s is not in the trash", domain.ErrNotFound, entity, id)
+	}
+	return nil
+}
+
+// Purge permanently deletes one trashed row. Foreign keys cascade to logs and join tables.
+func (r *trashRepository) Purge(ctx context.Context, entity domain.TrashEntity, id string) error {
+	m, ok := trashModels[entity]
+	if !ok {
+		return fmt.Errorf("%w: unknown trash entity %q", domain.ErrInvalidInput, entity)
+	}
+	res := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Delete(m.model)
+	if res.Error != nil {
+		return fmt.Errorf("failed to purge %s: %w", entity, res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s %s is not in the trash", domain.ErrNotFound, entity, id)
+	}
+	return nil
+}
+
+func (r *trashRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (domain.PurgeResult, error) {
+	result := domain.PurgeResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, entity := range domain.TrashEntities {
+			res := tx.Unscoped().Where("deleted_at < ?", cutoff).Delete(trashModels[entity].model)
+			if res.Error != nil {
+				return fmt.Errorf("failed to purge %s: %w", entity, res.Error)
+			}
+			result[entity] = res.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// replaceLiveLinks makes rows the owner's links to live rows of table, leaving links to
+// rows in the trash in place: GetByID does not list them, so callers cannot send them
+// back, and a restored row returns to where it was. column and table are constants of
+// the callers, never user input.
+func replaceLiveLinks[T any](tx *gorm.DB, ownerColumn, ownerID, column, table string, ids []string, rows []T) error {
+	if err := deleteLiveLinksExcept(tx, new(T), ownerColumn, ownerID, column, table, ids); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// deleteLiveLinksExcept deletes the owner's links in model's join table to live rows of
+// table that are not among ids.
+func deleteLiveLinksExcept(tx *gorm.DB, model interface{}, ownerColumn, ownerID, column, table string, ids []string) error {
+	drop := tx.Where(ownerColumn+" = ?", ownerID).
+		Where(column + " IN (SELECT id FROM " + table + " WHERE deleted_at IS NULL)")
+	if len(ids) > 0 {
+		drop = drop.Where(column+" NOT IN ?", ids)
+	}
+	return drop.Delete(model).Error
+}
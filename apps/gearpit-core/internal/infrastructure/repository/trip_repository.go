@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"gorm.io/gorm"
@@ -31,11 +33,57 @@ func (r *tripRepository) GetByID(ctx context.Context, id string) (*domain.Trip,
 		Preload("TripItems.Item"). // アイテム詳細
 		Preload("UserProfile").    // ユーザー情報
 		First(&trip, "id = ?", id).Error; err != nil {
-		return nil, fmt.Errorf("trip not found: %w", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: trip %s", domain.ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if err := applyPackedItems(r.db.WithContext(ctx), &trip); err != nil {
+		return nil, err
 	}
 	return &trip, nil
 }
 
+func (r *tripRepository) ListWithItems(ctx context.Context) ([]domain.Trip, error) {
+	var trips []domain.Trip
+	if err := r.db.WithContext(ctx).
+		Preload("TripItems").
+		Preload("TripItems.Item").
+		Preload("UserProfile").
+		Order("start_date DESC").
+		Find(&trips).Error; err != nil {
+		return nil, fmt.Errorf("failed to list trips: %w", err)
+	}
+	packed := make([]*domain.Trip, len(trips))
+	for i := range trips {
+		packed[i] = &trips[i]
+	}
+	if err := applyPackedItems(r.db.WithContext(ctx), packed...); err != nil {
+		return nil, err
+	}
+	return trips, nil
+}
+
+// applyPackedItems hides packing list rows whose item is in the trash (such items are
+// not preloaded) until they are restored, and rolls up the weights of the remaining items.
+func applyPackedItems(db *gorm.DB, trips ...*domain.Trip) error {
+	var items []*domain.Item
+	for _, trip := range trips {
+		tripItems := trip.TripItems[:0]
+		for _, ti := range trip.TripItems {
+			if ti.Item.ID != "" {
+				tripItems = append(tripItems, ti)
+			}
+		}
+		trip.TripItems = tripItems
+
+		for i := range trip.TripItems {
+			items = append(items, &trip.TripItems[i].Item)
+		}
+	}
+	return applyRolledUpWeights(db, items)
+}
+
 func (r *tripRepository) List(ctx context.Context) ([]domain.Trip, error) {
 	var trips []domain.Trip
 	if err := r.db.WithContext(ctx).Order("start_date DESC").Find(&trips).Error; err != nil {
@@ -44,21 +92,38 @@ func (r *tripRepository) List(ctx context.Context) ([]domain.Trip, error) {
 	return trips, nil
 }
 
+// Update saves the trip and records the changed fields in its history.
 func (r *tripRepository) Update(ctx context.Context, trip *domain.Trip) error {
-	if err := r.db.WithContext(ctx).Save(trip).Error; err != nil {
-		return fmt.Errorf("failed to update trip: %w", err)
-	}
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditTrips, trip.ID, r.loadAuditFields(trip.ID), func(tx *gorm.DB) error {
+		if err := tx.Save(trip).Error; err != nil {
+			return fmt.Errorf("failed to update trip: %w", err)
+		}
+		return nil
+	})
 }
 
-func (r *tripRepository) Delete(ctx context.Context, id string) error {
-	// 中間テーブル (trip_items) は GORM の外部キー制約 (OnDelete:CASCADE) で消えるのが理想だが、
-	// 安全のため明示的に消去、または Association モードでクリアする
-	// ここでは TripItem 自体を削除するクエリを発行
-	if err := r.db.WithContext(ctx).Where("trip_id = ?", id).Delete(&domain.TripItem{}).Error; err != nil {
-		return fmt.Errorf("failed to delete trip items: %w", err)
+// loadAuditFields reads the trip with its packing list for the history diff. Like
+// GetByID it leaves out items in the trash, so reverts compare the same list.
+func (r *tripRepository) loadAuditFields(tripID string) func(tx *gorm.DB) (domain.AuditFields, error) {
+	return func(tx *gorm.DB) (domain.AuditFields, error) {
+		var trip domain.Trip
+		if err := tx.Preload("TripItems.Item").First(&trip, "id = ?", tripID).Error; err != nil {
+			return nil, fmt.Errorf("trip not found: %w", err)
+		}
+		tripItems := trip.TripItems[:0]
+		for _, ti := range trip.TripItems {
+			if ti.Item.ID != "" {
+				tripItems = append(tripItems, ti)
+			}
+		}
+		trip.TripItems = tripItems
+		return trip.AuditFields(), nil
 	}
+}
 
+// Delete moves the trip to the trash. trip_items stay so a restore brings the packing list back;
+// they are removed by the foreign key cascade when the trip is purged.
+func (r *tripRepository) Delete(ctx context.Context, id string) error {
 	if err := r.db.WithContext(ctx).Delete(&domain.Trip{ID: id}).Error; err != nil {
 		return fmt.Errorf("failed to delete trip: %w", err)
 	}
@@ -73,25 +138,56 @@ func (r *tripRepository) UpsertItem(ctx context.Context, tripID string, itemID s
 		Quantity: quantity,
 	}
 
-	// PostgreSQL の ON CONFLICT (trip_id, item_id) DO UPDATE SET quantity = ... を実行
-	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "trip_id"}, {Name: "item_id"}}, // 複合主キー
-		DoUpdates: clause.AssignmentColumns([]string{"quantity"}),        // 更新するカラム
-	}).Create(&tripItem).Error; err != nil {
-		return fmt.Errorf("failed to upsert trip item: %w", err)
-	}
-
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditTrips, tripID, r.loadAuditFields(tripID), func(tx *gorm.DB) error {
+		if err := rejectInactiveItems(tx, "trip_items", "trip_id", tripID, []string{itemID}); err != nil {
+			return err
+		}
+		// PostgreSQL の ON CONFLICT (trip_id, item_id) DO UPDATE SET quantity = ... を実行
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "trip_id"}, {Name: "item_id"}}, // 複合主キー
+			DoUpdates: clause.AssignmentColumns([]string{"quantity"}),        // 更新するカラム
+		}).Create(&tripItem).Error; err != nil {
+			return fmt.Errorf("failed to upsert trip item: %w", err)
+		}
+		return nil
+	})
 }
 
 // ★ RemoveItem: 中間テーブルから削除
 func (r *tripRepository) RemoveItem(ctx context.Context, tripID string, itemID string) error {
-	if err := r.db.WithContext(ctx).
-		Where("trip_id = ? AND item_id = ?", tripID, itemID).
-		Delete(&domain.TripItem{}).Error; err != nil {
-		return fmt.Errorf("failed to remove item from trip: %w", err)
-	}
-	return nil
+	return auditedUpdate(ctx, r.db, domain.AuditTrips, tripID, r.loadAuditFields(tripID), func(tx *gorm.DB) error {
+		if err := tx.Where("trip_id = ? AND item_id = ?", tripID, itemID).
+			Delete(&domain.TripItem{}).Error; err != nil {
+			return fmt.Errorf("failed to remove item from trip: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetItems replaces the packing list with quantities in one recorded change.
+// Rows of items in the trash are kept, as GetByID does not list them.
+func (r *tripRepository) SetItems(ctx context.Context, tripID string, quantities map[string]int) error {
+	return auditedUpdate(ctx, r.db, domain.AuditTrips, tripID, r.loadAuditFields(tripID), func(tx *gorm.DB) error {
+		ids := make([]string, 0, len(quantities))
+		rows := make([]domain.TripItem, 0, len(quantities))
+		for itemID, quantity := range quantities {
+			ids = append(ids, itemID)
+			rows = append(rows, domain.TripItem{TripID: tripID, ItemID: itemID, Quantity: quantity})
+		}
+		if err := deleteLiveLinksExcept(tx, &domain.TripItem{}, "trip_id", tripID, "item_id", "items", ids); err != nil {
+			return fmt.Errorf("failed to set trip items: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "trip_id"}, {Name: "item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity"}),
+		}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to set trip items: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *tripRepository) DoInTransaction(ctx context.Context, fn func(txRepo domain.TripRepository) error) error {
@@ -101,25 +197,104 @@ func (r *tripRepository) DoInTransaction(ctx context.Context, fn func(txRepo dom
 	})
 }
 
+// IncrementItemUsages counts a use for every item on the trip and for all components
+// of those that are assemblies, since using a tent uses its poles too.
 func (r *tripRepository) IncrementItemUsages(ctx context.Context, tripID string, increment int) error {
-	// 1. Get List of ItemIDs associated with this trip
-	var tripItems []domain.TripItem
-	if err := r.db.WithContext(ctx).Where("trip_id = ?", tripID).Find(&tripItems).Error; err != nil {
-		return err
+	if err := r.db.WithContext(ctx).Exec(`
+		WITH RECURSIVE used(id) AS (
+			SELECT item_id FROM trip_items WHERE trip_id = ?
+			UNION
+			SELECT ic.component_id FROM item_components ic JOIN used ON ic.parent_id = used.id
+		)
+		UPDATE items SET usage_count = usage_count + ? WHERE id IN (SELECT id FROM used)`,
+		tripID, increment).Error; err != nil {
+		return fmt.Errorf("failed to increment item usages: %w", err)
 	}
+	return nil
+}
 
-	if len(tripItems) == 0 {
-		return nil
+func (r *tripRepository) UpsertMeterUsage(ctx context.Context, usage *domain.TripMeterUsage) error {
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "trip_id"}, {Name: "meter_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount"}),
+	}).Omit("Meter").Create(usage).Error; err != nil {
+		return fmt.Errorf("failed to save trip meter usage: %w", err)
 	}
+	return nil
+}
 
-	itemIDs := make([]string, 0, len(tripItems))
-	for _, ti := range tripItems {
-		itemIDs = append(itemIDs, ti.ItemID)
+func (r *tripRepository) RemoveMeterUsage(ctx context.Context, tripID, meterID string) error {
+	if err := r.db.WithContext(ctx).Where("trip_id = ? AND meter_id = ?", tripID, meterID).
+		Delete(&domain.TripMeterUsage{}).Error; err != nil {
+		return fmt.Errorf("failed to remove trip meter usage: %w", err)
 	}
+	return nil
+}
 
-	// 2. Increment usage_count for these Items
-	// usage_count = usage_count + increment
-	return r.db.WithContext(ctx).Model(&domain.Item{}).
-		Where("id IN ?", itemIDs).
-		UpdateColumn("usage_count", gorm.Expr("usage_count + ?", increment)).Error
+func (r *tripRepository) ListMeterUsages(ctx context.Context, tripID string) ([]domain.TripMeterUsage, error) {
+	var usages []domain.TripMeterUsage
+	if err := r.db.WithContext(ctx).Preload("Meter").Where("trip_id = ?", tripID).
+		Order("meter_id").Find(&usages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list trip meter usages: %w", err)
+	}
+	return usages, nil
+}
+
+func (r *tripRepository) ListTripMeters(ctx context.Context, tripID string) ([]domain.UsageMeter, error) {
+	var meters []domain.UsageMeter
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE used(id) AS (
+			SELECT item_id FROM trip_items WHERE trip_id = ?
+			UNION
+			SELECT ic.component_id FROM item_components ic JOIN used ON ic.parent_id = used.id
+		)
+		SELECT * FROM usage_meters WHERE item_id IN (SELECT id FROM used) ORDER BY item_id, created_at`,
+		tripID).Scan(&meters).Error; err != nil {
+		return nil, fmt.Errorf("failed to list trip meters: %w", err)
+	}
+	return meters, nil
+}
+
+func (r *tripRepository) RecordMeterUsages(ctx context.Context, entries []domain.UsageEntry) error {
+	return recordUsage(r.db.WithContext(ctx), entries)
+}
+
+func (r *tripRepository) DecrementConsumableStock(ctx context.Context, tripID string) error {
+	if err := r.db.WithContext(ctx).Exec(`
+		UPDATE items SET stock_quantity = GREATEST(items.stock_quantity - ti.quantity, 0)
+		FROM trip_items ti
+		WHERE ti.trip_id = ? AND ti.item_id = items.id
+			AND items.weight_type = ? AND items.stock_quantity IS NOT NULL AND items.deleted_at IS NULL`,
+		tripID, domain.WeightTypeConsumable).Error; err != nil {
+		return fmt.Errorf("failed to decrement consumable stock: %w", err)
+	}
+	return nil
+}
+
+func (r *tripRepository) ListStockedConsumables(ctx context.Context) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := r.db.WithContext(ctx).
+		Where("weight_type = ? AND stock_quantity IS NOT NULL", domain.WeightTypeConsumable).
+		Where("status NOT IN ?", domain.InactiveItemStatuses).
+		Order("name ASC").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to list stocked consumables: %w", err)
+	}
+	return items, nil
+}
+
+func (r *tripRepository) ListConsumableNeeds(ctx context.Context, from, to time.Time) ([]domain.ConsumableNeed, error) {
+	var needs []domain.ConsumableNeed
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT ti.item_id, t.id AS trip_id, t.name AS trip_name, t.start_date, ti.quantity
+		FROM trip_items ti
+		JOIN trips t ON t.id = ti.trip_id AND t.deleted_at IS NULL
+		JOIN items i ON i.id = ti.item_id AND i.deleted_at IS NULL
+		WHERE t.status <> 'completed' AND t.start_date >= ? AND t.start_date < ?
+			AND i.weight_type = ? AND i.stock_quantity IS NOT NULL
+		ORDER BY t.start_date ASC`,
+		from, to, domain.WeightTypeConsumable).Scan(&needs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list consumable needs: %w", err)
+	}
+	return needs, nil
 }
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(ctx, "attachments/a1", []byte("receipt"), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	r, err := store.Get(ctx, "attachments/a1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(data) != "receipt" {
+		t.Errorf("Get() = %q, want %q", data, "receipt")
+	}
+
+	if err := store.Delete(ctx, "attachments/a1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "attachments/a1"); err != nil {
+		t.Errorf("Delete() of a missing key should succeed, got %v", err)
+	}
+	if _, err := store.Get(ctx, "attachments/a1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Put(ctx, "../escape", []byte("x"), ""); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Put() outside root error = %v, want ErrInvalidInput", err)
+	}
+}
+
+// fakeS3 keeps objects in memory and checks the parts of SigV4 it can verify without the secret.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	t       *testing.T
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		f.t.Errorf("%s %s: payload hash does not match body", r.Method, r.URL.Path)
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio/") || !strings.Contains(auth, "/us-east-1/s3/aws4_request") {
+		f.t.Errorf("unexpected Authorization header %q", auth)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: map[string][]byte{}, t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Storage(S3Config{Endpoint: server.URL, Bucket: "gear", AccessKey: "minio", SecretKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(ctx, "attachments/a1", []byte("photo"), "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, ok := fake.objects["/gear/attachments/a1"]; !ok {
+		t.Errorf("object not stored under a path-style URL: %v", fake.objects)
+	}
+	r, err := store.Get(ctx, "attachments/a1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(r)
+	_ = r.Close()
+	if string(data) != "photo" {
+		t.Errorf("Get() = %q, want %q", data, "photo")
+	}
+	if err := store.Delete(ctx, "attachments/a1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "attachments/a1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type attachmentService struct {
+	repo    domain.AttachmentRepository
+	storage domain.BlobStorage
+}
+
+func NewAttachmentService(repo domain.AttachmentRepository, storage domain.BlobStorage) domain.AttachmentService {
+	return &attachmentService{repo: repo, storage: storage}
+}
+
+// Upload stores the file and, for images, a thumbnail. A file that cannot be
+// thumbnailed is still saved without one.
+func (s *attachmentService) Upload(ctx context.Context, params domain.UploadAttachmentParams) (*domain.Attachment, error) {
+	if len(params.Data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+	}
+	if len(params.Data) > domain.MaxAttachmentSize {
+		return nil, fmt.Errorf("%w: file is larger than %d MiB", domain.ErrInvalidInput, domain.MaxAttachmentSize>>20)
+	}
+	exists, err := s.repo.OwnerExists(ctx, params.Owner, params.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s %s", domain.ErrNotFound, params.Owner, params.OwnerID)
+	}
+
+	attachment := &domain.Attachment{
+		OwnerType:   params.Owner,
+		OwnerID:     params.OwnerID,
+		FileName:    domain.CleanFileName(params.FileName),
+		ContentType: domain.DetectContentType(params.Data),
+		Size:        int64(len(params.Data)),
+	}
+	var thumbnail []byte
+	if domain.IsImage(attachment.ContentType) {
+		if thumb, err := domain.MakeThumbnail(params.Data, domain.ThumbnailSize); err == nil {
+			thumbnail = thumb
+			attachment.HasThumbnail = true
+		}
+	}
+
+	// The row provides the ID the storage keys are derived from
+	if err := s.repo.Create(ctx, attachment); err != nil {
+		return nil, err
+	}
+	err = s.storage.Put(ctx, attachment.StorageKey(), params.Data, attachment.ContentType)
+	if err == nil && thumbnail != nil {
+		err = s.storage.Put(ctx, attachment.ThumbnailKey(), thumbnail, "image/jpeg")
+	}
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to store attachment: %w", err), s.remove(ctx, *attachment))
+	}
+	return attachment, nil
+}
+
+func (s *attachmentService) List(ctx context.Context, owner domain.AttachmentOwner, ownerID string) ([]domain.Attachment, error) {
+	return s.repo.ListByOwner(ctx, owner, ownerID)
+}
+
+func (s *attachmentService) Open(ctx context.Context, id string, thumbnail bool) (*domain.Attachment, io.ReadCloser, error) {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := attachment.StorageKey()
+	if thumbnail {
+		if !attachment.HasThumbnail {
+			return nil, nil, fmt.Errorf("%w: attachment %s has no thumbnail", domain.ErrNotFound, id)
+		}
+		key = attachment.ThumbnailKey()
+	}
+	content, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *attachmentService) Delete(ctx context.Context, id string) error {
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.remove(ctx, *attachment)
+}
+
+// CleanupOrphans deletes rows first so a failed blob delete never leaves a row
+// pointing at missing content. Blobs that fail to delete are reported.
+func (s *attachmentService) CleanupOrphans(ctx context.Context) (int, error) {
+	orphans, err := s.repo.DeleteOrphans(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, attachment := range orphans {
+		errs = append(errs, s.deleteBlobs(ctx, attachment))
+	}
+	return len(orphans), errors.Join(errs...)
+}
+
+func (s *attachmentService) remove(ctx context.Context, attachment domain.Attachment) error {
+	if err := s.repo.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+	return s.deleteBlobs(ctx, attachment)
+}
+
+func (s *attachmentService) deleteBlobs(ctx context.Context, attachment domain.Attachment) error {
+	err := s.storage.Delete(ctx, attachment.StorageKey())
+	if attachment.HasThumbnail {
+		err = errors.Join(err, s.storage.Delete(ctx, attachment.ThumbnailKey()))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete stored files of attachment %s: %w", attachment.ID, err)
+	}
+	return nil
+}
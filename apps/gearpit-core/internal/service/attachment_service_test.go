@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	args := m.Called(ctx, attachment)
+	attachment.ID = "att-1"
+	return args.Error(0)
+}
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, id string) (*domain.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attachment), args.Error(1)
+}
+func (m *MockAttachmentRepository) ListByOwner(ctx context.Context, owner domain.AttachmentOwner, ownerID string) ([]domain.Attachment, error) {
+	args := m.Called(ctx, owner, ownerID)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockAttachmentRepository) OwnerExists(ctx context.Context, owner domain.AttachmentOwner, ownerID string) (bool, error) {
+	args := m.Called(ctx, owner, ownerID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockAttachmentRepository) DeleteOrphans(ctx context.Context) ([]domain.Attachment, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Attachment), args.Error(1)
+}
+
+// memoryStorage is an in-memory BlobStorage.
+type memoryStorage struct {
+	blobs   map[string][]byte
+	failPut bool
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{blobs: map[string][]byte{}}
+}
+
+func (s *memoryStorage) Put(_ context.Context, key string, data []byte, _ string) error {
+	if s.failPut {
+		return fmt.Errorf("disk full")
+	}
+	s.blobs[key] = data
+	return nil
+}
+func (s *memoryStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+func (s *memoryStorage) Delete(_ context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	ctx := context.Background()
+	var img bytes.Buffer
+	_ = png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+
+	t.Run("Image is stored with a thumbnail", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		store := newMemoryStorage()
+		svc := NewAttachmentService(repo, store)
+		repo.On("OwnerExists", ctx, domain.AttachmentOwnerMaintenanceLog, "log-1").Return(true, nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+
+		attachment, err := svc.Upload(ctx, domain.UploadAttachmentParams{
+			Owner: domain.AttachmentOwnerMaintenanceLog, OwnerID: "log-1", FileName: "dent.png", Data: img.Bytes(),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", attachment.ContentType)
+		assert.True(t, attachment.HasThumbnail)
+		assert.Contains(t, store.blobs, "attachments/att-1")
+		assert.Contains(t, store.blobs, "attachments/att-1.thumb.jpg")
+	})
+
+	t.Run("Unknown owner is rejected", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		svc := NewAttachmentService(repo, newMemoryStorage())
+		repo.On("OwnerExists", ctx, domain.AttachmentOwnerItem, "missing").Return(false, nil)
+
+		_, err := svc.Upload(ctx, domain.UploadAttachmentParams{Owner: domain.AttachmentOwnerItem, OwnerID: "missing", Data: []byte("%PDF-1.4")})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Row is removed when storage fails", func(t *testing.T) {
+		repo := new(MockAttachmentRepository)
+		store := newMemoryStorage()
+		store.failPut = true
+		svc := NewAttachmentService(repo, store)
+		repo.On("OwnerExists", ctx, domain.AttachmentOwnerItem, "item-1").Return(true, nil)
+		repo.On("Create", ctx, mock.Anything).Return(nil)
+		repo.On("Delete", ctx, "att-1").Return(nil)
+
+		_, err := svc.Upload(ctx, domain.UploadAttachmentParams{Owner: domain.AttachmentOwnerItem, OwnerID: "item-1", Data: []byte("%PDF-1.4")})
+
+		assert.Error(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAttachmentService_CleanupOrphans(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAttachmentRepository)
+	store := newMemoryStorage()
+	svc := NewAttachmentService(repo, store)
+
+	orphan := domain.Attachment{ID: "att-9", HasThumbnail: true}
+	store.blobs[orphan.StorageKey()] = []byte("data")
+	store.blobs[orphan.ThumbnailKey()] = []byte("thumb")
+	store.blobs["attachments/keep"] = []byte("keep")
+	repo.On("DeleteOrphans", ctx).Return([]domain.Attachment{orphan}, nil)
+
+	n, err := svc.CleanupOrphans(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, store.blobs, 1)
+}
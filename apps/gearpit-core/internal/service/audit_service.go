@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type auditService struct {
+	repo        domain.AuditRepository
+	gears       domain.GearRepository
+	kits        domain.KitRepository
+	loadouts    domain.LoadoutRepository
+	trips       domain.TripRepository
+	maintenance domain.MaintenanceRepository
+}
+
+func NewAuditService(repo domain.AuditRepository, gears domain.GearRepository, kits domain.KitRepository, loadouts domain.LoadoutRepository, trips domain.TripRepository, maintenance domain.MaintenanceRepository) domain.AuditService {
+	return &auditService{repo: repo, gears: gears, kits: kits, loadouts: loadouts, trips: trips, maintenance: maintenance}
+}
+
+func (s *auditService) GetHistory(ctx context.Context, entity domain.AuditEntity, entityID string) ([]domain.AuditEntry, error) {
+	return s.repo.List(ctx, entity, entityID)
+}
+
+// RevertChange writes the old value back through the entity's repository, which
+// records the revert as new history entries linked to the reverted one.
+func (s *auditService) RevertChange(ctx context.Context, entity domain.AuditEntity, entityID, entryID string) ([]domain.AuditEntry, error) {
+	entry, err := s.repo.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.EntityType != entity || entry.EntityID != entityID {
+		return nil, fmt.Errorf("%w: history entry %s does not belong to %s %s", domain.ErrNotFound, entryID, entity, entityID)
+	}
+
+	ctx = domain.WithRevertOf(ctx, entry.ID)
+	switch entity {
+	case domain.AuditItems:
+		err = s.revertItem(ctx, entry)
+	case domain.AuditKits:
+		err = s.revertKit(ctx, entry)
+	case domain.AuditLoadouts:
+		err = s.revertLoadout(ctx, entry)
+	case domain.AuditTrips:
+		err = s.revertTrip(ctx, entry)
+	case domain.AuditMaintenanceLogs:
+		err = s.revertMaintenanceLog(ctx, entry)
+	default:
+		err = fmt.Errorf("%w: unknown history entity %q", domain.ErrInvalidInput, entity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.List(ctx, entity, entityID)
+	if err != nil {
+		return nil, err
+	}
+	reverts := []domain.AuditEntry{}
+	for _, e := range history {
+		if e.RevertOfID != nil && *e.RevertOfID == entry.ID {
+			reverts = append(reverts, e)
+		}
+	}
+	return reverts, nil
+}
+
+func (s *auditService) revertItem(ctx context.Context, entry *domain.AuditEntry) error {
+	item, err := s.gears.GetByID(ctx, entry.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := entry.CheckRevertible(item.AuditFields()); err != nil {
+		return err
+	}
+	if err := domain.SetAuditField(item, entry.Field, []byte(entry.OldValue)); err != nil {
+		return err
+	}
+	if err := item.ValidateStock(); err != nil {
+		return err
+	}
+	return s.gears.Update(ctx, item)
+}
+
+// revertKit only handles item links; kits have no other editable fields.
+func (s *auditService) revertKit(ctx context.Context, entry *domain.AuditEntry) error {
+	kit, err := s.kits.GetByID(ctx, entry.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := entry.CheckRevertible(kit.AuditFields()); err != nil {
+		return err
+	}
+	if entry.Field != domain.AuditFieldItemIDs {
+		return fmt.Errorf("%w: kit field %q cannot be reverted", domain.ErrInvalidInput, entry.Field)
+	}
+	want, err := domain.DecodeIDs([]byte(entry.OldValue))
+	if err != nil {
+		return err
+	}
+	return s.kits.SetItems(ctx, kit.ID, want)
+}
+
+func (s *auditService) revertLoadout(ctx context.Context, entry *domain.AuditEntry) error {
+	loadout, err := s.loadouts.GetByID(ctx, entry.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := entry.CheckRevertible(loadout.AuditFields()); err != nil {
+		return err
+	}
+
+	switch entry.Field {
+	case domain.AuditFieldItemIDs:
+		ids, err := domain.DecodeIDs([]byte(entry.OldValue))
+		if err != nil {
+			return err
+		}
+		loadout.Items = make([]domain.Item, 0, len(ids))
+		for _, id := range ids {
+			loadout.Items = append(loadout.Items, domain.Item{ID: id})
+		}
+	case domain.AuditFieldKitIDs:
+		ids, err := domain.DecodeIDs([]byte(entry.OldValue))
+		if err != nil {
+			return err
+		}
+		loadout.Kits = make([]domain.Kit, 0, len(ids))
+		for _, id := range ids {
+			loadout.Kits = append(loadout.Kits, domain.Kit{ID: id})
+		}
+	default:
+		if err := domain.SetAuditField(loadout, entry.Field, []byte(entry.OldValue)); err != nil {
+			return err
+		}
+	}
+	return s.loadouts.Update(ctx, loadout)
+}
+
+func (s *auditService) revertTrip(ctx context.Context, entry *domain.AuditEntry) error {
+	trip, err := s.trips.GetByID(ctx, entry.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := entry.CheckRevertible(trip.AuditFields()); err != nil {
+		return err
+	}
+	if entry.Field != domain.AuditFieldItemQuantities {
+		if err := domain.SetAuditField(trip, entry.Field, []byte(entry.OldValue)); err != nil {
+			return err
+		}
+		return s.trips.Update(ctx, trip)
+	}
+
+	want, err := domain.DecodeItemQuantities([]byte(entry.OldValue))
+	if err != nil {
+		return err
+	}
+	return s.trips.SetItems(ctx, trip.ID, want)
+}
+
+func (s *auditService) revertMaintenanceLog(ctx context.Context, entry *domain.AuditEntry) error {
+	log, err := s.maintenance.GetByID(ctx, entry.EntityID)
+	if err != nil {
+		return err
+	}
+	if err := entry.CheckRevertible(log.AuditFields()); err != nil {
+		return err
+	}
+	if err := domain.SetAuditField(log, entry.Field, []byte(entry.OldValue)); err != nil {
+		return err
+	}
+	return s.maintenance.Update(ctx, log)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, entity domain.AuditEntity, entityID string) ([]domain.AuditEntry, error) {
+	args := m.Called(ctx, entity, entityID)
+	return args.Get(0).([]domain.AuditEntry), args.Error(1)
+}
+func (m *MockAuditRepository) GetByID(ctx context.Context, id string) (*domain.AuditEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuditEntry), args.Error(1)
+}
+
+func TestAuditService_RevertChange(t *testing.T) {
+	ctx := context.Background()
+	entry := &domain.AuditEntry{
+		ID: "e1", EntityType: domain.AuditItems, EntityID: "tent",
+		Field: "usageCount", OldValue: []byte("14"), NewValue: []byte("0"),
+	}
+
+	t.Run("Restores the old value", func(t *testing.T) {
+		audit := new(MockAuditRepository)
+		gears := new(MockGearRepository)
+		service := NewAuditService(audit, gears, nil, nil, nil, nil)
+		revertOf := "e1"
+
+		audit.On("GetByID", ctx, "e1").Return(entry, nil)
+		gears.On("GetByID", mock.Anything, "tent").Return(&domain.Item{ID: "tent", UsageCount: 0}, nil)
+		gears.On("Update", mock.MatchedBy(func(c context.Context) bool {
+			return domain.RevertOfFromContext(c) != nil
+		}), mock.MatchedBy(func(item *domain.Item) bool { return item.UsageCount == 14 })).Return(nil)
+		audit.On("List", mock.Anything, domain.AuditItems, "tent").Return([]domain.AuditEntry{
+			{ID: "e2", Field: "usageCount", RevertOfID: &revertOf},
+			*entry,
+		}, nil)
+
+		reverts, err := service.RevertChange(ctx, domain.AuditItems, "tent", "e1")
+
+		assert.NoError(t, err)
+		assert.Len(t, reverts, 1)
+		assert.Equal(t, "e2", reverts[0].ID)
+		gears.AssertExpectations(t)
+	})
+
+	t.Run("Field changed again", func(t *testing.T) {
+		audit := new(MockAuditRepository)
+		gears := new(MockGearRepository)
+		service := NewAuditService(audit, gears, nil, nil, nil, nil)
+
+		audit.On("GetByID", ctx, "e1").Return(entry, nil)
+		gears.On("GetByID", mock.Anything, "tent").Return(&domain.Item{ID: "tent", UsageCount: 3}, nil)
+
+		_, err := service.RevertChange(ctx, domain.AuditItems, "tent", "e1")
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		gears.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Entry of another entity", func(t *testing.T) {
+		audit := new(MockAuditRepository)
+		service := NewAuditService(audit, nil, nil, nil, nil, nil)
+
+		audit.On("GetByID", ctx, "e1").Return(entry, nil)
+
+		_, err := service.RevertChange(ctx, domain.AuditItems, "stove", "e1")
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Restores a packing list in one change", func(t *testing.T) {
+		audit := new(MockAuditRepository)
+		trips := new(MockTripRepository)
+		service := NewAuditService(audit, nil, nil, nil, trips, nil)
+		listEntry := &domain.AuditEntry{
+			ID: "e3", EntityType: domain.AuditTrips, EntityID: "trip-1", Field: domain.AuditFieldItemQuantities,
+			OldValue: []byte(`{"stove":1,"tent":1}`), NewValue: []byte(`{"stove":2,"water":3}`),
+		}
+
+		audit.On("GetByID", ctx, "e3").Return(listEntry, nil)
+		trips.On("GetByID", mock.Anything, "trip-1").Return(&domain.Trip{ID: "trip-1", TripItems: []domain.TripItem{
+			{ItemID: "stove", Quantity: 2}, {ItemID: "water", Quantity: 3},
+		}}, nil)
+		trips.On("SetItems", mock.Anything, "trip-1", map[string]int{"stove": 1, "tent": 1}).Return(nil).Once()
+		audit.On("List", mock.Anything, domain.AuditTrips, "trip-1").Return([]domain.AuditEntry{}, nil)
+
+		_, err := service.RevertChange(ctx, domain.AuditTrips, "trip-1", "e3")
+
+		assert.NoError(t, err)
+		trips.AssertExpectations(t)
+		trips.AssertNotCalled(t, "UpsertItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		trips.AssertNotCalled(t, "RemoveItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type backupService struct {
+	repo domain.BackupRepository
+}
+
+func NewBackupService(repo domain.BackupRepository) domain.BackupService {
+	return &backupService{repo: repo}
+}
+
+func (s *backupService) CreateBackup(ctx context.Context) (*domain.BackupArchive, error) {
+	version, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := s.repo.Dump(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archive.FormatVersion = domain.BackupFormatVersion
+	archive.SchemaVersion = version
+	archive.CreatedAt = time.Now()
+	return archive, nil
+}
+
+func (s *backupService) RestoreBackup(ctx context.Context, archive *domain.BackupArchive, policy domain.ConflictPolicy) (*domain.RestoreResult, error) {
+	version, err := s.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := domain.PrepareArchiveForRestore(archive, version); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.Restore(ctx, archive, policy)
+	if err != nil {
+		return nil, err
+	}
+	result.SchemaVersion = archive.SchemaVersion
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type catalogService struct {
+	repo  domain.CatalogRepository
+	gears domain.GearRepository
+}
+
+func NewCatalogService(repo domain.CatalogRepository, gears domain.GearRepository) domain.CatalogService {
+	return &catalogService{repo: repo, gears: gears}
+}
+
+// ImportCatalog upserts every valid product. Later rows win when a dump repeats a barcode.
+func (s *catalogService) ImportCatalog(ctx context.Context, format domain.CatalogFormat, data io.Reader, dryRun bool) (*domain.CatalogImportResult, error) {
+	products, rowErrors, err := domain.ParseCatalog(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// A batch upsert cannot touch the same row twice
+	index := map[string]int{}
+	unique := make([]domain.CatalogProduct, 0, len(products))
+	for _, p := range products {
+		if i, ok := index[p.Barcode]; ok {
+			unique[i] = p
+			continue
+		}
+		index[p.Barcode] = len(unique)
+		unique = append(unique, p)
+	}
+
+	result := &domain.CatalogImportResult{
+		DryRun:    dryRun,
+		TotalRows: len(products) + len(rowErrors),
+		Imported:  len(products),
+		Errors:    rowErrors,
+	}
+	if result.Errors == nil {
+		result.Errors = []domain.ImportRowError{}
+	}
+	if dryRun {
+		return result, nil
+	}
+	if err := s.repo.Upsert(ctx, unique); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *catalogService) LookupBarcode(ctx context.Context, barcode string) (*domain.BarcodeLookup, error) {
+	normalized, err := domain.NormalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: barcode is required", domain.ErrInvalidInput)
+	}
+
+	lookup := &domain.BarcodeLookup{
+		Barcode: normalized,
+		Params:  domain.CreateGearParams{Barcode: normalized, WeightType: domain.WeightTypeBase},
+	}
+	product, err := s.repo.GetByBarcode(ctx, normalized)
+	switch {
+	case err == nil:
+		lookup.Product = product
+		lookup.Params = product.GearParams()
+	case !errors.Is(err, domain.ErrNotFound):
+		return nil, err
+	}
+
+	if lookup.Items, err = s.gears.FindByBarcode(ctx, normalized); err != nil {
+		return nil, err
+	}
+	return lookup, nil
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCatalogRepository
+type MockCatalogRepository struct {
+	mock.Mock
+}
+
+func (m *MockCatalogRepository) Upsert(ctx context.Context, products []domain.CatalogProduct) error {
+	args := m.Called(ctx, products)
+	return args.Error(0)
+}
+func (m *MockCatalogRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.CatalogProduct, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CatalogProduct), args.Error(1)
+}
+
+func TestCatalogService_ImportCatalog(t *testing.T) {
+	ctx := context.Background()
+	csv := "barcode,name\n4901234567894,Old Name\n4901234567894,Gas Canister\n96385074,\n"
+
+	t.Run("Dry run does not write", func(t *testing.T) {
+		repo := new(MockCatalogRepository)
+		svc := NewCatalogService(repo, new(MockGearRepository))
+
+		result, err := svc.ImportCatalog(ctx, domain.CatalogFormatCSV, strings.NewReader(csv), true)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.TotalRows)
+		assert.Equal(t, 2, result.Imported)
+		assert.Len(t, result.Errors, 1)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Later rows win for a repeated barcode", func(t *testing.T) {
+		repo := new(MockCatalogRepository)
+		svc := NewCatalogService(repo, new(MockGearRepository))
+		repo.On("Upsert", ctx, mock.MatchedBy(func(products []domain.CatalogProduct) bool {
+			return len(products) == 1 && products[0].Name == "Gas Canister"
+		})).Return(nil)
+
+		_, err := svc.ImportCatalog(ctx, domain.CatalogFormatCSV, strings.NewReader(csv), false)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestCatalogService_LookupBarcode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Known product prefills params", func(t *testing.T) {
+		repo := new(MockCatalogRepository)
+		gears := new(MockGearRepository)
+		svc := NewCatalogService(repo, gears)
+
+		product := &domain.CatalogProduct{Barcode: "0036000291452", Name: "Headlamp", WeightGram: 45}
+		owned := []domain.Item{{ID: "item-1", Name: "Headlamp", Barcode: "0036000291452"}}
+		repo.On("GetByBarcode", ctx, "0036000291452").Return(product, nil)
+		gears.On("FindByBarcode", ctx, "0036000291452").Return(owned, nil)
+
+		// UPC-A scans find the EAN-13 form
+		lookup, err := svc.LookupBarcode(ctx, "036000291452")
+		assert.NoError(t, err)
+		assert.Equal(t, product, lookup.Product)
+		assert.Equal(t, "Headlamp", lookup.Params.Name)
+		assert.Equal(t, 45, lookup.Params.WeightGram)
+		assert.Equal(t, owned, lookup.Items)
+	})
+
+	t.Run("Unknown product still carries the barcode", func(t *testing.T) {
+		repo := new(MockCatalogRepository)
+		gears := new(MockGearRepository)
+		svc := NewCatalogService(repo, gears)
+
+		repo.On("GetByBarcode", ctx, "4901234567894").Return(nil, fmt.Errorf("%w: product", domain.ErrNotFound))
+		gears.On("FindByBarcode", ctx, "4901234567894").Return([]domain.Item{}, nil)
+
+		lookup, err := svc.LookupBarcode(ctx, "4901234567894")
+		assert.NoError(t, err)
+		assert.Nil(t, lookup.Product)
+		assert.Equal(t, "4901234567894", lookup.Params.Barcode)
+	})
+
+	t.Run("Missing or invalid barcode", func(t *testing.T) {
+		svc := NewCatalogService(new(MockCatalogRepository), new(MockGearRepository))
+
+		_, err := svc.LookupBarcode(ctx, "")
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		_, err = svc.LookupBarcode(ctx, "4901234567895")
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
@@ -14,6 +14,18 @@ func NewDashboardService(repo domain.DashboardRepository) domain.DashboardServic
 	return &dashboardService{repo: repo}
 }
 
-func (s *dashboardService) GetDashboardStats(ctx context.Context) (*domain.DashboardStats, error) {
-	return s.repo.GetStats(ctx)
+// dashboardTopCostItems is the length of the best/worst value lists per currency.
+const dashboardTopCostItems = 5
+
+func (s *dashboardService) GetDashboardStats(ctx context.Context, includeInactive bool) (*domain.DashboardStats, error) {
+	stats, err := s.repo.GetStats(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := s.repo.ListCostInputs(ctx, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	stats.Costs = domain.SummarizeCosts(inputs, dashboardTopCostItems)
+	return stats, nil
 }
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type exportService struct {
+	gearRepo    domain.GearRepository
+	kitRepo     domain.KitRepository
+	loadoutRepo domain.LoadoutRepository
+	tripRepo    domain.TripRepository
+}
+
+func NewExportService(gearRepo domain.GearRepository, kitRepo domain.KitRepository, loadoutRepo domain.LoadoutRepository, tripRepo domain.TripRepository) domain.ExportService {
+	return &exportService{
+		gearRepo:    gearRepo,
+		kitRepo:     kitRepo,
+		loadoutRepo: loadoutRepo,
+		tripRepo:    tripRepo,
+	}
+}
+
+func (s *exportService) Export(ctx context.Context, params domain.ExportParams) (*domain.ExportFile, error) {
+	var data interface{}
+	var rows []domain.PackingListRow
+
+	switch params.Entity {
+	case domain.ExportEntityItems:
+		items, err := s.gearRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data = items
+		for _, item := range items {
+			rows = append(rows, domain.PackingListRow{Item: item, Quantity: 1})
+		}
+
+	case domain.ExportEntityKits:
+		kits, err := s.loadKits(ctx, params.ID)
+		if err != nil {
+			return nil, err
+		}
+		data = kits
+		for _, kit := range kits {
+			rows = append(rows, kitRows(kit.Name, kit.Items)...)
+		}
+
+	case domain.ExportEntityLoadouts:
+		loadouts, err := s.loadLoadouts(ctx, params.ID)
+		if err != nil {
+			return nil, err
+		}
+		data = loadouts
+		for _, loadout := range loadouts {
+			items := append([]domain.Item{}, loadout.Items...)
+			for _, kit := range loadout.Kits {
+				items = append(items, kit.Items...)
+			}
+			rows = append(rows, kitRows(loadout.Name, items)...)
+		}
+
+	case domain.ExportEntityTrips:
+		trips, err := s.loadTrips(ctx, params.ID)
+		if err != nil {
+			return nil, err
+		}
+		data = trips
+		for _, trip := range trips {
+			for _, ti := range trip.TripItems {
+				rows = append(rows, domain.PackingListRow{Group: trip.Name, Item: ti.Item, Quantity: ti.Quantity})
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported export entity %q", domain.ErrInvalidInput, params.Entity)
+	}
+
+	var buf bytes.Buffer
+	file := &domain.ExportFile{}
+	baseName := "gearpit-" + string(params.Entity)
+
+	switch params.Format {
+	case domain.ExportFormatJSON:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return nil, fmt.Errorf("failed to encode json export: %w", err)
+		}
+		file.FileName = baseName + ".json"
+		file.ContentType = "application/json"
+	case domain.ExportFormatCSV:
+		if err := domain.WriteItemsCSV(&buf, rows); err != nil {
+			return nil, fmt.Errorf("failed to write csv export: %w", err)
+		}
+		file.FileName = baseName + ".csv"
+		file.ContentType = "text/csv"
+	case domain.ExportFormatLighterPack:
+		if err := domain.WriteLighterPackCSV(&buf, rows); err != nil {
+			return nil, fmt.Errorf("failed to write lighterpack export: %w", err)
+		}
+		file.FileName = baseName + "-lighterpack.csv"
+		file.ContentType = "text/csv"
+	default:
+		return nil, fmt.Errorf("%w: unsupported export format %q", domain.ErrInvalidInput, params.Format)
+	}
+
+	file.Data = buf.Bytes()
+	return file, nil
+}
+
+func (s *exportService) loadKits(ctx context.Context, id string) ([]domain.Kit, error) {
+	if id == "" {
+		return s.kitRepo.List(ctx)
+	}
+	kit, err := s.kitRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Kit{*kit}, nil
+}
+
+func (s *exportService) loadLoadouts(ctx context.Context, id string) ([]domain.Loadout, error) {
+	if id == "" {
+		return s.loadoutRepo.List(ctx)
+	}
+	loadout, err := s.loadoutRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Loadout{*loadout}, nil
+}
+
+func (s *exportService) loadTrips(ctx context.Context, id string) ([]domain.Trip, error) {
+	if id == "" {
+		return s.tripRepo.ListWithItems(ctx)
+	}
+	trip, err := s.tripRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Trip{*trip}, nil
+}
+
+// kitRows merges duplicate items (e.g. an item in two kits of a loadout) into one row with a quantity.
+func kitRows(group string, items []domain.Item) []domain.PackingListRow {
+	var rows []domain.PackingListRow
+	index := map[string]int{}
+	for _, item := range items {
+		if i, ok := index[item.ID]; ok {
+			rows[i].Quantity++
+			continue
+		}
+		index[item.ID] = len(rows)
+		rows = append(rows, domain.PackingListRow{Group: group, Item: item, Quantity: 1})
+	}
+	return rows
+}
@@ -3,44 +3,81 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"gorm.io/datatypes"
 )
 
 type gearService struct {
-	repo domain.GearRepository
+	repo    domain.GearRepository
+	schemas domain.PropertySchemaRepository
 }
 
-func NewGearService(repo domain.GearRepository) domain.GearService {
-	return &gearService{repo: repo}
+func NewGearService(repo domain.GearRepository, schemas domain.PropertySchemaRepository) domain.GearService {
+	return &gearService{repo: repo, schemas: schemas}
 }
 
 func (s *gearService) CreateItem(ctx context.Context, params domain.CreateGearParams) (*domain.Item, error) {
-	// プロパティをJSONとして構築
-	// プロパティをJSONとして構築
-	props := map[string]interface{}{
-		"category": params.Category,
-		"brand":    params.Brand,
-		"tags":     params.Tags,
+	schema, err := s.schemaFor(ctx, params.Category)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAttributes(schema, params.Attributes); err != nil {
+		return nil, err
+	}
+
+	if params.Barcode, err = domain.NormalizeBarcode(params.Barcode); err != nil {
+		return nil, err
+	}
+
+	item := newItemFromParams(params)
+	if err := item.ValidateStock(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// newItemFromParams builds an unsaved Item, packing category/brand/tags and attributes into Properties.
+func newItemFromParams(params domain.CreateGearParams) *domain.Item {
+	propsJSON := buildProperties(params.Category, params.Brand, params.Tags, params.Attributes)
+	currency := params.Currency
+	if currency == "" && params.PurchasePrice != nil {
+		currency = domain.DefaultCurrency
 	}
-	propsJSON, _ := json.Marshal(props)
 
-	item := &domain.Item{
+	return &domain.Item{
 		Name:                params.Name,
 		Description:         params.Description,
 		Manufacturer:        params.Manufacturer,
 		WeightGram:          params.WeightGram,
 		WeightType:          params.WeightType,
-		Properties:          datatypes.JSON(propsJSON),
+		Unit:                string(params.Unit),
+		Status:              domain.ItemStatusActive,
+		PurchasePrice:       params.PurchasePrice,
+		PurchaseDate:        params.PurchaseDate,
+		Currency:            currency,
+		Vendor:              params.Vendor,
+		Storage:             params.Storage,
+		SerialNumber:        domain.NormalizeSerialNumber(params.SerialNumber),
+		WarrantyExpiresAt:   params.WarrantyExpiresAt,
+		ExpiresAt:           params.ExpiresAt,
+		LotNumber:           strings.TrimSpace(params.LotNumber),
+		Barcode:             params.Barcode,
+		StockQuantity:       params.StockQuantity,
+		ReorderThreshold:    params.ReorderThreshold,
+		Properties:          propsJSON,
 		UsageCount:          params.UsageCount,
 		MaintenanceInterval: params.MaintenanceInterval,
+		MaintenanceLogType:  strings.TrimSpace(params.MaintenanceLogType),
 	}
-
-	if err := s.repo.Create(ctx, item); err != nil {
-		return nil, err
-	}
-	return item, nil
 }
 
 func (s *gearService) GetItem(ctx context.Context, id string) (*domain.Item, error) {
@@ -51,35 +88,344 @@ func (s *gearService) ListItems(ctx context.Context) ([]domain.Item, error) {
 	return s.repo.List(ctx)
 }
 
+func (s *gearService) ListItemsFiltered(ctx context.Context, filter domain.GearFilter) (*domain.GearPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFiltered(ctx, filter)
+}
+
 func (s *gearService) UpdateItem(ctx context.Context, id string, params domain.UpdateGearParams) (*domain.Item, error) {
 	item, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// プロパティ更新
-	props := map[string]interface{}{
-		"category": params.Category,
-		"brand":    params.Brand,
-		"tags":     params.Tags,
+	attrs := params.Attributes
+	if attrs == nil {
+		attrs = item.Attributes()
 	}
-	propsJSON, _ := json.Marshal(props)
+	schema, err := s.schemaFor(ctx, params.Category)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAttributes(schema, attrs); err != nil {
+		return nil, err
+	}
+
+	// プロパティ更新
+	propsJSON := buildProperties(params.Category, params.Brand, params.Tags, attrs)
 
 	item.Name = params.Name
 	item.Description = params.Description
 	item.Manufacturer = params.Manufacturer
 	item.WeightGram = params.WeightGram
 	item.WeightType = params.WeightType
-	item.Properties = datatypes.JSON(propsJSON)
+	if params.Unit != "" {
+		item.Unit = string(params.Unit)
+	}
+	item.Properties = propsJSON
 	item.UsageCount = params.UsageCount
 	item.MaintenanceInterval = params.MaintenanceInterval
+	if params.MaintenanceLogType != nil {
+		item.MaintenanceLogType = strings.TrimSpace(*params.MaintenanceLogType)
+	}
+	// Purchase fields are optional in updates; omitted values keep the stored ones
+	if params.PurchasePrice != nil {
+		item.PurchasePrice = params.PurchasePrice
+	}
+	if params.PurchaseDate != nil {
+		item.PurchaseDate = params.PurchaseDate
+	}
+	if params.Currency != "" {
+		item.Currency = params.Currency
+	}
+	if params.Vendor != "" {
+		item.Vendor = params.Vendor
+	}
+	if params.Storage != "" {
+		item.Storage = params.Storage
+	}
+	if params.SerialNumber != nil {
+		item.SerialNumber = domain.NormalizeSerialNumber(*params.SerialNumber)
+	}
+	switch {
+	case params.ClearWarrantyExpiresAt:
+		item.WarrantyExpiresAt = nil
+	case params.WarrantyExpiresAt != nil:
+		item.WarrantyExpiresAt = params.WarrantyExpiresAt
+	}
+	switch {
+	case params.ClearExpiresAt:
+		item.ExpiresAt = nil
+	case params.ExpiresAt != nil:
+		item.ExpiresAt = params.ExpiresAt
+	}
+	if params.LotNumber != nil {
+		item.LotNumber = strings.TrimSpace(*params.LotNumber)
+	}
+	if params.Barcode != nil {
+		if item.Barcode, err = domain.NormalizeBarcode(*params.Barcode); err != nil {
+			return nil, err
+		}
+	}
+	if params.StockQuantity != nil {
+		item.StockQuantity = params.StockQuantity
+	}
+	if params.ReorderThreshold != nil {
+		item.ReorderThreshold = *params.ReorderThreshold
+	}
+	if params.MaintenanceMeterID != nil {
+		if item.MaintenanceMeterID, err = s.maintenanceMeter(ctx, item.ID, *params.MaintenanceMeterID); err != nil {
+			return nil, err
+		}
+	}
+	// An item that stops being a consumable stops keeping stock
+	if item.WeightType != domain.WeightTypeConsumable && params.StockQuantity == nil {
+		item.StockQuantity = nil
+		item.ReorderThreshold = 0
+	}
+	if err := item.ValidateStock(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
 
+// ChangeItemStatus applies a lifecycle transition. Maintenance logs and trip history are kept.
+func (s *gearService) ChangeItemStatus(ctx context.Context, id string, params domain.ChangeItemStatusParams) (*domain.Item, error) {
+	item, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := item.TransitionTo(params, time.Now()); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Update(ctx, item); err != nil {
 		return nil, err
 	}
 	return item, nil
 }
 
+// GetItemCost returns purchase and maintenance totals with cost per use and per trip day.
+func (s *gearService) GetItemCost(ctx context.Context, id string) (*domain.ItemCost, error) {
+	input, err := s.repo.GetCostInput(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cost := domain.ComputeItemCost(*input)
+	return &cost, nil
+}
+
+// FindBySerialNumber looks items up by serial, ignoring case and surrounding spaces.
+func (s *gearService) FindBySerialNumber(ctx context.Context, serial string) ([]domain.Item, error) {
+	serial = domain.NormalizeSerialNumber(serial)
+	if serial == "" {
+		return nil, fmt.Errorf("%w: serial number is required", domain.ErrInvalidInput)
+	}
+	return s.repo.FindBySerialNumber(ctx, serial)
+}
+
+// FindByBarcode looks items up by a scanned UPC/EAN; UPC-A and EAN-13 forms of a code match.
+func (s *gearService) FindByBarcode(ctx context.Context, barcode string) ([]domain.Item, error) {
+	normalized, err := domain.NormalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: barcode is required", domain.ErrInvalidInput)
+	}
+	return s.repo.FindByBarcode(ctx, normalized)
+}
+
+// ListExpiringWarranties returns warranties ending within the next days days.
+func (s *gearService) ListExpiringWarranties(ctx context.Context, days int) ([]domain.WarrantyAlert, error) {
+	if err := domain.ValidateWindowDays(days); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	items, err := s.repo.ListWarrantiesExpiring(ctx, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]domain.WarrantyAlert, 0, len(items))
+	for _, item := range items {
+		alerts = append(alerts, domain.WarrantyAlert{Item: item, DaysLeft: *item.WarrantyDaysLeft(now)})
+	}
+	return alerts, nil
+}
+
+// ListExpiringItems returns items that have expired or expire within the next days days.
+func (s *gearService) ListExpiringItems(ctx context.Context, days int) ([]domain.ExpiryAlert, error) {
+	if err := domain.ValidateWindowDays(days); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	items, err := s.repo.ListExpiring(ctx, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]domain.ExpiryAlert, 0, len(items))
+	for _, item := range items {
+		alerts = append(alerts, domain.ExpiryAlert{
+			Item:     item,
+			DaysLeft: *item.ExpiryDaysLeft(now),
+			Expired:  !item.ExpiresAt.After(now),
+		})
+	}
+	return alerts, nil
+}
+
+// FindDuplicates scores all items in the inventory against each other.
+func (s *gearService) FindDuplicates(ctx context.Context, minScore float64) ([]domain.DuplicatePair, error) {
+	if err := domain.ValidateDuplicateMinScore(minScore); err != nil {
+		return nil, err
+	}
+	items, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.FindDuplicates(items, minScore), nil
+}
+
+func (s *gearService) MergeItems(ctx context.Context, survivorID string, duplicateIDs []string) (*domain.Item, error) {
+	ids, err := domain.ValidateMerge(survivorID, duplicateIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range append([]string{survivorID}, ids...) {
+		if _, err := s.repo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Merge(ctx, survivorID, ids); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, survivorID)
+}
+
+func (s *gearService) GetComponents(ctx context.Context, id string) (*domain.ComponentNode, error) {
+	return s.repo.GetComponentTree(ctx, id)
+}
+
+func (s *gearService) SetComponents(ctx context.Context, id string, components []domain.ItemComponent) (*domain.ComponentNode, error) {
+	// The repository repeats the check with the stored links to catch cycles
+	components, err := domain.ValidateComponents(id, components, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetComponents(ctx, id, components); err != nil {
+		return nil, err
+	}
+	return s.repo.GetComponentTree(ctx, id)
+}
+
+// maintenanceMeter checks that meterID is one of the item's meters. Empty yields nil.
+func (s *gearService) maintenanceMeter(ctx context.Context, itemID, meterID string) (*string, error) {
+	if meterID == "" {
+		return nil, nil
+	}
+	meter, err := s.repo.GetMeter(ctx, meterID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && meter.ItemID != itemID) {
+		return nil, fmt.Errorf("%w: meter %s does not belong to the item", domain.ErrInvalidInput, meterID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &meter.ID, nil
+}
+
+func (s *gearService) CreateMeter(ctx context.Context, itemID, name string, unit domain.MeterUnit) (*domain.UsageMeter, error) {
+	meter := &domain.UsageMeter{ItemID: itemID, Name: name, Unit: unit}
+	if err := meter.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if err := s.checkMeterName(ctx, meter); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateMeter(ctx, meter); err != nil {
+		return nil, err
+	}
+	return meter, nil
+}
+
+func (s *gearService) ListMeters(ctx context.Context, itemID string) ([]domain.UsageMeter, error) {
+	if _, err := s.repo.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMeters(ctx, itemID)
+}
+
+// UpdateMeter renames the meter or changes its unit; the counted usage is kept.
+func (s *gearService) UpdateMeter(ctx context.Context, id, name string, unit domain.MeterUnit) (*domain.UsageMeter, error) {
+	meter, err := s.repo.GetMeter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	meter.Name = name
+	meter.Unit = unit
+	if err := meter.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkMeterName(ctx, meter); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateMeter(ctx, meter); err != nil {
+		return nil, err
+	}
+	return meter, nil
+}
+
+// checkMeterName rejects a name another meter of the item already uses.
+func (s *gearService) checkMeterName(ctx context.Context, meter *domain.UsageMeter) error {
+	meters, err := s.repo.ListMeters(ctx, meter.ItemID)
+	if err != nil {
+		return err
+	}
+	for _, m := range meters {
+		if m.ID != meter.ID && strings.EqualFold(m.Name, meter.Name) {
+			return fmt.Errorf("%w: item already has a meter named %q", domain.ErrConflict, m.Name)
+		}
+	}
+	return nil
+}
+
+func (s *gearService) DeleteMeter(ctx context.Context, id string) error {
+	return s.repo.DeleteMeter(ctx, id)
+}
+
+func (s *gearService) RecordUsage(ctx context.Context, meterID string, amount float64, recordedAt time.Time, note string) (*domain.UsageEntry, error) {
+	if err := domain.ValidateMeterAmount(amount); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetMeter(ctx, meterID); err != nil {
+		return nil, err
+	}
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+	entry := &domain.UsageEntry{MeterID: meterID, Amount: amount, Note: strings.TrimSpace(note), RecordedAt: recordedAt}
+	if err := s.repo.RecordUsage(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *gearService) ListUsageEntries(ctx context.Context, meterID string) ([]domain.UsageEntry, error) {
+	if _, err := s.repo.GetMeter(ctx, meterID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListUsageEntries(ctx, meterID)
+}
+
 func (s *gearService) DeleteItem(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
@@ -87,3 +433,164 @@ func (s *gearService) DeleteItem(ctx context.Context, id string) error {
 func (s *gearService) SearchItems(ctx context.Context, query string) ([]domain.Item, error) {
 	return s.repo.Search(ctx, query)
 }
+
+func (s *gearService) SearchRanked(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", domain.ErrInvalidInput)
+	}
+	return s.repo.FullTextSearch(ctx, query, domain.NormalizeSearchLimit(limit))
+}
+
+// ImportItems parses a CSV export and creates all rows in a single transaction.
+// When any row fails to parse nothing is written, so the caller can fix the file and retry.
+func (s *gearService) ImportItems(ctx context.Context, params domain.ImportGearParams) (*domain.ImportResult, error) {
+	var rows []domain.ImportRow
+	var rowErrors []domain.ImportRowError
+	var err error
+
+	switch params.Format {
+	case domain.ImportFormatLighterPack:
+		rows, rowErrors, err = domain.ParseLighterPackCSV(params.Data, params.DefaultCurrency)
+	case domain.ImportFormatCSV:
+		unit := params.DefaultUnit
+		if unit == "" {
+			unit = domain.UnitGram
+		}
+		rows, rowErrors, err = domain.ParseGenericCSV(params.Data, params.Mapping, unit, params.DefaultCurrency)
+	default:
+		return nil, fmt.Errorf("%w: unsupported import format %q", domain.ErrInvalidInput, params.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{
+		DryRun:    params.DryRun,
+		TotalRows: len(rows) + len(rowErrors),
+		Items:     make([]domain.Item, 0, len(rows)),
+		Errors:    rowErrors,
+	}
+
+	// Rows are validated against the schema of their category, like single creates
+	schemas := map[string]*domain.PropertySchema{}
+	valid := rows[:0]
+	for _, row := range rows {
+		schema, cached := schemas[row.Params.Category]
+		if !cached {
+			schema, err = s.schemaFor(ctx, row.Params.Category)
+			if err != nil {
+				return nil, err
+			}
+			schemas[row.Params.Category] = schema
+		}
+		if err := validateAttributes(schema, row.Params.Attributes); err != nil {
+			rowErrors = append(rowErrors, domain.ImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+		valid = append(valid, row)
+	}
+	rows = valid
+	sort.Slice(rowErrors, func(i, j int) bool { return rowErrors[i].Line < rowErrors[j].Line })
+	result.Errors = rowErrors
+
+	items := make([]*domain.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, newItemFromParams(row.Params))
+	}
+
+	if params.DryRun || len(rowErrors) > 0 {
+		for _, item := range items {
+			result.Items = append(result.Items, *item)
+		}
+		return result, nil
+	}
+
+	err = s.repo.DoInTransaction(ctx, func(txRepo domain.GearRepository) error {
+		for i, item := range items {
+			if err := txRepo.Create(ctx, item); err != nil {
+				return fmt.Errorf("line %d: %w", rows[i].Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		result.Items = append(result.Items, *item)
+	}
+	result.Imported = len(items)
+	return result, nil
+}
+
+// FindByProperties converts filter values to the types declared by the category's schema
+// (or guesses them without one) and queries the repository.
+func (s *gearService) FindByProperties(ctx context.Context, category string, filters []domain.PropertyFilter) ([]domain.Item, error) {
+	schema, err := s.schemaFor(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := make([]domain.PropertyFilter, 0, len(filters))
+	for _, f := range filters {
+		var field *domain.PropertyField
+		if schema != nil {
+			if found, ok := schema.Field(f.Key); ok {
+				field = &found
+			}
+		}
+		if f.Op != domain.PropertyOpEq && field != nil &&
+			field.Type != domain.PropertyTypeNumber && field.Type != domain.PropertyTypeInteger {
+			return nil, fmt.Errorf("%w: %s is not numeric and only supports eq", domain.ErrInvalidInput, f.Key)
+		}
+		value, err := f.TypedValue(field)
+		if err != nil {
+			return nil, err
+		}
+		f.Value = value
+		typed = append(typed, f)
+	}
+	return s.repo.FindByProperties(ctx, category, typed)
+}
+
+// schemaFor returns the schema registered for a category, or nil when there is none.
+func (s *gearService) schemaFor(ctx context.Context, category string) (*domain.PropertySchema, error) {
+	if category == "" {
+		return nil, nil
+	}
+	schema, err := s.schemas.GetByCategory(ctx, category)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func validateAttributes(schema *domain.PropertySchema, attrs map[string]interface{}) error {
+	for key := range attrs {
+		if domain.IsReservedPropertyKey(key) {
+			return fmt.Errorf("%w: attribute %q is reserved", domain.ErrInvalidInput, key)
+		}
+	}
+	if schema == nil {
+		return nil
+	}
+	return schema.Validate(attrs)
+}
+
+// buildProperties merges the fixed keys and category attributes into the Properties JSON.
+func buildProperties(category, brand string, tags []string, attrs map[string]interface{}) datatypes.JSON {
+	props := make(map[string]interface{}, len(attrs)+3)
+	for key, value := range attrs {
+		props[key] = value
+	}
+	props["category"] = category
+	props["brand"] = brand
+	props["tags"] = tags
+	propsJSON, _ := json.Marshal(props)
+	return datatypes.JSON(propsJSON)
+}
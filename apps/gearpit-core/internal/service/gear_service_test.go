@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +31,13 @@ func (m *MockGearRepository) List(ctx context.Context) ([]domain.Item, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]domain.Item), args.Error(1)
 }
+func (m *MockGearRepository) ListFiltered(ctx context.Context, filter domain.GearFilter) (*domain.GearPage, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GearPage), args.Error(1)
+}
 func (m *MockGearRepository) Update(ctx context.Context, item *domain.Item) error {
 	args := m.Called(ctx, item)
 	return args.Error(0)
@@ -45,6 +54,76 @@ func (m *MockGearRepository) Search(ctx context.Context, query string) ([]domain
 	return args.Get(0).([]domain.Item), args.Error(1)
 }
 
+func (m *MockGearRepository) FullTextSearch(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SearchHit), args.Error(1)
+}
+
+func (m *MockGearRepository) FindByProperties(ctx context.Context, category string, filters []domain.PropertyFilter) ([]domain.Item, error) {
+	args := m.Called(ctx, category, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+
+func (m *MockGearRepository) GetCostInput(ctx context.Context, id string) (*domain.ItemCostInput, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ItemCostInput), args.Error(1)
+}
+
+func (m *MockGearRepository) FindByBarcode(ctx context.Context, barcode string) ([]domain.Item, error) {
+	args := m.Called(ctx, barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+func (m *MockGearRepository) FindBySerialNumber(ctx context.Context, serial string) ([]domain.Item, error) {
+	args := m.Called(ctx, serial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+
+func (m *MockGearRepository) ListWarrantiesExpiring(ctx context.Context, from, to time.Time) ([]domain.Item, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+
+func (m *MockGearRepository) ListExpiring(ctx context.Context, before time.Time) ([]domain.Item, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+func (m *MockGearRepository) Merge(ctx context.Context, survivorID string, duplicateIDs []string) error {
+	args := m.Called(ctx, survivorID, duplicateIDs)
+	return args.Error(0)
+}
+func (m *MockGearRepository) SetComponents(ctx context.Context, parentID string, components []domain.ItemComponent) error {
+	args := m.Called(ctx, parentID, components)
+	return args.Error(0)
+}
+func (m *MockGearRepository) GetComponentTree(ctx context.Context, id string) (*domain.ComponentNode, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ComponentNode), args.Error(1)
+}
+
 func (m *MockGearRepository) DoInTransaction(ctx context.Context, fn func(txRepo domain.GearRepository) error) error {
 	// Simple mock implementation: just execute the function with the mock itself
 	return fn(m)
@@ -55,9 +134,80 @@ func (m *MockGearRepository) AddMaintenanceLog(ctx context.Context, log *domain.
 	return args.Error(0)
 }
 
+func (m *MockGearRepository) MarkMeterServiced(ctx context.Context, meterID string) (float64, error) {
+	args := m.Called(ctx, meterID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockGearRepository) CreateMeter(ctx context.Context, meter *domain.UsageMeter) error {
+	args := m.Called(ctx, meter)
+	return args.Error(0)
+}
+
+func (m *MockGearRepository) GetMeter(ctx context.Context, id string) (*domain.UsageMeter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UsageMeter), args.Error(1)
+}
+
+func (m *MockGearRepository) ListMeters(ctx context.Context, itemID string) ([]domain.UsageMeter, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]domain.UsageMeter), args.Error(1)
+}
+
+func (m *MockGearRepository) UpdateMeter(ctx context.Context, meter *domain.UsageMeter) error {
+	args := m.Called(ctx, meter)
+	return args.Error(0)
+}
+
+func (m *MockGearRepository) DeleteMeter(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGearRepository) RecordUsage(ctx context.Context, entry *domain.UsageEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockGearRepository) ListUsageEntries(ctx context.Context, meterID string) ([]domain.UsageEntry, error) {
+	args := m.Called(ctx, meterID)
+	return args.Get(0).([]domain.UsageEntry), args.Error(1)
+}
+
+type MockPropertySchemaRepository struct {
+	mock.Mock
+}
+
+func (m *MockPropertySchemaRepository) Create(ctx context.Context, schema *domain.PropertySchema) error {
+	args := m.Called(ctx, schema)
+	return args.Error(0)
+}
+func (m *MockPropertySchemaRepository) GetByCategory(ctx context.Context, category string) (*domain.PropertySchema, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PropertySchema), args.Error(1)
+}
+func (m *MockPropertySchemaRepository) List(ctx context.Context) ([]domain.PropertySchema, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.PropertySchema), args.Error(1)
+}
+func (m *MockPropertySchemaRepository) Update(ctx context.Context, schema *domain.PropertySchema) error {
+	args := m.Called(ctx, schema)
+	return args.Error(0)
+}
+func (m *MockPropertySchemaRepository) Delete(ctx context.Context, category string) error {
+	args := m.Called(ctx, category)
+	return args.Error(0)
+}
+
 func TestGearService_CreateItem(t *testing.T) {
 	mockRepo := new(MockGearRepository)
-	service := NewGearService(mockRepo)
+	service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
 
 	ctx := context.Background()
 	params := domain.CreateGearParams{
@@ -82,3 +232,400 @@ func TestGearService_CreateItem(t *testing.T) {
 	assert.Equal(t, "Test Gear", item.Name)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestGearService_UpdateItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Omitted maintenance log type is kept", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		mockRepo.On("GetByID", ctx, "stove").Return(&domain.Item{ID: "stove", MaintenanceInterval: 20, MaintenanceLogType: "cleaning"}, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		item, err := service.UpdateItem(ctx, "stove", domain.UpdateGearParams{Name: "Stove", MaintenanceInterval: 30})
+		assert.NoError(t, err)
+		assert.Equal(t, "cleaning", item.MaintenanceLogType)
+
+		none := " "
+		item, err = service.UpdateItem(ctx, "stove", domain.UpdateGearParams{Name: "Stove", MaintenanceInterval: 30, MaintenanceLogType: &none})
+		assert.NoError(t, err)
+		assert.Empty(t, item.MaintenanceLogType)
+	})
+
+	t.Run("Clears the serial number and warranty date", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		warranty := time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("GetByID", ctx, "tent").Return(&domain.Item{ID: "tent", SerialNumber: "SN-1", WarrantyExpiresAt: &warranty}, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		item, err := service.UpdateItem(ctx, "tent", domain.UpdateGearParams{Name: "Tent"})
+		assert.NoError(t, err)
+		assert.Equal(t, "SN-1", item.SerialNumber)
+		assert.Equal(t, &warranty, item.WarrantyExpiresAt)
+
+		empty := ""
+		item, err = service.UpdateItem(ctx, "tent", domain.UpdateGearParams{Name: "Tent", SerialNumber: &empty, ClearWarrantyExpiresAt: true})
+		assert.NoError(t, err)
+		assert.Empty(t, item.SerialNumber)
+		assert.Nil(t, item.WarrantyExpiresAt)
+	})
+
+	t.Run("Clears the expiry date and lot number", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		expiry := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("GetByID", ctx, "gas").Return(&domain.Item{ID: "gas", ExpiresAt: &expiry, LotNumber: "L-42"}, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		item, err := service.UpdateItem(ctx, "gas", domain.UpdateGearParams{Name: "Gas"})
+		assert.NoError(t, err)
+		assert.Equal(t, &expiry, item.ExpiresAt)
+		assert.Equal(t, "L-42", item.LotNumber)
+
+		empty := ""
+		item, err = service.UpdateItem(ctx, "gas", domain.UpdateGearParams{Name: "Gas", ClearExpiresAt: true, LotNumber: &empty})
+		assert.NoError(t, err)
+		assert.Nil(t, item.ExpiresAt)
+		assert.Empty(t, item.LotNumber)
+	})
+
+	t.Run("Clears the barcode", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		mockRepo.On("GetByID", ctx, "bar").Return(&domain.Item{ID: "bar", Barcode: "4901234567894"}, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		item, err := service.UpdateItem(ctx, "bar", domain.UpdateGearParams{Name: "Energy Bar"})
+		assert.NoError(t, err)
+		assert.Equal(t, "4901234567894", item.Barcode)
+
+		empty := ""
+		item, err = service.UpdateItem(ctx, "bar", domain.UpdateGearParams{Name: "Energy Bar", Barcode: &empty})
+		assert.NoError(t, err)
+		assert.Empty(t, item.Barcode)
+	})
+}
+
+func TestGearService_ImportItems(t *testing.T) {
+	csv := "Item Name,Category,desc,qty,weight,unit,url,price,worn,consumable\n" +
+		"Tent,Shelter,,1,500,g,,,,\n" +
+		"Stove,Kitchen,,1,1,oz,,,,\n"
+
+	t.Run("Dry run does not write", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		mockSchemas := new(MockPropertySchemaRepository)
+		mockSchemas.On("GetByCategory", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+		service := NewGearService(mockRepo, mockSchemas)
+
+		result, err := service.ImportItems(context.Background(), domain.ImportGearParams{
+			Format: domain.ImportFormatLighterPack,
+			Data:   strings.NewReader(csv),
+			DryRun: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.TotalRows)
+		assert.Equal(t, 0, result.Imported)
+		assert.Len(t, result.Items, 2)
+		assert.Equal(t, 28, result.Items[1].WeightGram)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Creates all rows", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		mockSchemas := new(MockPropertySchemaRepository)
+		mockSchemas.On("GetByCategory", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+		service := NewGearService(mockRepo, mockSchemas)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Item")).Return(nil).Twice()
+
+		result, err := service.ImportItems(context.Background(), domain.ImportGearParams{
+			Format: domain.ImportFormatLighterPack,
+			Data:   strings.NewReader(csv),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Row errors abort the import", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		mockSchemas := new(MockPropertySchemaRepository)
+		mockSchemas.On("GetByCategory", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+		service := NewGearService(mockRepo, mockSchemas)
+
+		result, err := service.ImportItems(context.Background(), domain.ImportGearParams{
+			Format: domain.ImportFormatLighterPack,
+			Data:   strings.NewReader(csv + "Pot,Kitchen,,1,heavy,g,,,,\n"),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Imported)
+		assert.Len(t, result.Errors, 1)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGearService_CreateItem_ValidatesAttributes(t *testing.T) {
+	minWidth := 50.0
+	skis := &domain.PropertySchema{
+		Category: "Skis",
+		Fields: []domain.PropertyField{
+			{Key: "waistWidthMm", Type: domain.PropertyTypeNumber, Required: true, Min: &minWidth},
+		},
+	}
+	ctx := context.Background()
+
+	t.Run("Valid attributes are stored in properties", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		mockSchemas := new(MockPropertySchemaRepository)
+		mockSchemas.On("GetByCategory", ctx, "Skis").Return(skis, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Item")).Return(nil)
+		service := NewGearService(mockRepo, mockSchemas)
+
+		item, err := service.CreateItem(ctx, domain.CreateGearParams{
+			Name:       "Touring Ski",
+			Category:   "Skis",
+			Attributes: map[string]interface{}{"waistWidthMm": 98.0},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 98.0, item.PropertyMap()["waistWidthMm"])
+		assert.Equal(t, "Skis", item.PropertyString("category"))
+	})
+
+	t.Run("Invalid attributes are rejected", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		mockSchemas := new(MockPropertySchemaRepository)
+		mockSchemas.On("GetByCategory", ctx, "Skis").Return(skis, nil)
+		service := NewGearService(mockRepo, mockSchemas)
+
+		_, err := service.CreateItem(ctx, domain.CreateGearParams{
+			Name:       "Touring Ski",
+			Category:   "Skis",
+			Attributes: map[string]interface{}{"waistWidthMm": "wide"},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGearService_ChangeItemStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Valid transition is saved", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+		mockRepo.On("GetByID", ctx, "item-1").Return(&domain.Item{ID: "item-1", Status: domain.ItemStatusActive}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(item *domain.Item) bool {
+			return item.Status == domain.ItemStatusRetired && item.RetiredAt != nil
+		})).Return(nil)
+
+		item, err := service.ChangeItemStatus(ctx, "item-1", domain.ChangeItemStatusParams{Status: domain.ItemStatusRetired, Reason: "worn out"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "worn out", item.StatusReason)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid transition is rejected", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+		mockRepo.On("GetByID", ctx, "item-1").Return(&domain.Item{ID: "item-1", Status: domain.ItemStatusSold}, nil)
+
+		_, err := service.ChangeItemStatus(ctx, "item-1", domain.ChangeItemStatusParams{Status: domain.ItemStatusActive})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGearService_Warranty(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Serial lookup is normalized", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+		mockRepo.On("FindBySerialNumber", ctx, "GW-123").Return([]domain.Item{{ID: "watch"}}, nil)
+
+		items, err := service.FindBySerialNumber(ctx, "  gw-123 ")
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expiring warranties carry days left", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+		expires := time.Now().Add(10*24*time.Hour - time.Hour)
+		mockRepo.On("ListWarrantiesExpiring", ctx, mock.Anything, mock.MatchedBy(func(to time.Time) bool {
+			return to.After(time.Now().AddDate(0, 0, 29))
+		})).Return([]domain.Item{{ID: "skis", WarrantyExpiresAt: &expires}}, nil)
+
+		alerts, err := service.ListExpiringWarranties(ctx, 30)
+
+		assert.NoError(t, err)
+		assert.Len(t, alerts, 1)
+		assert.Equal(t, 10, alerts[0].DaysLeft)
+	})
+
+	t.Run("Negative window is rejected", func(t *testing.T) {
+		service := NewGearService(new(MockGearRepository), new(MockPropertySchemaRepository))
+
+		_, err := service.ListExpiringWarranties(ctx, -1)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestGearService_ListExpiringItems(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockGearRepository)
+	service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+	expired := time.Now().AddDate(0, 0, -3)
+	soon := time.Now().Add(5*24*time.Hour - time.Hour)
+	mockRepo.On("ListExpiring", ctx, mock.Anything).Return([]domain.Item{
+		{ID: "fuel", ExpiresAt: &expired},
+		{ID: "bars", ExpiresAt: &soon},
+	}, nil)
+
+	alerts, err := service.ListExpiringItems(ctx, 14)
+
+	assert.NoError(t, err)
+	assert.Len(t, alerts, 2)
+	assert.True(t, alerts[0].Expired)
+	assert.False(t, alerts[1].Expired)
+	assert.Equal(t, 5, alerts[1].DaysLeft)
+}
+
+func TestGearService_MergeItems(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Merges into the survivor", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		mockRepo.On("GetByID", ctx, "keep").Return(&domain.Item{ID: "keep", UsageCount: 5}, nil).Once()
+		mockRepo.On("GetByID", ctx, "dup").Return(&domain.Item{ID: "dup", UsageCount: 3}, nil)
+		mockRepo.On("Merge", ctx, "keep", []string{"dup"}).Return(nil)
+		mockRepo.On("GetByID", ctx, "keep").Return(&domain.Item{ID: "keep", UsageCount: 8}, nil).Once()
+
+		item, err := service.MergeItems(ctx, "keep", []string{"dup", " dup "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 8, item.UsageCount)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects merging an item into itself", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		_, err := service.MergeItems(ctx, "keep", []string{"keep"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown duplicate", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		mockRepo.On("GetByID", ctx, "keep").Return(&domain.Item{ID: "keep"}, nil)
+		mockRepo.On("GetByID", ctx, "gone").Return(nil, domain.ErrNotFound)
+
+		_, err := service.MergeItems(ctx, "keep", []string{"gone"})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGearService_SetComponents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Replaces the components", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		want := []domain.ItemComponent{
+			{ParentID: "tent", ComponentID: "fly", Quantity: 1},
+			{ParentID: "tent", ComponentID: "stake", Quantity: 8},
+		}
+		tree := &domain.ComponentNode{Item: domain.Item{ID: "tent"}, Quantity: 1, TotalWeightGram: 1200}
+		mockRepo.On("SetComponents", ctx, "tent", want).Return(nil)
+		mockRepo.On("GetComponentTree", ctx, "tent").Return(tree, nil)
+
+		got, err := service.SetComponents(ctx, "tent", []domain.ItemComponent{
+			{ComponentID: " fly ", Quantity: 1},
+			{ComponentID: "stake", Quantity: 8},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, tree, got)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Rejects an invalid quantity", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		_, err := service.SetComponents(ctx, "tent", []domain.ItemComponent{{ComponentID: "fly", Quantity: 0}})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "SetComponents", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGearService_Meters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects a duplicate meter name", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		mockRepo.On("GetByID", ctx, "rope").Return(&domain.Item{ID: "rope"}, nil)
+		mockRepo.On("ListMeters", ctx, "rope").Return([]domain.UsageMeter{{ID: "m1", ItemID: "rope", Name: "Falls"}}, nil)
+
+		_, err := service.CreateMeter(ctx, "rope", " falls ", domain.MeterUnitFalls)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		mockRepo.AssertNotCalled(t, "CreateMeter", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Maintenance counts on one of the item's meters", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+		meterID := "stove-hours"
+		otherID := "other-hours"
+
+		mockRepo.On("GetByID", ctx, "stove").Return(&domain.Item{ID: "stove"}, nil)
+		mockRepo.On("GetMeter", ctx, meterID).Return(&domain.UsageMeter{ID: meterID, ItemID: "stove"}, nil)
+		mockRepo.On("GetMeter", ctx, otherID).Return(&domain.UsageMeter{ID: otherID, ItemID: "lantern"}, nil)
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+		item, err := service.UpdateItem(ctx, "stove", domain.UpdateGearParams{Name: "Stove", MaintenanceInterval: 20, MaintenanceMeterID: &meterID})
+		assert.NoError(t, err)
+		assert.Equal(t, &meterID, item.MaintenanceMeterID)
+
+		_, err = service.UpdateItem(ctx, "stove", domain.UpdateGearParams{Name: "Stove", MaintenanceMeterID: &otherID})
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+
+	t.Run("Rejects a non-positive amount", func(t *testing.T) {
+		mockRepo := new(MockGearRepository)
+		service := NewGearService(mockRepo, new(MockPropertySchemaRepository))
+
+		_, err := service.RecordUsage(ctx, "m1", -2, time.Time{}, "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything)
+	})
+}
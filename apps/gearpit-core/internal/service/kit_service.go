@@ -39,6 +39,10 @@ func (s *kitService) AddItemToKit(ctx context.Context, kitID, itemID string) err
 	return s.repo.AddItem(ctx, kitID, itemID)
 }
 
+func (s *kitService) DeleteKit(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
 func (s *kitService) RemoveItemFromKit(ctx context.Context, kitID, itemID string) error {
 	return s.repo.RemoveItem(ctx, kitID, itemID)
 }
@@ -52,7 +52,7 @@ func (s *loadoutService) calculateWeights(l *domain.Loadout) {
 	var total, base, consumable, worn, long int
 
 	countItem := func(item domain.Item) {
-		w := item.WeightGram
+		w := item.PackWeightGram()
 		total += w
 		switch item.WeightType {
 		case domain.WeightTypeConsumable:
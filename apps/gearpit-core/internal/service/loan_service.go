@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type loanService struct {
+	repo     domain.LoanRepository
+	gears    domain.GearRepository
+	profiles domain.ProfileRepository
+	now      func() time.Time
+}
+
+func NewLoanService(repo domain.LoanRepository, gears domain.GearRepository, profiles domain.ProfileRepository) domain.LoanService {
+	return &loanService{repo: repo, gears: gears, profiles: profiles, now: time.Now}
+}
+
+// CreateLoan lends an item out. An item can only be on one open loan at a time; the
+// check below gives a readable conflict, and a unique index catches concurrent requests.
+func (s *loanService) CreateLoan(ctx context.Context, params domain.SaveLoanParams) (*domain.Loan, error) {
+	if _, err := s.gears.GetByID(ctx, params.ItemID); err != nil {
+		return nil, err
+	}
+	loan := &domain.Loan{
+		ItemID:            params.ItemID,
+		BorrowerProfileID: nonEmptyID(params.BorrowerProfileID),
+		BorrowerContact:   strings.TrimSpace(params.BorrowerContact),
+		LoanedAt:          s.now(),
+		DueAt:             params.DueAt,
+		Note:              params.Note,
+	}
+	if params.LoanedAt != nil {
+		loan.LoanedAt = *params.LoanedAt
+	}
+	if err := s.validate(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	open, err := s.repo.List(ctx, domain.LoanFilter{ItemID: params.ItemID, Status: domain.LoanStatusOpen})
+	if err != nil {
+		return nil, err
+	}
+	if len(open) > 0 {
+		return nil, fmt.Errorf("%w: item is already lent to %s", domain.ErrConflict, open[0].BorrowerName())
+	}
+
+	if err := s.repo.Create(ctx, loan); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, loan.ID)
+}
+
+func (s *loanService) GetLoan(ctx context.Context, id string) (*domain.Loan, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *loanService) ListLoans(ctx context.Context, filter domain.LoanFilter) ([]domain.Loan, error) {
+	return s.repo.List(ctx, filter)
+}
+
+// UpdateLoan changes the borrower, dates or note; a missing due date clears it. The item
+// of a loan cannot change.
+func (s *loanService) UpdateLoan(ctx context.Context, id string, params domain.SaveLoanParams) (*domain.Loan, error) {
+	loan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	loan.BorrowerProfileID = nonEmptyID(params.BorrowerProfileID)
+	loan.BorrowerContact = strings.TrimSpace(params.BorrowerContact)
+	loan.BorrowerProfile = nil
+	if params.LoanedAt != nil {
+		loan.LoanedAt = *params.LoanedAt
+	}
+	loan.DueAt = params.DueAt
+	loan.Note = params.Note
+	if err := s.validate(ctx, loan); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, loan); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *loanService) ReturnLoan(ctx context.Context, id string, returnedAt *time.Time) (*domain.Loan, error) {
+	loan, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !loan.IsOpen() {
+		return nil, fmt.Errorf("%w: loan was already returned", domain.ErrConflict)
+	}
+	at := s.now()
+	if returnedAt != nil {
+		at = *returnedAt
+	}
+	loan.ReturnedAt = &at
+	if err := loan.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, loan); err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (s *loanService) DeleteLoan(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *loanService) ListOverdue(ctx context.Context) ([]domain.OverdueLoan, error) {
+	now := s.now()
+	loans, err := s.repo.ListOverdue(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	overdue := make([]domain.OverdueLoan, 0, len(loans))
+	for _, loan := range loans {
+		overdue = append(overdue, domain.NewOverdueLoan(loan, now))
+	}
+	return overdue, nil
+}
+
+// nonEmptyID treats an empty ID like a missing one.
+func nonEmptyID(id *string) *string {
+	if id == nil || *id == "" {
+		return nil
+	}
+	return id
+}
+
+// validate checks the loan and that a borrowing profile exists.
+func (s *loanService) validate(ctx context.Context, loan *domain.Loan) error {
+	if err := loan.Validate(); err != nil {
+		return err
+	}
+	if loan.BorrowerProfileID != nil {
+		if _, err := s.profiles.GetByID(ctx, *loan.BorrowerProfileID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLoanRepository
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) Create(ctx context.Context, loan *domain.Loan) error {
+	args := m.Called(ctx, loan)
+	return args.Error(0)
+}
+func (m *MockLoanRepository) GetByID(ctx context.Context, id string) (*domain.Loan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Loan), args.Error(1)
+}
+func (m *MockLoanRepository) List(ctx context.Context, filter domain.LoanFilter) ([]domain.Loan, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+func (m *MockLoanRepository) Update(ctx context.Context, loan *domain.Loan) error {
+	args := m.Called(ctx, loan)
+	return args.Error(0)
+}
+func (m *MockLoanRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockLoanRepository) ListOverdue(ctx context.Context, now time.Time) ([]domain.Loan, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+func (m *MockLoanRepository) ListByItems(ctx context.Context, itemIDs []string) ([]domain.Loan, error) {
+	args := m.Called(ctx, itemIDs)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
+// MockProfileRepository
+type MockProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockProfileRepository) Create(ctx context.Context, profile *domain.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+func (m *MockProfileRepository) GetByID(ctx context.Context, id string) (*domain.UserProfile, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserProfile), args.Error(1)
+}
+func (m *MockProfileRepository) List(ctx context.Context) ([]domain.UserProfile, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.UserProfile), args.Error(1)
+}
+func (m *MockProfileRepository) Update(ctx context.Context, profile *domain.UserProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+func (m *MockProfileRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestLoanService_CreateLoan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Lends to a contact", func(t *testing.T) {
+		repo := new(MockLoanRepository)
+		gears := new(MockGearRepository)
+		service := NewLoanService(repo, gears, new(MockProfileRepository))
+
+		gears.On("GetByID", ctx, "tent").Return(&domain.Item{ID: "tent"}, nil)
+		repo.On("List", ctx, domain.LoanFilter{ItemID: "tent", Status: domain.LoanStatusOpen}).Return([]domain.Loan{}, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(loan *domain.Loan) bool {
+			return loan.BorrowerContact == "Aki" && loan.BorrowerProfileID == nil && !loan.LoanedAt.IsZero()
+		})).Run(func(args mock.Arguments) { args.Get(1).(*domain.Loan).ID = "loan-1" }).Return(nil)
+		repo.On("GetByID", ctx, "loan-1").Return(&domain.Loan{ID: "loan-1"}, nil)
+
+		empty := ""
+		loan, err := service.CreateLoan(ctx, domain.SaveLoanParams{ItemID: "tent", BorrowerProfileID: &empty, BorrowerContact: " Aki "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "loan-1", loan.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Item already lent out", func(t *testing.T) {
+		repo := new(MockLoanRepository)
+		gears := new(MockGearRepository)
+		service := NewLoanService(repo, gears, new(MockProfileRepository))
+
+		gears.On("GetByID", ctx, "tent").Return(&domain.Item{ID: "tent"}, nil)
+		repo.On("List", ctx, mock.Anything).Return([]domain.Loan{{BorrowerContact: "Ren"}}, nil)
+
+		_, err := service.CreateLoan(ctx, domain.SaveLoanParams{ItemID: "tent", BorrowerContact: "Aki"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown borrower profile", func(t *testing.T) {
+		repo := new(MockLoanRepository)
+		gears := new(MockGearRepository)
+		profiles := new(MockProfileRepository)
+		service := NewLoanService(repo, gears, profiles)
+		ghost := "ghost"
+
+		gears.On("GetByID", ctx, "tent").Return(&domain.Item{ID: "tent"}, nil)
+		profiles.On("GetByID", ctx, "ghost").Return(nil, domain.ErrNotFound)
+
+		_, err := service.CreateLoan(ctx, domain.SaveLoanParams{ItemID: "tent", BorrowerProfileID: &ghost})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func TestLoanService_UpdateLoan_ClearsDueDate(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockLoanRepository)
+	service := NewLoanService(repo, new(MockGearRepository), new(MockProfileRepository))
+	due := time.Now().AddDate(0, 0, 7)
+
+	repo.On("GetByID", ctx, "loan-1").Return(&domain.Loan{ID: "loan-1", BorrowerContact: "Aki", LoanedAt: time.Now(), DueAt: &due}, nil)
+	repo.On("Update", ctx, mock.MatchedBy(func(loan *domain.Loan) bool { return loan.DueAt == nil })).Return(nil)
+
+	_, err := service.UpdateLoan(ctx, "loan-1", domain.SaveLoanParams{BorrowerContact: "Aki"})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestLoanService_ReturnLoan(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockLoanRepository)
+	service := NewLoanService(repo, new(MockGearRepository), new(MockProfileRepository))
+	returned := time.Now()
+
+	repo.On("GetByID", ctx, "open").Return(&domain.Loan{ID: "open", BorrowerContact: "Aki", LoanedAt: time.Now().AddDate(0, 0, -1)}, nil)
+	repo.On("GetByID", ctx, "closed").Return(&domain.Loan{ID: "closed", ReturnedAt: &returned}, nil)
+	repo.On("Update", ctx, mock.Anything).Return(nil)
+
+	loan, err := service.ReturnLoan(ctx, "open", nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, loan.ReturnedAt)
+
+	_, err = service.ReturnLoan(ctx, "closed", nil)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
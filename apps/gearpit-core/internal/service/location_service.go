@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type locationService struct {
+	repo  domain.LocationRepository
+	gears domain.GearRepository
+	now   func() time.Time
+}
+
+func NewLocationService(repo domain.LocationRepository, gears domain.GearRepository) domain.LocationService {
+	return &locationService{repo: repo, gears: gears, now: time.Now}
+}
+
+func (s *locationService) tree(ctx context.Context) (domain.LocationTree, error) {
+	locations, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewLocationTree(locations), nil
+}
+
+func (s *locationService) CreateLocation(ctx context.Context, params domain.SaveLocationParams) (*domain.Location, error) {
+	location := &domain.Location{
+		Name:        strings.TrimSpace(params.Name),
+		Description: params.Description,
+		ParentID:    params.ParentID,
+	}
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.ValidateParent("", location.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, location); err != nil {
+		return nil, err
+	}
+	tree[location.ID] = *location
+	location.Path = tree.Path(location.ID)
+	return location, nil
+}
+
+func (s *locationService) GetLocation(ctx context.Context, id string) (*domain.Location, error) {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	location.Path = tree.Path(id)
+	return location, nil
+}
+
+func (s *locationService) ListLocations(ctx context.Context) ([]domain.Location, error) {
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tree.WithPaths(), nil
+}
+
+// UpdateLocation renames or re-parents a location; its sub-locations and items move with it.
+func (s *locationService) UpdateLocation(ctx context.Context, id string, params domain.SaveLocationParams) (*domain.Location, error) {
+	location, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	location.Name = strings.TrimSpace(params.Name)
+	location.Description = params.Description
+	location.ParentID = params.ParentID
+	if err := location.Validate(); err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.ValidateParent(id, location.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, location); err != nil {
+		return nil, err
+	}
+	tree[id] = *location
+	location.Path = tree.Path(id)
+	return location, nil
+}
+
+func (s *locationService) DeleteLocation(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *locationService) MoveItem(ctx context.Context, itemID string, params domain.MoveItemParams) (*domain.ItemMove, error) {
+	item, err := s.gears.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if params.LocationID != nil {
+		if _, ok := tree[*params.LocationID]; !ok {
+			return nil, fmt.Errorf("%w: location %s", domain.ErrNotFound, *params.LocationID)
+		}
+	}
+	if derefID(item.LocationID) == derefID(params.LocationID) {
+		return nil, fmt.Errorf("%w: item is already there", domain.ErrInvalidInput)
+	}
+
+	move := &domain.ItemMove{
+		ItemID:         item.ID,
+		FromLocationID: item.LocationID,
+		ToLocationID:   params.LocationID,
+		FromPath:       tree.Path(derefID(item.LocationID)),
+		ToPath:         tree.Path(derefID(params.LocationID)),
+		Note:           strings.TrimSpace(params.Note),
+		MovedAt:        s.now(),
+	}
+	if err := s.repo.MoveItem(ctx, move); err != nil {
+		return nil, err
+	}
+	return move, nil
+}
+
+func (s *locationService) ListMoves(ctx context.Context, itemID string) ([]domain.ItemMove, error) {
+	if _, err := s.gears.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMoves(ctx, itemID)
+}
+
+func (s *locationService) WhereIs(ctx context.Context, query string) ([]domain.ItemWhereabouts, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", domain.ErrInvalidInput)
+	}
+	items, err := s.gears.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.ItemWhereabouts, 0, len(items))
+	for _, item := range items {
+		results = append(results, whereabouts(tree, item))
+	}
+	return results, nil
+}
+
+func (s *locationService) Locate(ctx context.Context, itemID string) (*domain.ItemWhereabouts, error) {
+	item, err := s.gears.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := whereabouts(tree, *item)
+	return &result, nil
+}
+
+func whereabouts(tree domain.LocationTree, item domain.Item) domain.ItemWhereabouts {
+	id := derefID(item.LocationID)
+	trail := tree.Ancestry(id)
+	if trail == nil {
+		trail = []domain.Location{}
+	}
+	return domain.ItemWhereabouts{Item: item, Path: tree.Path(id), Locations: trail}
+}
+
+func derefID(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLocationRepository
+type MockLocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockLocationRepository) Create(ctx context.Context, location *domain.Location) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockLocationRepository) GetByID(ctx context.Context, id string) (*domain.Location, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Location), args.Error(1)
+}
+func (m *MockLocationRepository) List(ctx context.Context) ([]domain.Location, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Location), args.Error(1)
+}
+func (m *MockLocationRepository) Update(ctx context.Context, location *domain.Location) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+func (m *MockLocationRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockLocationRepository) MoveItem(ctx context.Context, move *domain.ItemMove) error {
+	args := m.Called(ctx, move)
+	return args.Error(0)
+}
+func (m *MockLocationRepository) ListMoves(ctx context.Context, itemID string) ([]domain.ItemMove, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]domain.ItemMove), args.Error(1)
+}
+
+func houseLocations() []domain.Location {
+	house := "house"
+	return []domain.Location{
+		{ID: "house", Name: "House"},
+		{ID: "garage", Name: "Garage", ParentID: &house},
+		{ID: "car", Name: "Car"},
+	}
+}
+
+func TestLocationService_MoveItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Records paths of both ends", func(t *testing.T) {
+		repo := new(MockLocationRepository)
+		gears := new(MockGearRepository)
+		service := NewLocationService(repo, gears)
+		garage := "garage"
+		car := "car"
+
+		gears.On("GetByID", ctx, "stove").Return(&domain.Item{ID: "stove", LocationID: &garage}, nil)
+		repo.On("List", ctx).Return(houseLocations(), nil)
+		repo.On("MoveItem", ctx, mock.MatchedBy(func(move *domain.ItemMove) bool {
+			return move.FromPath == "House / Garage" && move.ToPath == "Car" && move.Note == "packed"
+		})).Return(nil)
+
+		move, err := service.MoveItem(ctx, "stove", domain.MoveItemParams{LocationID: &car, Note: " packed "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "stove", move.ItemID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown location", func(t *testing.T) {
+		repo := new(MockLocationRepository)
+		gears := new(MockGearRepository)
+		service := NewLocationService(repo, gears)
+		attic := "attic"
+
+		gears.On("GetByID", ctx, "stove").Return(&domain.Item{ID: "stove"}, nil)
+		repo.On("List", ctx).Return(houseLocations(), nil)
+
+		_, err := service.MoveItem(ctx, "stove", domain.MoveItemParams{LocationID: &attic})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		repo.AssertNotCalled(t, "MoveItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLocationService_UpdateLocation_RejectsCycle(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockLocationRepository)
+	service := NewLocationService(repo, new(MockGearRepository))
+	garage := "garage"
+
+	repo.On("GetByID", ctx, "house").Return(&domain.Location{ID: "house", Name: "House"}, nil)
+	repo.On("List", ctx).Return(houseLocations(), nil)
+
+	_, err := service.UpdateLocation(ctx, "house", domain.SaveLocationParams{Name: "House", ParentID: &garage})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestLocationService_WhereIs(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockLocationRepository)
+	gears := new(MockGearRepository)
+	service := NewLocationService(repo, gears)
+	garage := "garage"
+
+	gears.On("Search", ctx, "stove").Return([]domain.Item{{ID: "stove", LocationID: &garage}, {ID: "spare"}}, nil)
+	repo.On("List", ctx).Return(houseLocations(), nil)
+
+	results, err := service.WhereIs(ctx, " stove ")
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "House / Garage", results[0].Path)
+	assert.Len(t, results[0].Locations, 2)
+	assert.Equal(t, "", results[1].Path)
+	assert.NotNil(t, results[1].Locations)
+}
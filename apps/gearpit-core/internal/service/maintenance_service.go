@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
@@ -10,24 +12,52 @@ import (
 type maintenanceService struct {
 	repo     domain.MaintenanceRepository
 	gearRepo domain.GearRepository // Add GearRepository
+	now      func() time.Time
 }
 
 func NewMaintenanceService(repo domain.MaintenanceRepository, gearRepo domain.GearRepository) domain.MaintenanceService {
-	return &maintenanceService{repo: repo, gearRepo: gearRepo}
+	return &maintenanceService{repo: repo, gearRepo: gearRepo, now: time.Now}
 }
 
 func (s *maintenanceService) AddLog(ctx context.Context, itemID, logType, description string, cost int, performedAt time.Time) (*domain.MaintenanceLog, error) {
 	var log *domain.MaintenanceLog
 
+	// Vehicles remember the odometer so km intervals restart from it
+	readings, err := s.repo.ListOdometerReadings(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	var odometerKm *int
+	if km, ok := domain.OdometerAt(readings, performedAt); ok {
+		odometerKm = &km
+	}
+
 	// Use GearRepository transaction because we need to update Item and create Log
-	err := s.gearRepo.DoInTransaction(ctx, func(txRepo domain.GearRepository) error {
+	err = s.gearRepo.DoInTransaction(ctx, func(txRepo domain.GearRepository) error {
 		// 1. Get Item to snapshot usage
 		item, err := txRepo.GetByID(ctx, itemID)
 		if err != nil {
 			return err
 		}
 
-		// 2. Create Log
+		// 2. Logs matching a rule restart only that rule, which is read off the logs
+		rules, err := s.rulesForItem(ctx, *item)
+		if err != nil {
+			return err
+		}
+		resetCounter := item.RestartsMaintenanceCounter(rules, logType)
+
+		// 3. Restart the meter the item's interval counts on
+		var meterTotal *float64
+		if resetCounter && item.MaintenanceMeterID != nil {
+			total, err := txRepo.MarkMeterServiced(ctx, *item.MaintenanceMeterID)
+			if err != nil {
+				return err
+			}
+			meterTotal = &total
+		}
+
+		// 4. Create Log
 		log = &domain.MaintenanceLog{
 			ItemID:        itemID,
 			Type:          logType,
@@ -35,12 +65,17 @@ func (s *maintenanceService) AddLog(ctx context.Context, itemID, logType, descri
 			Cost:          cost,
 			PerformedAt:   performedAt,
 			SnapshotUsage: item.UsageCount,
+			OdometerKm:    odometerKm,
+			MeterTotal:    meterTotal,
 		}
 		if err := txRepo.AddMaintenanceLog(ctx, log); err != nil {
 			return err
 		}
 
-		// 3. Reset Item Usage
+		// 5. Reset Item Usage
+		if !resetCounter {
+			return nil
+		}
 		item.UsageCount = 0
 		if err := txRepo.Update(ctx, item); err != nil {
 			return err
@@ -61,16 +96,26 @@ func (s *maintenanceService) GetItemLogs(ctx context.Context, itemID string) ([]
 }
 
 func (s *maintenanceService) UpdateLog(ctx context.Context, id, logType, description string, cost int, performedAt time.Time) (*domain.MaintenanceLog, error) {
-	// 更新用。GetByIDがないので、ログIDから取得するメソッドがRepositoryに必要だが、
-	// 簡易的に構築してSaveを呼ぶ（Repositoryの実装依存）
-	// 正しくは GetByID をRepositoryに追加すべき
-	log := &domain.MaintenanceLog{
-		ID:          id,
-		Type:        logType,
-		Description: description,
-		Cost:        cost,
-		PerformedAt: performedAt,
+	// Load the stored log so the item link and usage snapshot survive the save
+	log, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A moved log takes the odometer of its new date, like AddLog does
+	if !performedAt.Equal(log.PerformedAt) {
+		readings, err := s.repo.ListOdometerReadings(ctx, log.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		log.OdometerKm = nil
+		if km, ok := domain.OdometerAt(readings, performedAt); ok {
+			log.OdometerKm = &km
+		}
 	}
+	log.Type = logType
+	log.Description = description
+	log.Cost = cost
+	log.PerformedAt = performedAt
 	if err := s.repo.Update(ctx, log); err != nil {
 		return nil, err
 	}
@@ -80,3 +125,262 @@ func (s *maintenanceService) UpdateLog(ctx context.Context, id, logType, descrip
 func (s *maintenanceService) DeleteLog(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
+
+// AddOdometerReading records the odometer of a vehicle. readAt defaults to now.
+func (s *maintenanceService) AddOdometerReading(ctx context.Context, itemID string, km int, readAt time.Time, note string) (*domain.OdometerReading, error) {
+	if _, err := s.gearRepo.GetByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+	if readAt.IsZero() {
+		readAt = s.now()
+	}
+	reading := &domain.OdometerReading{ItemID: itemID, Km: km, ReadAt: readAt, Note: strings.TrimSpace(note)}
+
+	existing, err := s.repo.ListOdometerReadings(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateOdometerReading(*reading, existing); err != nil {
+		return nil, err
+	}
+	if err := s.repo.AddOdometerReading(ctx, reading); err != nil {
+		return nil, err
+	}
+	return reading, nil
+}
+
+func (s *maintenanceService) ListOdometerReadings(ctx context.Context, itemID string) ([]domain.OdometerReading, error) {
+	return s.repo.ListOdometerReadings(ctx, itemID)
+}
+
+// CreateServiceRule scopes the rule to an item or a category. Its intervals start at the
+// given last service, or now.
+func (s *maintenanceService) CreateServiceRule(ctx context.Context, params domain.SaveServiceRuleParams) (*domain.ServiceRule, error) {
+	itemID, category := strings.TrimSpace(params.ItemID), strings.TrimSpace(params.Category)
+	if (itemID == "") == (category == "") {
+		return nil, fmt.Errorf("%w: rule needs either an itemId or a category", domain.ErrInvalidInput)
+	}
+	rule := &domain.ServiceRule{Category: category}
+	if itemID != "" {
+		if _, err := s.gearRepo.GetByID(ctx, itemID); err != nil {
+			return nil, err
+		}
+		rule.ItemID = &itemID
+	}
+	applyServiceRuleParams(rule, params)
+	if rule.LastServiceAt == nil {
+		now := s.now()
+		rule.LastServiceAt = &now
+	}
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRule(ctx, rule.ID)
+}
+
+// UpdateServiceRule replaces the name, log type, intervals and grace. An omitted last
+// service keeps the stored one.
+func (s *maintenanceService) UpdateServiceRule(ctx context.Context, id string, params domain.SaveServiceRuleParams) (*domain.ServiceRule, error) {
+	rule, err := s.repo.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyServiceRuleParams(rule, params)
+	if err := s.validateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRule(ctx, id)
+}
+
+func applyServiceRuleParams(rule *domain.ServiceRule, params domain.SaveServiceRuleParams) {
+	rule.Name = params.Name
+	rule.LogType = params.LogType
+	rule.IntervalKm = params.IntervalKm
+	rule.MeterName = params.MeterName
+	rule.IntervalUsage = params.IntervalUsage
+	rule.IntervalMonths = params.IntervalMonths
+	rule.GraceUsage = params.GraceUsage
+	rule.GraceDays = params.GraceDays
+	if params.LastServiceKm != nil {
+		rule.LastServiceKm = params.LastServiceKm
+	}
+	if params.LastServiceUsage != nil {
+		rule.LastServiceUsage = params.LastServiceUsage
+	}
+	if params.LastServiceAt != nil {
+		rule.LastServiceAt = params.LastServiceAt
+	}
+}
+
+// validateRule checks the rule and that the meter of an item rule exists. Items of a
+// category may lack the meter; their usage interval stays unknown.
+func (s *maintenanceService) validateRule(ctx context.Context, rule *domain.ServiceRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.ItemID == nil || rule.MeterName == "" {
+		return nil
+	}
+	meters, err := s.gearRepo.ListMeters(ctx, *rule.ItemID)
+	if err != nil {
+		return err
+	}
+	for _, m := range meters {
+		if strings.EqualFold(m.Name, rule.MeterName) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: item has no meter named %q", domain.ErrInvalidInput, rule.MeterName)
+}
+
+func (s *maintenanceService) DeleteServiceRule(ctx context.Context, id string) error {
+	return s.repo.DeleteRule(ctx, id)
+}
+
+func (s *maintenanceService) ListServiceRules(ctx context.Context, category string) ([]domain.ServiceRule, error) {
+	return s.repo.ListCategoryRules(ctx, strings.TrimSpace(category))
+}
+
+func (s *maintenanceService) GetServiceStatus(ctx context.Context, itemID string) ([]domain.ServiceStatus, error) {
+	item, err := s.gearRepo.GetByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.rulesForItem(ctx, *item)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.itemStatuses(ctx, *item, rules)
+	if err != nil {
+		return nil, err
+	}
+	domain.SortServiceStatuses(statuses)
+	return statuses, nil
+}
+
+func (s *maintenanceService) ListDueServices(ctx context.Context) ([]domain.ServiceStatus, error) {
+	itemRules, err := s.repo.ListRules(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	categoryRules, err := s.repo.ListCategoryRules(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Every item with a rule of its own or in a category that has rules, in first-seen order
+	var items []domain.Item
+	seen := map[string]bool{}
+	for _, r := range itemRules {
+		if r.Item != nil && !seen[r.Item.ID] {
+			seen[r.Item.ID] = true
+			items = append(items, *r.Item)
+		}
+	}
+	categories := map[string]bool{}
+	for _, r := range categoryRules {
+		if categories[r.Category] {
+			continue
+		}
+		categories[r.Category] = true
+		members, err := s.gearRepo.FindByProperties(ctx, r.Category, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range members {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				items = append(items, item)
+			}
+		}
+	}
+
+	var due []domain.ServiceStatus
+	for _, item := range items {
+		if item.Status.IsInactive() {
+			continue
+		}
+		rules := domain.RulesForItem(item, itemRules, categoryRules)
+		if len(rules) == 0 {
+			continue
+		}
+		statuses, err := s.itemStatuses(ctx, item, rules)
+		if err != nil {
+			return nil, err
+		}
+		for _, st := range statuses {
+			if st.State.IsDue() {
+				due = append(due, st)
+			}
+		}
+	}
+	domain.SortServiceStatuses(due)
+	return due, nil
+}
+
+// rulesForItem loads the item's own rules and the rules of its category.
+func (s *maintenanceService) rulesForItem(ctx context.Context, item domain.Item) ([]domain.ServiceRule, error) {
+	itemRules, err := s.repo.ListRules(ctx, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	var categoryRules []domain.ServiceRule
+	if category := item.PropertyString("category"); category != "" {
+		if categoryRules, err = s.repo.ListCategoryRules(ctx, category); err != nil {
+			return nil, err
+		}
+	}
+	return domain.RulesForItem(item, itemRules, categoryRules), nil
+}
+
+// itemStatuses loads the item's logs, readings and the meters its rules count on once
+// and evaluates the rules.
+func (s *maintenanceService) itemStatuses(ctx context.Context, item domain.Item, rules []domain.ServiceRule) ([]domain.ServiceStatus, error) {
+	history := domain.ServiceHistory{Item: item}
+	var err error
+	if history.Logs, err = s.repo.GetByItemID(ctx, item.ID); err != nil {
+		return nil, err
+	}
+	if history.Readings, err = s.repo.ListOdometerReadings(ctx, item.ID); err != nil {
+		return nil, err
+	}
+	if meterNames := ruleMeterNames(rules); len(meterNames) > 0 {
+		if history.Meters, err = s.gearRepo.ListMeters(ctx, item.ID); err != nil {
+			return nil, err
+		}
+		for _, m := range history.Meters {
+			if !meterNames[strings.ToLower(m.Name)] {
+				continue
+			}
+			entries, err := s.gearRepo.ListUsageEntries(ctx, m.ID)
+			if err != nil {
+				return nil, err
+			}
+			history.Entries = append(history.Entries, entries...)
+		}
+	}
+
+	now := s.now()
+	statuses := make([]domain.ServiceStatus, 0, len(rules))
+	for _, rule := range rules {
+		statuses = append(statuses, domain.ComputeServiceStatus(rule, history, now))
+	}
+	return statuses, nil
+}
+
+// ruleMeterNames returns the lower-cased names of the meters the rules count on.
+func ruleMeterNames(rules []domain.ServiceRule) map[string]bool {
+	names := map[string]bool{}
+	for _, r := range rules {
+		if r.MeterName != "" {
+			names[strings.ToLower(r.MeterName)] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMaintenanceRepository
+type MockMaintenanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceRepository) Create(ctx context.Context, log *domain.MaintenanceLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) GetByID(ctx context.Context, id string) (*domain.MaintenanceLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MaintenanceLog), args.Error(1)
+}
+func (m *MockMaintenanceRepository) GetByItemID(ctx context.Context, itemID string) ([]domain.MaintenanceLog, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]domain.MaintenanceLog), args.Error(1)
+}
+func (m *MockMaintenanceRepository) Update(ctx context.Context, log *domain.MaintenanceLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) AddOdometerReading(ctx context.Context, reading *domain.OdometerReading) error {
+	args := m.Called(ctx, reading)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) ListOdometerReadings(ctx context.Context, itemID string) ([]domain.OdometerReading, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]domain.OdometerReading), args.Error(1)
+}
+func (m *MockMaintenanceRepository) CreateRule(ctx context.Context, rule *domain.ServiceRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) GetRule(ctx context.Context, id string) (*domain.ServiceRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceRule), args.Error(1)
+}
+func (m *MockMaintenanceRepository) ListRules(ctx context.Context, itemID string) ([]domain.ServiceRule, error) {
+	args := m.Called(ctx, itemID)
+	return args.Get(0).([]domain.ServiceRule), args.Error(1)
+}
+func (m *MockMaintenanceRepository) ListCategoryRules(ctx context.Context, category string) ([]domain.ServiceRule, error) {
+	args := m.Called(ctx, category)
+	return args.Get(0).([]domain.ServiceRule), args.Error(1)
+}
+func (m *MockMaintenanceRepository) UpdateRule(ctx context.Context, rule *domain.ServiceRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+func (m *MockMaintenanceRepository) DeleteRule(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+var bikeReadings = []domain.OdometerReading{
+	{ItemID: "bike", Km: 10000, ReadAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{ItemID: "bike", Km: 14900, ReadAt: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestMaintenanceService_AddLog_SnapshotsOdometer(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears)
+
+	repo.On("ListOdometerReadings", ctx, "bike").Return(bikeReadings, nil)
+	repo.On("ListRules", ctx, "bike").Return([]domain.ServiceRule{}, nil)
+	gears.On("GetByID", ctx, "bike").Return(&domain.Item{ID: "bike", UsageCount: 4, MaintenanceInterval: 10, MaintenanceLogType: "oil"}, nil)
+	gears.On("AddMaintenanceLog", ctx, mock.MatchedBy(func(l *domain.MaintenanceLog) bool {
+		return l.OdometerKm != nil && *l.OdometerKm == 10000 && l.SnapshotUsage == 4
+	})).Return(nil)
+	gears.On("Update", ctx, mock.Anything).Return(nil)
+
+	_, err := svc.AddLog(ctx, "bike", "oil", "", 0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	gears.AssertExpectations(t)
+}
+
+func TestMaintenanceService_UpdateLog_MovesOdometer(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	svc := NewMaintenanceService(repo, new(MockGearRepository))
+	km := 10000
+
+	repo.On("GetByID", ctx, "log-1").Return(&domain.MaintenanceLog{
+		ID: "log-1", ItemID: "bike", Type: "oil", PerformedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), OdometerKm: &km,
+	}, nil)
+	repo.On("ListOdometerReadings", ctx, "bike").Return(bikeReadings, nil)
+	repo.On("Update", ctx, mock.MatchedBy(func(l *domain.MaintenanceLog) bool {
+		return l.OdometerKm != nil && *l.OdometerKm == 14900
+	})).Return(nil)
+
+	_, err := svc.UpdateLog(ctx, "log-1", "oil", "", 0, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestMaintenanceService_AddLog_RestartsMaintenanceMeter(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears)
+	meterID := "stove-hours"
+
+	repo.On("ListOdometerReadings", ctx, "stove").Return([]domain.OdometerReading{}, nil)
+	repo.On("ListRules", ctx, "stove").Return([]domain.ServiceRule{}, nil)
+	gears.On("GetByID", ctx, "stove").Return(&domain.Item{ID: "stove", MaintenanceInterval: 20, MaintenanceLogType: "cleaning", MaintenanceMeterID: &meterID}, nil)
+	gears.On("MarkMeterServiced", ctx, meterID).Return(41.5, nil)
+	gears.On("AddMaintenanceLog", ctx, mock.MatchedBy(func(l *domain.MaintenanceLog) bool {
+		return l.MeterTotal != nil && *l.MeterTotal == 41.5 && l.OdometerKm == nil
+	})).Return(nil)
+	gears.On("Update", ctx, mock.Anything).Return(nil)
+
+	_, err := svc.AddLog(ctx, "stove", "cleaning", "", 0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	gears.AssertExpectations(t)
+}
+
+func TestMaintenanceService_AddLog_KeepsCountersForRuleLogs(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears)
+	meterID := "ski-days"
+	skis := &domain.Item{ID: "skis", UsageCount: 9, MaintenanceMeterID: &meterID, Properties: []byte(`{"category":"Skis"}`)}
+
+	repo.On("ListOdometerReadings", ctx, "skis").Return([]domain.OdometerReading{}, nil)
+	repo.On("ListRules", ctx, "skis").Return([]domain.ServiceRule{}, nil)
+	repo.On("ListCategoryRules", ctx, "Skis").Return([]domain.ServiceRule{{ID: "wax", Category: "Skis", LogType: "wax"}}, nil)
+	gears.On("GetByID", ctx, "skis").Return(skis, nil)
+	gears.On("AddMaintenanceLog", ctx, mock.MatchedBy(func(l *domain.MaintenanceLog) bool {
+		return l.Type == "Wax" && l.MeterTotal == nil
+	})).Return(nil)
+
+	_, err := svc.AddLog(ctx, "skis", "Wax", "", 0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, skis.UsageCount)
+	gears.AssertNotCalled(t, "MarkMeterServiced", mock.Anything, mock.Anything)
+	gears.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMaintenanceService_AddLog_KeepsCounterForOtherLogTypes(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears)
+	meterID := "stove-hours"
+	stove := &domain.Item{ID: "stove", UsageCount: 7, MaintenanceInterval: 20, MaintenanceLogType: "cleaning", MaintenanceMeterID: &meterID}
+
+	repo.On("ListOdometerReadings", ctx, "stove").Return([]domain.OdometerReading{}, nil)
+	repo.On("ListRules", ctx, "stove").Return([]domain.ServiceRule{}, nil)
+	gears.On("GetByID", ctx, "stove").Return(stove, nil)
+	gears.On("AddMaintenanceLog", ctx, mock.MatchedBy(func(l *domain.MaintenanceLog) bool {
+		return l.Type == "repair" && l.SnapshotUsage == 7 && l.MeterTotal == nil
+	})).Return(nil)
+
+	_, err := svc.AddLog(ctx, "stove", "repair", "", 0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, stove.UsageCount)
+	gears.AssertNotCalled(t, "MarkMeterServiced", mock.Anything, mock.Anything)
+	gears.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMaintenanceService_AddLog_RestartsUntypedInterval(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears)
+	rope := &domain.Item{ID: "rope", UsageCount: 12, MaintenanceInterval: 10}
+
+	repo.On("ListOdometerReadings", ctx, "rope").Return([]domain.OdometerReading{}, nil)
+	repo.On("ListRules", ctx, "rope").Return([]domain.ServiceRule{}, nil)
+	gears.On("GetByID", ctx, "rope").Return(rope, nil)
+	gears.On("AddMaintenanceLog", ctx, mock.Anything).Return(nil)
+	gears.On("Update", ctx, mock.MatchedBy(func(item *domain.Item) bool { return item.UsageCount == 0 })).Return(nil)
+
+	_, err := svc.AddLog(ctx, "rope", "inspection", "", 0, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	gears.AssertExpectations(t)
+}
+
+func TestMaintenanceService_CreateServiceRule_Scope(t *testing.T) {
+	ctx := context.Background()
+	months := 12
+	tests := []struct {
+		name   string
+		params domain.SaveServiceRuleParams
+	}{
+		{"Neither item nor category", domain.SaveServiceRuleParams{Name: "Grind", LogType: "grind", IntervalMonths: &months}},
+		{"Both item and category", domain.SaveServiceRuleParams{ItemID: "skis", Category: "Skis", Name: "Grind", LogType: "grind", IntervalMonths: &months}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockMaintenanceRepository)
+			svc := NewMaintenanceService(repo, new(MockGearRepository))
+
+			_, err := svc.CreateServiceRule(ctx, tt.params)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+			repo.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMaintenanceService_AddOdometerReading(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects a lower reading", func(t *testing.T) {
+		repo := new(MockMaintenanceRepository)
+		gears := new(MockGearRepository)
+		svc := NewMaintenanceService(repo, gears)
+
+		gears.On("GetByID", ctx, "bike").Return(&domain.Item{ID: "bike"}, nil)
+		repo.On("ListOdometerReadings", ctx, "bike").Return(bikeReadings, nil)
+
+		_, err := svc.AddOdometerReading(ctx, "bike", 12000, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		repo.AssertNotCalled(t, "AddOdometerReading", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Defaults to now", func(t *testing.T) {
+		repo := new(MockMaintenanceRepository)
+		gears := new(MockGearRepository)
+		svc := NewMaintenanceService(repo, gears).(*maintenanceService)
+		now := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		gears.On("GetByID", ctx, "bike").Return(&domain.Item{ID: "bike"}, nil)
+		repo.On("ListOdometerReadings", ctx, "bike").Return(bikeReadings, nil)
+		repo.On("AddOdometerReading", ctx, mock.Anything).Return(nil)
+
+		reading, err := svc.AddOdometerReading(ctx, "bike", 15200, time.Time{}, " trip ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, now, reading.ReadAt)
+		assert.Equal(t, "trip", reading.Note)
+	})
+}
+
+func TestMaintenanceService_ListDueServices(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	gears := new(MockGearRepository)
+	svc := NewMaintenanceService(repo, gears).(*maintenanceService)
+	svc.now = func() time.Time { return time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC) }
+
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fiveThousand, twelve, fiveHundred := 5000, 12, 500
+	ten, five := 10000, 5.0
+	bikeID := "bike"
+	bike := &domain.Item{ID: bikeID}
+	skis := domain.Item{ID: "skis", Properties: []byte(`{"category":"Skis"}`)}
+	rules := []domain.ServiceRule{
+		{ID: "oil", ItemID: &bikeID, Item: bike, Name: "Oil", LogType: "oil", IntervalKm: &fiveThousand, IntervalMonths: &twelve, LastServiceKm: &ten, LastServiceAt: &jan},
+		{ID: "chain", ItemID: &bikeID, Item: bike, Name: "Chain", LogType: "chain", IntervalKm: &fiveHundred, LastServiceAt: &jan},
+	}
+	repo.On("ListRules", ctx, "").Return(rules, nil)
+	repo.On("ListCategoryRules", ctx, "").Return([]domain.ServiceRule{
+		{ID: "wax", Category: "Skis", Name: "Wax", LogType: "wax", MeterName: "Ski days", IntervalUsage: &five, LastServiceAt: &jan},
+	}, nil)
+	gears.On("FindByProperties", ctx, "Skis", mock.Anything).Return([]domain.Item{
+		skis, {ID: "old-skis", Status: domain.ItemStatusRetired, Properties: []byte(`{"category":"Skis"}`)},
+	}, nil)
+	repo.On("GetByItemID", ctx, "bike").Return([]domain.MaintenanceLog{
+		{Type: "chain", PerformedAt: time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), OdometerKm: &[]int{14850}[0]},
+	}, nil).Once()
+	repo.On("ListOdometerReadings", ctx, "bike").Return(bikeReadings, nil).Once()
+	repo.On("GetByItemID", ctx, "skis").Return([]domain.MaintenanceLog{}, nil).Once()
+	repo.On("ListOdometerReadings", ctx, "skis").Return([]domain.OdometerReading{}, nil).Once()
+	gears.On("ListMeters", ctx, "skis").Return([]domain.UsageMeter{{ID: "ski-days", Name: "Ski days", Unit: domain.MeterUnitDays, Total: 6}}, nil).Once()
+	gears.On("ListUsageEntries", ctx, "ski-days").Return([]domain.UsageEntry{
+		{MeterID: "ski-days", Amount: 6, RecordedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	due, err := svc.ListDueServices(ctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, due, 2) {
+		assert.Equal(t, "wax", due[0].Rule.ID)
+		assert.Equal(t, "skis", due[0].ItemID)
+		assert.Equal(t, domain.ServiceOverdue, due[0].State)
+		assert.Equal(t, "oil", due[1].Rule.ID)
+		assert.Equal(t, domain.ServiceDueSoon, due[1].State)
+		assert.Equal(t, 100, *due[1].RemainingKm)
+	}
+	repo.AssertExpectations(t)
+	gears.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type pcBuildService struct {
+	repo  domain.PCBuildRepository
+	gears domain.GearRepository
+}
+
+func NewPCBuildService(repo domain.PCBuildRepository, gears domain.GearRepository) domain.PCBuildService {
+	return &pcBuildService{repo: repo, gears: gears}
+}
+
+func (s *pcBuildService) CreateBuild(ctx context.Context, params domain.SavePCBuildParams) (*domain.PCBuild, error) {
+	build := &domain.PCBuild{}
+	if err := s.apply(ctx, build, params); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, build); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, build.ID)
+}
+
+func (s *pcBuildService) GetBuild(ctx context.Context, id string) (*domain.PCBuild, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *pcBuildService) ListBuilds(ctx context.Context) ([]domain.PCBuild, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *pcBuildService) UpdateBuild(ctx context.Context, id string, params domain.SavePCBuildParams) (*domain.PCBuild, error) {
+	build, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, build, params); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, build); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *pcBuildService) DeleteBuild(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *pcBuildService) GetReport(ctx context.Context, id string) (*domain.BuildReport, error) {
+	build, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	report := build.Report()
+	return &report, nil
+}
+
+// apply validates params and copies them onto build.
+func (s *pcBuildService) apply(ctx context.Context, build *domain.PCBuild, params domain.SavePCBuildParams) error {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return fmt.Errorf("%w: build name is required", domain.ErrInvalidInput)
+	}
+	parts, err := domain.ValidateBuildParts(params.Parts)
+	if err != nil {
+		return err
+	}
+	for _, p := range parts {
+		if _, err := s.gears.GetByID(ctx, p.ItemID); err != nil {
+			return err
+		}
+	}
+	build.Name = name
+	build.Description = params.Description
+	build.Parts = parts
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPCBuildRepository
+type MockPCBuildRepository struct {
+	mock.Mock
+}
+
+func (m *MockPCBuildRepository) Create(ctx context.Context, build *domain.PCBuild) error {
+	args := m.Called(ctx, build)
+	return args.Error(0)
+}
+func (m *MockPCBuildRepository) GetByID(ctx context.Context, id string) (*domain.PCBuild, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PCBuild), args.Error(1)
+}
+func (m *MockPCBuildRepository) List(ctx context.Context) ([]domain.PCBuild, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.PCBuild), args.Error(1)
+}
+func (m *MockPCBuildRepository) Update(ctx context.Context, build *domain.PCBuild) error {
+	args := m.Called(ctx, build)
+	return args.Error(0)
+}
+func (m *MockPCBuildRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestPCBuildService_CreateBuild(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Creates with normalized parts", func(t *testing.T) {
+		repo := new(MockPCBuildRepository)
+		gears := new(MockGearRepository)
+		svc := NewPCBuildService(repo, gears)
+
+		gears.On("GetByID", ctx, "cpu-1").Return(&domain.Item{ID: "cpu-1"}, nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(b *domain.PCBuild) bool {
+			return b.Name == "Workstation" && len(b.Parts) == 1 && b.Parts[0].Slot == domain.BuildSlotCPU
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.PCBuild).ID = "build-1"
+		}).Return(nil)
+		repo.On("GetByID", ctx, "build-1").Return(&domain.PCBuild{ID: "build-1", Name: "Workstation"}, nil)
+
+		build, err := svc.CreateBuild(ctx, domain.SavePCBuildParams{
+			Name:  " Workstation ",
+			Parts: []domain.BuildPart{{ItemID: "cpu-1", Slot: "CPU"}},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "build-1", build.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown item", func(t *testing.T) {
+		repo := new(MockPCBuildRepository)
+		gears := new(MockGearRepository)
+		svc := NewPCBuildService(repo, gears)
+
+		gears.On("GetByID", ctx, "gone").Return(nil, domain.ErrNotFound)
+
+		_, err := svc.CreateBuild(ctx, domain.SavePCBuildParams{
+			Name:  "Workstation",
+			Parts: []domain.BuildPart{{ItemID: "gone", Slot: domain.BuildSlotGPU}},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Name is required", func(t *testing.T) {
+		svc := NewPCBuildService(new(MockPCBuildRepository), new(MockGearRepository))
+
+		_, err := svc.CreateBuild(ctx, domain.SavePCBuildParams{Name: " "})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
@@ -14,13 +14,14 @@ func NewProfileService(repo domain.ProfileRepository) domain.ProfileService {
 	return &profileService{repo: repo}
 }
 
-func (s *profileService) CreateProfile(ctx context.Context, name string, height, weight float64, age int, gender string) (*domain.UserProfile, error) {
+func (s *profileService) CreateProfile(ctx context.Context, name string, height, weight float64, age int, gender string, unitSystem domain.UnitSystem) (*domain.UserProfile, error) {
 	profile := &domain.UserProfile{
-		Name:     name,
-		HeightCm: height,
-		WeightKg: weight,
-		Age:      age,
-		Gender:   gender,
+		Name:       name,
+		HeightCm:   height,
+		WeightKg:   weight,
+		Age:        age,
+		Gender:     gender,
+		UnitSystem: unitSystem,
 	}
 	if err := s.repo.Create(ctx, profile); err != nil {
 		return nil, err
@@ -36,7 +37,7 @@ func (s *profileService) ListProfiles(ctx context.Context) ([]domain.UserProfile
 	return s.repo.List(ctx)
 }
 
-func (s *profileService) UpdateProfile(ctx context.Context, id, name string, height, weight float64, age int, gender string) (*domain.UserProfile, error) {
+func (s *profileService) UpdateProfile(ctx context.Context, id, name string, height, weight float64, age int, gender string, unitSystem domain.UnitSystem) (*domain.UserProfile, error) {
 	profile, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -47,6 +48,9 @@ func (s *profileService) UpdateProfile(ctx context.Context, id, name string, hei
 	profile.WeightKg = weight
 	profile.Age = age
 	profile.Gender = gender
+	if unitSystem != "" {
+		profile.UnitSystem = unitSystem
+	}
 
 	if err := s.repo.Update(ctx, profile); err != nil {
 		return nil, err
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"gorm.io/datatypes"
+)
+
+type propertySchemaService struct {
+	repo domain.PropertySchemaRepository
+}
+
+func NewPropertySchemaService(repo domain.PropertySchemaRepository) domain.PropertySchemaService {
+	return &propertySchemaService{repo: repo}
+}
+
+func (s *propertySchemaService) CreateSchema(ctx context.Context, category, description string, fields []domain.PropertyField) (*domain.PropertySchema, error) {
+	schema := &domain.PropertySchema{
+		Category:    category,
+		Description: description,
+		Fields:      datatypes.JSONSlice[domain.PropertyField](fields),
+	}
+	if err := schema.ValidateDefinition(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByCategory(ctx, category); err == nil {
+		return nil, fmt.Errorf("%w: category %q already has a property schema", domain.ErrConflict, category)
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (s *propertySchemaService) GetSchema(ctx context.Context, category string) (*domain.PropertySchema, error) {
+	return s.repo.GetByCategory(ctx, category)
+}
+
+func (s *propertySchemaService) ListSchemas(ctx context.Context) ([]domain.PropertySchema, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateSchema replaces the fields of a schema. Existing items are validated
+// against the new definition the next time they are saved.
+func (s *propertySchemaService) UpdateSchema(ctx context.Context, category, description string, fields []domain.PropertyField) (*domain.PropertySchema, error) {
+	schema, err := s.repo.GetByCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Description = description
+	schema.Fields = datatypes.JSONSlice[domain.PropertyField](fields)
+	if err := schema.ValidateDefinition(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (s *propertySchemaService) DeleteSchema(ctx context.Context, category string) error {
+	return s.repo.Delete(ctx, category)
+}
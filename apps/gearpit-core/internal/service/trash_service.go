@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type trashService struct {
+	repo        domain.TrashRepository
+	attachments domain.AttachmentCleaner
+	retention   time.Duration
+	now         func() time.Time
+}
+
+// NewTrashService keeps trashed rows for retention (domain.DefaultTrashRetention when <= 0).
+// Attachments of purged rows are removed through attachments.
+func NewTrashService(repo domain.TrashRepository, attachments domain.AttachmentCleaner, retention time.Duration) domain.TrashService {
+	if retention <= 0 {
+		retention = domain.DefaultTrashRetention
+	}
+	return &trashService{repo: repo, attachments: attachments, retention: retention, now: time.Now}
+}
+
+func (s *trashService) ListTrash(ctx context.Context) ([]domain.TrashEntry, error) {
+	entries, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].PurgeAt = entries[i].DeletedAt.Add(s.retention)
+	}
+	return entries, nil
+}
+
+func (s *trashService) Restore(ctx context.Context, entity domain.TrashEntity, id string) error {
+	return s.repo.Restore(ctx, entity, id)
+}
+
+func (s *trashService) Purge(ctx context.Context, entity domain.TrashEntity, id string) error {
+	if err := s.repo.Purge(ctx, entity, id); err != nil {
+		return err
+	}
+	return s.cleanupAttachments(ctx)
+}
+
+func (s *trashService) PurgeExpired(ctx context.Context) (domain.PurgeResult, error) {
+	result, err := s.repo.PurgeDeletedBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return nil, err
+	}
+	return result, s.cleanupAttachments(ctx)
+}
+
+// cleanupAttachments runs after every purge; leftovers are retried by the next one.
+func (s *trashService) cleanupAttachments(ctx context.Context) error {
+	if _, err := s.attachments.CleanupOrphans(ctx); err != nil {
+		return fmt.Errorf("purged, but failed to clean up attachments: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) List(ctx context.Context) ([]domain.TrashEntry, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.TrashEntry), args.Error(1)
+}
+func (m *MockTrashRepository) Restore(ctx context.Context, entity domain.TrashEntity, id string) error {
+	args := m.Called(ctx, entity, id)
+	return args.Error(0)
+}
+func (m *MockTrashRepository) Purge(ctx context.Context, entity domain.TrashEntity, id string) error {
+	args := m.Called(ctx, entity, id)
+	return args.Error(0)
+}
+func (m *MockTrashRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (domain.PurgeResult, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(domain.PurgeResult), args.Error(1)
+}
+
+type MockAttachmentCleaner struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentCleaner) CleanupOrphans(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTrashService_Retention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	deletedAt := now.Add(-48 * time.Hour)
+
+	mockRepo := new(MockTrashRepository)
+	cleaner := new(MockAttachmentCleaner)
+	svc := NewTrashService(mockRepo, cleaner, 7*24*time.Hour).(*trashService)
+	svc.now = func() time.Time { return now }
+
+	mockRepo.On("List", ctx).Return([]domain.TrashEntry{{Entity: domain.TrashItems, ID: "item-1", DeletedAt: deletedAt}}, nil)
+	mockRepo.On("PurgeDeletedBefore", ctx, now.Add(-7*24*time.Hour)).Return(domain.PurgeResult{domain.TrashItems: 2}, nil)
+	cleaner.On("CleanupOrphans", ctx).Return(1, nil)
+
+	entries, err := svc.ListTrash(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, deletedAt.Add(7*24*time.Hour), entries[0].PurgeAt)
+
+	result, err := svc.PurgeExpired(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result[domain.TrashItems])
+	mockRepo.AssertExpectations(t)
+	cleaner.AssertExpectations(t)
+}
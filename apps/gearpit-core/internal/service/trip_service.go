@@ -2,17 +2,19 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 )
 
 type tripService struct {
-	repo domain.TripRepository
+	repo  domain.TripRepository
+	loans domain.LoanRepository
 }
 
-func NewTripService(repo domain.TripRepository) domain.TripService {
-	return &tripService{repo: repo}
+func NewTripService(repo domain.TripRepository, loans domain.LoanRepository) domain.TripService {
+	return &tripService{repo: repo, loans: loans}
 }
 
 // 修正: userProfileID 引数を追加, durationDaysを追加, plannedHikingHoursを追加
@@ -46,14 +48,18 @@ func (s *tripService) GetTrip(ctx context.Context, id string) (*domain.Trip, err
 		bodyWeightKg = trip.UserProfile.WeightKg
 	}
 
-	var packWeightKg float64
+	var packWeightGram int
 	for _, ti := range trip.TripItems {
-		itemWeightKg := float64(ti.Item.WeightGram*ti.Quantity) / 1000.0
-		packWeightKg += itemWeightKg
+		packWeightGram += ti.Item.PackWeightGram() * ti.Quantity
 	}
+	trip.PackWeightGram = packWeightGram
+	packWeightKg := float64(packWeightGram) / 1000.0
 
 	trip.PredictedHydrationML = domain.CalculateHydration(bodyWeightKg, packWeightKg, trip.PlannedHikingHours)
 	trip.PredictedCalories = domain.CalculateCalories(bodyWeightKg, packWeightKg, trip.PlannedHikingHours)
+	if trip.Warnings, err = s.warnings(ctx, trip); err != nil {
+		return nil, err
+	}
 
 	return trip, nil
 }
@@ -87,14 +93,18 @@ func (s *tripService) UpdateTrip(ctx context.Context, id, name, description, loc
 		bodyWeightKg = trip.UserProfile.WeightKg
 	}
 
-	var packWeightKg float64
+	var packWeightGram int
 	for _, ti := range trip.TripItems {
-		itemWeightKg := float64(ti.Item.WeightGram*ti.Quantity) / 1000.0
-		packWeightKg += itemWeightKg
+		packWeightGram += ti.Item.PackWeightGram() * ti.Quantity
 	}
+	trip.PackWeightGram = packWeightGram
+	packWeightKg := float64(packWeightGram) / 1000.0
 
 	trip.PredictedHydrationML = domain.CalculateHydration(bodyWeightKg, packWeightKg, trip.PlannedHikingHours)
 	trip.PredictedCalories = domain.CalculateCalories(bodyWeightKg, packWeightKg, trip.PlannedHikingHours)
+	if trip.Warnings, err = s.warnings(ctx, trip); err != nil {
+		return nil, err
+	}
 
 	return trip, nil
 }
@@ -123,7 +133,17 @@ func (s *tripService) CompleteTrip(ctx context.Context, id string) error {
 			return err
 		}
 
-		// 4. Update Trip Status
+		// 4. Add the trip's use to the items' usage meters
+		if err := recordTripMeterUsages(ctx, txRepo, trip, increment); err != nil {
+			return err
+		}
+
+		// 5. Take consumables out of stock
+		if err := txRepo.DecrementConsumableStock(ctx, id); err != nil {
+			return err
+		}
+
+		// 6. Update Trip Status
 		trip.Status = "completed"
 		if err := txRepo.Update(ctx, trip); err != nil {
 			return err
@@ -133,13 +153,63 @@ func (s *tripService) CompleteTrip(ctx context.Context, id string) error {
 	})
 }
 
+// recordTripMeterUsages records one entry per meter the trip used, dated at the trip's end.
+func recordTripMeterUsages(ctx context.Context, txRepo domain.TripRepository, trip *domain.Trip, days int) error {
+	meters, err := txRepo.ListTripMeters(ctx, trip.ID)
+	if err != nil {
+		return err
+	}
+	recorded, err := txRepo.ListMeterUsages(ctx, trip.ID)
+	if err != nil {
+		return err
+	}
+	recordedAt := trip.EndDate
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+	amounts := domain.TripMeterAmounts(meters, recorded, days)
+	entries := make([]domain.UsageEntry, 0, len(amounts))
+	for _, m := range meters {
+		if amount, ok := amounts[m.ID]; ok {
+			tripID := trip.ID
+			entries = append(entries, domain.UsageEntry{MeterID: m.ID, TripID: &tripID, Amount: amount, Note: trip.Name, RecordedAt: recordedAt})
+		}
+	}
+	return txRepo.RecordMeterUsages(ctx, entries)
+}
+
 func (s *tripService) DeleteTrip(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
 
 // 追加: 個数更新用
-func (s *tripService) AddOrUpdateItem(ctx context.Context, tripID string, itemID string, quantity int) error {
-	return s.repo.UpsertItem(ctx, tripID, itemID, quantity)
+func (s *tripService) AddOrUpdateItem(ctx context.Context, tripID string, itemID string, quantity int) ([]domain.TripWarning, error) {
+	trip, err := s.repo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertItem(ctx, tripID, itemID, quantity); err != nil {
+		return nil, err
+	}
+
+	loans, err := s.loans.ListByItems(ctx, []string{itemID})
+	if err != nil {
+		return nil, err
+	}
+	return trip.LoanWarnings(loans), nil
+}
+
+// warnings collects expiry and loan warnings for the trip's items.
+func (s *tripService) warnings(ctx context.Context, trip *domain.Trip) ([]domain.TripWarning, error) {
+	itemIDs := make([]string, 0, len(trip.TripItems))
+	for _, ti := range trip.TripItems {
+		itemIDs = append(itemIDs, ti.ItemID)
+	}
+	loans, err := s.loans.ListByItems(ctx, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	return append(trip.ExpiryWarnings(), trip.LoanWarnings(loans)...), nil
 }
 
 func (s *tripService) RemoveItemFromTrip(ctx context.Context, tripID string, itemID string) error {
@@ -148,3 +218,63 @@ func (s *tripService) RemoveItemFromTrip(ctx context.Context, tripID string, ite
 	// 先ほどのステップで RemoveItem(単体) を追加したのでそれを呼ぶ。
 	return s.repo.RemoveItem(ctx, tripID, itemID)
 }
+
+func (s *tripService) SetMeterUsage(ctx context.Context, tripID, meterID string, amount float64) ([]domain.TripMeterUsage, error) {
+	trip, err := s.repo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.Status == "completed" {
+		return nil, fmt.Errorf("%w: trip is already completed", domain.ErrConflict)
+	}
+	if amount == 0 {
+		if err := s.repo.RemoveMeterUsage(ctx, tripID, meterID); err != nil {
+			return nil, err
+		}
+		return s.repo.ListMeterUsages(ctx, tripID)
+	}
+	if err := domain.ValidateMeterAmount(amount); err != nil {
+		return nil, err
+	}
+	meters, err := s.repo.ListTripMeters(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, m := range meters {
+		found = found || m.ID == meterID
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: meter %s does not belong to an item on the trip", domain.ErrInvalidInput, meterID)
+	}
+	if err := s.repo.UpsertMeterUsage(ctx, &domain.TripMeterUsage{TripID: tripID, MeterID: meterID, Amount: amount}); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMeterUsages(ctx, tripID)
+}
+
+func (s *tripService) ListMeterUsages(ctx context.Context, tripID string) ([]domain.TripMeterUsage, error) {
+	if _, err := s.repo.GetByID(ctx, tripID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMeterUsages(ctx, tripID)
+}
+
+// ShoppingList compares stocked consumables with what planned trips starting in the next days days need.
+func (s *tripService) ShoppingList(ctx context.Context, days int) ([]domain.ShoppingListLine, error) {
+	if err := domain.ValidateWindowDays(days); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	items, err := s.repo.ListStockedConsumables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	needs, err := s.repo.ListConsumableNeeds(ctx, today, today.AddDate(0, 0, days+1))
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildShoppingList(items, needs), nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTripRepository
+type MockTripRepository struct {
+	mock.Mock
+}
+
+func (m *MockTripRepository) Create(ctx context.Context, trip *domain.Trip) error {
+	args := m.Called(ctx, trip)
+	return args.Error(0)
+}
+func (m *MockTripRepository) GetByID(ctx context.Context, id string) (*domain.Trip, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Trip), args.Error(1)
+}
+func (m *MockTripRepository) List(ctx context.Context) ([]domain.Trip, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Trip), args.Error(1)
+}
+func (m *MockTripRepository) ListWithItems(ctx context.Context) ([]domain.Trip, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Trip), args.Error(1)
+}
+func (m *MockTripRepository) Update(ctx context.Context, trip *domain.Trip) error {
+	args := m.Called(ctx, trip)
+	return args.Error(0)
+}
+func (m *MockTripRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockTripRepository) UpsertItem(ctx context.Context, tripID string, itemID string, quantity int) error {
+	args := m.Called(ctx, tripID, itemID, quantity)
+	return args.Error(0)
+}
+func (m *MockTripRepository) RemoveItem(ctx context.Context, tripID string, itemID string) error {
+	args := m.Called(ctx, tripID, itemID)
+	return args.Error(0)
+}
+func (m *MockTripRepository) SetItems(ctx context.Context, tripID string, quantities map[string]int) error {
+	args := m.Called(ctx, tripID, quantities)
+	return args.Error(0)
+}
+func (m *MockTripRepository) DoInTransaction(ctx context.Context, fn func(txRepo domain.TripRepository) error) error {
+	return fn(m)
+}
+func (m *MockTripRepository) IncrementItemUsages(ctx context.Context, tripID string, increment int) error {
+	args := m.Called(ctx, tripID, increment)
+	return args.Error(0)
+}
+func (m *MockTripRepository) DecrementConsumableStock(ctx context.Context, tripID string) error {
+	args := m.Called(ctx, tripID)
+	return args.Error(0)
+}
+func (m *MockTripRepository) UpsertMeterUsage(ctx context.Context, usage *domain.TripMeterUsage) error {
+	args := m.Called(ctx, usage)
+	return args.Error(0)
+}
+func (m *MockTripRepository) RemoveMeterUsage(ctx context.Context, tripID, meterID string) error {
+	args := m.Called(ctx, tripID, meterID)
+	return args.Error(0)
+}
+func (m *MockTripRepository) ListMeterUsages(ctx context.Context, tripID string) ([]domain.TripMeterUsage, error) {
+	args := m.Called(ctx, tripID)
+	return args.Get(0).([]domain.TripMeterUsage), args.Error(1)
+}
+func (m *MockTripRepository) ListTripMeters(ctx context.Context, tripID string) ([]domain.UsageMeter, error) {
+	args := m.Called(ctx, tripID)
+	return args.Get(0).([]domain.UsageMeter), args.Error(1)
+}
+func (m *MockTripRepository) RecordMeterUsages(ctx context.Context, entries []domain.UsageEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+func (m *MockTripRepository) ListStockedConsumables(ctx context.Context) ([]domain.Item, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Item), args.Error(1)
+}
+func (m *MockTripRepository) ListConsumableNeeds(ctx context.Context, from, to time.Time) ([]domain.ConsumableNeed, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.ConsumableNeed), args.Error(1)
+}
+
+func TestCompleteTrip_DecrementsStock(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, new(MockLoanRepository))
+
+	mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", Status: "planned", DurationDays: 2}, nil)
+	mockRepo.On("IncrementItemUsages", ctx, "trip-1", 2).Return(nil)
+	mockRepo.On("ListTripMeters", ctx, "trip-1").Return([]domain.UsageMeter{}, nil)
+	mockRepo.On("ListMeterUsages", ctx, "trip-1").Return([]domain.TripMeterUsage{}, nil)
+	mockRepo.On("RecordMeterUsages", ctx, []domain.UsageEntry{}).Return(nil)
+	mockRepo.On("DecrementConsumableStock", ctx, "trip-1").Return(nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(trip *domain.Trip) bool { return trip.Status == "completed" })).Return(nil)
+
+	err := service.CompleteTrip(ctx, "trip-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCompleteTrip_RecordsMeterUsage(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, new(MockLoanRepository))
+	end := time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", Name: "Hakuba", Status: "planned", DurationDays: 3, EndDate: end}, nil)
+	mockRepo.On("IncrementItemUsages", ctx, "trip-1", 3).Return(nil)
+	mockRepo.On("ListTripMeters", ctx, "trip-1").Return([]domain.UsageMeter{
+		{ID: "ski-days", Unit: domain.MeterUnitDays},
+		{ID: "rope-falls", Unit: domain.MeterUnitFalls},
+		{ID: "stove-hours", Unit: domain.MeterUnitHours},
+	}, nil)
+	mockRepo.On("ListMeterUsages", ctx, "trip-1").Return([]domain.TripMeterUsage{{TripID: "trip-1", MeterID: "rope-falls", Amount: 2}}, nil)
+	mockRepo.On("RecordMeterUsages", ctx, mock.MatchedBy(func(entries []domain.UsageEntry) bool {
+		return len(entries) == 2 &&
+			entries[0].MeterID == "ski-days" && entries[0].Amount == 3 &&
+			entries[1].MeterID == "rope-falls" && entries[1].Amount == 2 &&
+			*entries[1].TripID == "trip-1" && entries[1].RecordedAt.Equal(end)
+	})).Return(nil)
+	mockRepo.On("DecrementConsumableStock", ctx, "trip-1").Return(nil)
+	mockRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+	err := service.CompleteTrip(ctx, "trip-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetMeterUsage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects meters of items not on the trip", func(t *testing.T) {
+		mockRepo := new(MockTripRepository)
+		service := NewTripService(mockRepo, new(MockLoanRepository))
+
+		mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", Status: "planned"}, nil)
+		mockRepo.On("ListTripMeters", ctx, "trip-1").Return([]domain.UsageMeter{{ID: "rope-falls"}}, nil)
+
+		_, err := service.SetMeterUsage(ctx, "trip-1", "stove-hours", 1.5)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpsertMeterUsage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects completed trips", func(t *testing.T) {
+		mockRepo := new(MockTripRepository)
+		service := NewTripService(mockRepo, new(MockLoanRepository))
+
+		mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", Status: "completed"}, nil)
+
+		_, err := service.SetMeterUsage(ctx, "trip-1", "rope-falls", 2)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+}
+
+func TestGetTrip_CountsAssembliesByRolledUpWeight(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	loans := new(MockLoanRepository)
+	service := NewTripService(mockRepo, loans)
+	tentTotal := 1450 // Shell itemized into fly, inner and poles
+
+	mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", TripItems: []domain.TripItem{
+		{ItemID: "tent", Quantity: 1, Item: domain.Item{ID: "tent", WeightGram: 0, TotalWeightGram: &tentTotal}},
+		{ItemID: "stove", Quantity: 2, Item: domain.Item{ID: "stove", WeightGram: 80}},
+	}}, nil)
+	loans.On("ListByItems", ctx, []string{"tent", "stove"}).Return([]domain.Loan{}, nil)
+
+	trip, err := service.GetTrip(ctx, "trip-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1610, trip.PackWeightGram)
+}
+
+func TestCompleteTrip_AlreadyCompleted(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, new(MockLoanRepository))
+
+	mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", Status: "completed"}, nil)
+
+	err := service.CompleteTrip(ctx, "trip-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "DecrementConsumableStock", ctx, "trip-1")
+}
+
+func TestShoppingList(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, new(MockLoanRepository))
+	stock := 1
+
+	mockRepo.On("ListStockedConsumables", ctx).Return([]domain.Item{{ID: "gas", StockQuantity: &stock}}, nil)
+	mockRepo.On("ListConsumableNeeds", ctx, mock.Anything, mock.MatchedBy(func(to time.Time) bool {
+		return to.After(time.Now().AddDate(0, 0, 14))
+	})).Return([]domain.ConsumableNeed{{ItemID: "gas", TripID: "trip-1", Quantity: 3}}, nil)
+
+	lines, err := service.ShoppingList(ctx, 14)
+
+	assert.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, 2, lines[0].ToBuy)
+}
+
+func TestAddOrUpdateItem_WarnsAboutLoans(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	loans := new(MockLoanRepository)
+	service := NewTripService(mockRepo, loans)
+	start := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	due := start.AddDate(0, 0, 5)
+
+	mockRepo.On("GetByID", ctx, "trip-1").Return(&domain.Trip{ID: "trip-1", StartDate: start, EndDate: start.AddDate(0, 0, 2)}, nil)
+	mockRepo.On("UpsertItem", ctx, "trip-1", "tent", 1).Return(nil)
+	loans.On("ListByItems", ctx, []string{"tent"}).Return([]domain.Loan{
+		{ItemID: "tent", Item: &domain.Item{Name: "Tent"}, BorrowerContact: "Aki", LoanedAt: start.AddDate(0, 0, -3), DueAt: &due},
+	}, nil)
+
+	warnings, err := service.AddOrUpdateItem(ctx, "trip-1", "tent", 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, domain.TripWarningLoaned, warnings[0].Type)
+	mockRepo.AssertExpectations(t)
+}
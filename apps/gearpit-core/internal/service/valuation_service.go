@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
+)
+
+type valuationService struct {
+	rules     domain.DepreciationRuleRepository
+	dashboard domain.DashboardRepository
+	now       func() time.Time
+}
+
+func NewValuationService(rules domain.DepreciationRuleRepository, dashboard domain.DashboardRepository) domain.ValuationService {
+	return &valuationService{rules: rules, dashboard: dashboard, now: time.Now}
+}
+
+func (s *valuationService) ListRules(ctx context.Context) ([]domain.DepreciationRule, error) {
+	return s.rules.List(ctx)
+}
+
+func (s *valuationService) GetRule(ctx context.Context, category string) (*domain.DepreciationRule, error) {
+	return s.rules.GetByCategory(ctx, category)
+}
+
+func (s *valuationService) SaveRule(ctx context.Context, rule domain.DepreciationRule) (*domain.DepreciationRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Save(ctx, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (s *valuationService) DeleteRule(ctx context.Context, category string) error {
+	return s.rules.Delete(ctx, category)
+}
+
+func (s *valuationService) GetReport(ctx context.Context, groupBy domain.ValuationGroupBy) (*domain.ValuationReport, error) {
+	rules, err := s.rules.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := s.dashboard.ListValuationInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := domain.BuildValuationReport(inputs, rules, groupBy, s.now())
+	return &report, nil
+}
+
+func (s *valuationService) ExportReport(ctx context.Context, groupBy domain.ValuationGroupBy, format domain.ExportFormat) (*domain.ExportFile, error) {
+	report, err := s.GetReport(ctx, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	file := &domain.ExportFile{}
+	baseName := "gearpit-valuation-" + report.GeneratedAt.Format("20060102")
+
+	switch format {
+	case domain.ExportFormatJSON:
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return nil, fmt.Errorf("failed to encode valuation report: %w", err)
+		}
+		file.FileName = baseName + ".json"
+		file.ContentType = "application/json"
+	case domain.ExportFormatCSV:
+		if err := domain.WriteValuationCSV(&buf, *report); err != nil {
+			return nil, fmt.Errorf("failed to write valuation csv: %w", err)
+		}
+		file.FileName = baseName + ".csv"
+		file.ContentType = "text/csv"
+	case domain.ExportFormatHTML:
+		if err := domain.WriteValuationHTML(&buf, *report); err != nil {
+			return nil, fmt.Errorf("failed to render valuation report: %w", err)
+		}
+		file.FileName = baseName + ".html"
+		file.ContentType = "text/html; charset=utf-8"
+	default:
+		return nil, fmt.Errorf("%w: unsupported report format %q", domain.ErrInvalidInput, format)
+	}
+
+	file.Data = buf.Bytes()
+	return file, nil
+}
@@ -1,16 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/domain"
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/handler"
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure"
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure/repository"
+	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/infrastructure/storage"
 	"github.com/nordiwnd/gearpit/apps/gearpit-core/internal/service"
 )
 
@@ -64,6 +68,22 @@ func main() {
 		&domain.Trip{},
 		&domain.TripItem{},
 		&domain.UserProfile{},
+		&domain.PropertySchema{},
+		&domain.DepreciationRule{},
+		&domain.Attachment{},
+		&domain.Location{},
+		&domain.ItemMove{},
+		&domain.Loan{},
+		&domain.AuditEntry{},
+		&domain.CatalogProduct{},
+		&domain.ItemComponent{},
+		&domain.PCBuild{},
+		&domain.BuildPart{},
+		&domain.OdometerReading{},
+		&domain.ServiceRule{},
+		&domain.UsageMeter{},
+		&domain.UsageEntry{},
+		&domain.TripMeterUsage{},
 	); err != nil {
 		slog.Error("Failed to migrate database", "error", err)
 		os.Exit(1)
@@ -74,8 +94,12 @@ func main() {
 		infrastructure.SeedDB(db)
 	}
 	// Dependency Injection (DI) Setup
+	propertySchemaRepo := repository.NewPropertySchemaRepository(db)
+	propertySchemaService := service.NewPropertySchemaService(propertySchemaRepo)
+	propertySchemaHandler := handler.NewPropertySchemaHandler(propertySchemaService)
+
 	gearRepo := repository.NewGearRepository(db)
-	gearService := service.NewGearService(gearRepo)
+	gearService := service.NewGearService(gearRepo, propertySchemaRepo)
 	gearHandler := handler.NewGearHandler(gearService)
 	kitRepo := repository.NewKitRepository(db)
 	kitService := service.NewKitService(kitRepo)
@@ -93,17 +117,72 @@ func main() {
 	dashboardService := service.NewDashboardService(dashboardRepo)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
 
+	loanRepo := repository.NewLoanRepository(db)
 	tripRepo := repository.NewTripRepository(db)
-	tripService := service.NewTripService(tripRepo)
+	tripService := service.NewTripService(tripRepo, loanRepo)
 	tripHandler := handler.NewTripHandler(tripService)
 
 	profileRepo := repository.NewProfileRepository(db)
 	profileService := service.NewProfileService(profileRepo)
 	profileHandler := handler.NewProfileHandler(profileService)
 
+	exportService := service.NewExportService(gearRepo, kitRepo, loadoutRepo, tripRepo)
+	exportHandler := handler.NewExportHandler(exportService)
+
+	backupRepo := repository.NewBackupRepository(db)
+	backupService := service.NewBackupService(backupRepo)
+	backupHandler := handler.NewBackupHandler(backupService)
+
+	depreciationRuleRepo := repository.NewDepreciationRuleRepository(db)
+	valuationService := service.NewValuationService(depreciationRuleRepo, dashboardRepo)
+	valuationHandler := handler.NewValuationHandler(valuationService)
+
+	blobStorage, err := newBlobStorage()
+	if err != nil {
+		slog.Error("Failed to set up attachment storage", "error", err)
+		os.Exit(1)
+	}
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	attachmentService := service.NewAttachmentService(attachmentRepo, blobStorage)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+
+	loanService := service.NewLoanService(loanRepo, gearRepo, profileRepo)
+	loanHandler := handler.NewLoanHandler(loanService)
+
+	locationRepo := repository.NewLocationRepository(db)
+	locationService := service.NewLocationService(locationRepo, gearRepo)
+	locationHandler := handler.NewLocationHandler(locationService)
+
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepo, gearRepo, kitRepo, loadoutRepo, tripRepo, maintenanceRepo)
+	auditHandler := handler.NewAuditHandler(auditService)
+
+	catalogRepo := repository.NewCatalogRepository(db)
+	catalogService := service.NewCatalogService(catalogRepo, gearRepo)
+	catalogHandler := handler.NewCatalogHandler(catalogService)
+
+	pcBuildRepo := repository.NewPCBuildRepository(db)
+	pcBuildService := service.NewPCBuildService(pcBuildRepo, gearRepo)
+	pcBuildHandler := handler.NewPCBuildHandler(pcBuildService)
+
+	trashRetention := domain.DefaultTrashRetention
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		trashRetention = time.Duration(days) * 24 * time.Hour
+	}
+	trashRepo := repository.NewTrashRepository(db)
+	trashService := service.NewTrashService(trashRepo, attachmentService, trashRetention)
+	trashHandler := handler.NewTrashHandler(trashService)
+	go runTrashPurge(trashService, time.Hour)
+
 	// Router setup
 	mux := http.NewServeMux()
 
+	// Routes returning weights honour ?unit= and ?profileId= (see handler.DisplayUnitMiddleware)
+	displayUnit := handler.DisplayUnitMiddleware(profileService)
+	handleWeights := func(pattern string, h http.HandlerFunc) {
+		mux.Handle(pattern, displayUnit(h))
+	}
+
 	// Health Check
 	mux.HandleFunc("/api/v1/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -111,7 +190,7 @@ func main() {
 	})
 
 	// 1. Collection routes: /api/v1/gears
-	mux.HandleFunc("/api/v1/gears", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/gears", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			gearHandler.SearchItems(w, r)
@@ -124,8 +203,138 @@ func main() {
 		}
 	})
 
+	// Bulk import: /api/v1/gears/import
+	mux.HandleFunc("/api/v1/gears/import", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			gearHandler.ImportItems(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Ranked full-text search: /api/v1/gears/search?q=
+	handleWeights("/api/v1/gears/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.SearchRanked(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Typed property query: /api/v1/gears/by-property?category=Skis&waistWidth=gte:100
+	handleWeights("/api/v1/gears/by-property", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.FindByProperties(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Serial number lookup: /api/v1/gears/by-serial?serial=
+	handleWeights("/api/v1/gears/by-serial", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.FindBySerialNumber(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Warranties ending soon: /api/v1/gears/warranties?days=30
+	handleWeights("/api/v1/gears/warranties", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.ListExpiringWarranties(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Perishables expired or expiring soon: /api/v1/gears/expiring?days=30
+	handleWeights("/api/v1/gears/expiring", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.ListExpiringItems(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Likely duplicate items: /api/v1/gears/duplicates?minScore=0.7
+	handleWeights("/api/v1/gears/duplicates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.FindDuplicates(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Items carrying a barcode: /api/v1/gears/by-barcode?barcode=4901234567894
+	handleWeights("/api/v1/gears/by-barcode", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gearHandler.FindByBarcode(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	// 2. Item routes: /api/v1/gears/{id}
-	mux.HandleFunc("/api/v1/gears/", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/gears/", func(w http.ResponseWriter, r *http.Request) {
+		// /api/v1/gears/{id}/status の判定
+		if strings.HasSuffix(r.URL.Path, "/status") && r.Method == http.MethodPost {
+			gearHandler.ChangeStatus(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/cost") && r.Method == http.MethodGet {
+			gearHandler.GetItemCost(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/location") && r.Method == http.MethodGet {
+			locationHandler.Locate(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/move") && r.Method == http.MethodPost {
+			locationHandler.MoveItem(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/moves") && r.Method == http.MethodGet {
+			locationHandler.ListMoves(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/merge") && r.Method == http.MethodPost {
+			gearHandler.MergeItems(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/components") && (r.Method == http.MethodGet || r.Method == http.MethodPut) {
+			gearHandler.HandleComponents(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/meters") && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			gearHandler.HandleItemMeters(w, r)
+			return
+		}
+
 		switch r.Method {
 		case http.MethodPut:
 			gearHandler.UpdateItem(w, r)
@@ -138,8 +347,48 @@ func main() {
 		}
 	})
 
+	// Usage meters: PUT/DELETE /api/v1/meters/{id}, GET/POST /api/v1/meters/{id}/usage
+	mux.HandleFunc("/api/v1/meters/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/usage") && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			gearHandler.HandleMeterUsage(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut, http.MethodDelete:
+			gearHandler.HandleMeter(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Property Schema Routes
+	mux.HandleFunc("/api/v1/property-schemas", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			propertySchemaHandler.ListSchemas(w, r)
+		case http.MethodPost:
+			propertySchemaHandler.CreateSchema(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/property-schemas/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPut, http.MethodDelete:
+			propertySchemaHandler.HandleSchema(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	// Kit Routes
-	mux.HandleFunc("/api/v1/kits", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/kits", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			kitHandler.ListKits(w, r)
@@ -151,10 +400,12 @@ func main() {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
-	mux.HandleFunc("/api/v1/kits/", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/kits/", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			kitHandler.GetKit(w, r)
+		case http.MethodDelete:
+			kitHandler.DeleteKit(w, r)
 		case http.MethodOptions:
 			w.WriteHeader(http.StatusOK)
 		default:
@@ -163,7 +414,7 @@ func main() {
 	})
 
 	// Loadout Routes
-	mux.HandleFunc("/api/v1/loadouts", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/loadouts", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			loadoutHandler.ListLoadouts(w, r)
@@ -176,7 +427,7 @@ func main() {
 		}
 	})
 
-	mux.HandleFunc("/api/v1/loadouts/", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/loadouts/", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			loadoutHandler.GetLoadout(w, r)
@@ -202,7 +453,16 @@ func main() {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+	// /api/v1/maintenance/item/{itemId} (GET logs), .../odometer (GET, POST), .../status (GET)
 	mux.HandleFunc("/api/v1/maintenance/item/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/odometer") && (r.Method == http.MethodGet || r.Method == http.MethodPost) {
+			maintenanceHandler.HandleOdometer(w, r)
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/status") && r.Method == http.MethodGet {
+			maintenanceHandler.GetServiceStatus(w, r)
+			return
+		}
 		switch r.Method {
 		case http.MethodGet:
 			maintenanceHandler.GetItemLogs(w, r)
@@ -212,6 +472,40 @@ func main() {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+	// Services due soon or overdue across all items
+	handleWeights("/api/v1/maintenance/due", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			maintenanceHandler.ListDueServices(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// Service rules: GET (category rules) and POST /api/v1/maintenance/rules, PUT/DELETE /api/v1/maintenance/rules/{id}
+	handleWeights("/api/v1/maintenance/rules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			maintenanceHandler.ListServiceRules(w, r)
+		case http.MethodPost:
+			maintenanceHandler.CreateServiceRule(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	handleWeights("/api/v1/maintenance/rules/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut, http.MethodDelete:
+			maintenanceHandler.HandleServiceRule(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
 	mux.HandleFunc("/api/v1/maintenance/", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPut:
@@ -226,7 +520,7 @@ func main() {
 	})
 
 	// Dashboard Routes
-	mux.HandleFunc("/api/v1/dashboard/stats", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/dashboard/stats", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			dashboardHandler.GetStats(w, r)
@@ -238,7 +532,7 @@ func main() {
 	})
 
 	// Trips Routes
-	mux.HandleFunc("/api/v1/trips", func(w http.ResponseWriter, r *http.Request) {
+	handleWeights("/api/v1/trips", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodGet:
 			tripHandler.ListTrips(w, r)
@@ -251,12 +545,28 @@ func main() {
 		}
 	})
 
-	mux.HandleFunc("/api/v1/trips/", func(w http.ResponseWriter, r *http.Request) {
+	// Consumables to buy for upcoming trips: /api/v1/trips/shopping-list?days=30
+	handleWeights("/api/v1/trips/shopping-list", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			tripHandler.ShoppingList(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	handleWeights("/api/v1/trips/", func(w http.ResponseWriter, r *http.Request) {
 		// /api/v1/trips/{id}/items の判定
 		if strings.Contains(r.URL.Path, "/items") {
 			tripHandler.HandleTripItems(w, r)
 			return
 		}
+		if strings.HasSuffix(r.URL.Path, "/meters") && (r.Method == http.MethodGet || r.Method == http.MethodPut) {
+			tripHandler.HandleTripMeters(w, r)
+			return
+		}
 		// /api/v1/trips/{id}/complete の判定
 		if strings.HasSuffix(r.URL.Path, "/complete") && r.Method == http.MethodPost {
 			tripHandler.CompleteTrip(w, r)
@@ -303,6 +613,251 @@ func main() {
 		}
 	})
 
+	// Export Routes: /api/v1/export/{items|kits|loadouts|trips}[/{id}]
+	mux.HandleFunc("/api/v1/export/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			exportHandler.Export(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Backup Routes
+	mux.HandleFunc("/api/v1/backup", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			backupHandler.CreateBackup(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/backup/restore", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			backupHandler.RestoreBackup(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Trash Routes
+	mux.HandleFunc("/api/v1/trash", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			trashHandler.ListTrash(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// /api/v1/trash/{entity}/{id}/restore (POST), /api/v1/trash/{entity}/{id} (DELETE = purge now)
+	mux.HandleFunc("/api/v1/trash/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodDelete:
+			trashHandler.HandleEntry(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// History Routes
+	// /api/v1/history/{entity}/{id} (GET), /api/v1/history/{entity}/{id}/{entryId}/revert (POST)
+	mux.HandleFunc("/api/v1/history/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPost:
+			auditHandler.HandleHistory(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Product Catalog Routes
+	mux.HandleFunc("/api/v1/catalog/import", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			catalogHandler.ImportCatalog(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// Scan-to-add: /api/v1/catalog/lookup?barcode=4901234567894
+	handleWeights("/api/v1/catalog/lookup", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			catalogHandler.LookupBarcode(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Valuation Routes
+	mux.HandleFunc("/api/v1/reports/valuation", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			valuationHandler.GetReport(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/depreciation-rules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			valuationHandler.ListRules(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/depreciation-rules/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPut, http.MethodDelete:
+			valuationHandler.HandleRule(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Attachment Routes
+	mux.HandleFunc("/api/v1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			attachmentHandler.ListAttachments(w, r)
+		case http.MethodPost:
+			attachmentHandler.UploadAttachment(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// /api/v1/attachments/{id} (GET download, DELETE), /api/v1/attachments/{id}/thumbnail (GET)
+	mux.HandleFunc("/api/v1/attachments/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodDelete:
+			attachmentHandler.HandleAttachment(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Location Routes
+	mux.HandleFunc("/api/v1/locations", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			locationHandler.ListLocations(w, r)
+		case http.MethodPost:
+			locationHandler.CreateLocation(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// Where is it: /api/v1/locations/where?q=stove
+	mux.HandleFunc("/api/v1/locations/where", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			locationHandler.WhereIs(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/locations/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPut, http.MethodDelete:
+			locationHandler.HandleLocation(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Loan Routes
+	mux.HandleFunc("/api/v1/loans", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			loanHandler.ListLoans(w, r)
+		case http.MethodPost:
+			loanHandler.CreateLoan(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/api/v1/loans/overdue", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			loanHandler.ListOverdue(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// /api/v1/loans/{id} (GET, PUT, DELETE), /api/v1/loans/{id}/return (POST)
+	mux.HandleFunc("/api/v1/loans/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPut, http.MethodDelete, http.MethodPost:
+			loanHandler.HandleLoan(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// PC Build Routes
+	mux.HandleFunc("/api/v1/pc-builds", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			pcBuildHandler.ListBuilds(w, r)
+		case http.MethodPost:
+			pcBuildHandler.CreateBuild(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	// /api/v1/pc-builds/{id} (GET, PUT, DELETE), /api/v1/pc-builds/{id}/report (GET)
+	mux.HandleFunc("/api/v1/pc-builds/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodPut, http.MethodDelete:
+			pcBuildHandler.HandleBuild(w, r)
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	// Start server with CORS middleware
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -310,18 +865,56 @@ func main() {
 	}
 	slog.Info("Starting server", "port", port)
 
-	if err := http.ListenAndServe(":"+port, enableCORS(mux)); err != nil {
+	if err := http.ListenAndServe(":"+port, enableCORS(handler.ActorMiddleware(mux))); err != nil {
 		slog.Error("Server failed", "error", err)
 		os.Exit(1)
 	}
 }
 
+// runTrashPurge removes expired trash once at startup and then every interval.
+func runTrashPurge(trash domain.TrashService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		result, err := trash.PurgeExpired(context.Background())
+		if err != nil {
+			slog.Error("Failed to purge trash", "error", err)
+		} else {
+			slog.Info("Purged expired trash", "result", result)
+		}
+		<-ticker.C
+	}
+}
+
+// newBlobStorage picks attachment storage from ATTACHMENT_STORAGE: "local" (default,
+// files below ATTACHMENT_DIR) or "s3" (S3_ENDPOINT, S3_BUCKET, S3_REGION, S3_ACCESS_KEY, S3_SECRET_KEY).
+func newBlobStorage() (domain.BlobStorage, error) {
+	switch os.Getenv("ATTACHMENT_STORAGE") {
+	case "", "local":
+		dir := os.Getenv("ATTACHMENT_DIR")
+		if dir == "" {
+			dir = "./data/attachments"
+		}
+		return storage.NewLocalStorage(dir)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    os.Getenv("S3_REGION"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown ATTACHMENT_STORAGE %q", os.Getenv("ATTACHMENT_STORAGE"))
+	}
+}
+
 // enableCORS is a middleware to allow cross-origin requests from the frontend.
 func enableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+handler.ActorHeader)
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)